@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"project-management-backend/services"
+	"project-management-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APITokenController struct {
+	Service services.APITokenService
+}
+
+func NewAPITokenController(service services.APITokenService) *APITokenController {
+	return &APITokenController{Service: service}
+}
+
+// ListPersonalTokens - Daftar personal access token aktif milik user
+func (tc *APITokenController) ListPersonalTokens(c *gin.Context) {
+	currentUser := GetCurrentUser(c)
+	tokens, err := tc.Service.ListPersonalTokens(currentUser)
+	if err != nil {
+		utils.Error(currentUser.ID, "list_api_tokens", "api_tokens", 0, err.Error(), "")
+		utils.RespondError(c, 500, err)
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Daftar token berhasil diambil",
+		Data:    tokens,
+	})
+}
+
+// CreatePersonalToken - Buat personal access token, token hanya ditampilkan sekali
+func (tc *APITokenController) CreatePersonalToken(c *gin.Context) {
+	var input services.CreateAPITokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+	created, err := tc.Service.CreatePersonalToken(input, currentUser)
+	if err != nil {
+		utils.Error(currentUser.ID, "CREATE_API_TOKEN", "api_tokens", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	utils.ActivityLog(currentUser.ID, "CREATE_API_TOKEN", "api_tokens", created.APIToken.ID, nil, created.APIToken)
+
+	c.JSON(201, APIResponse{
+		Success: true,
+		Code:    201,
+		Message: "Token berhasil dibuat, simpan token karena tidak akan ditampilkan lagi",
+		Data:    created,
+	})
+}
+
+// RevokePersonalToken - Cabut personal access token
+func (tc *APITokenController) RevokePersonalToken(c *gin.Context) {
+	tokenID, err := ParseUintParam(c, "token_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+	if err := tc.Service.RevokePersonalToken(tokenID, currentUser); err != nil {
+		utils.Error(currentUser.ID, "REVOKE_API_TOKEN", "api_tokens", tokenID, err.Error(), "")
+		utils.RespondError(c, 404, err)
+		return
+	}
+
+	utils.ActivityLog(currentUser.ID, "REVOKE_API_TOKEN", "api_tokens", tokenID, nil, nil)
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Token berhasil dicabut",
+	})
+}
+
+// ListServiceKeys - Daftar service key aktif sebuah workspace
+func (tc *APITokenController) ListServiceKeys(c *gin.Context) {
+	workspaceID, err := ParseUintParam(c, "workspace_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+	keys, err := tc.Service.ListServiceKeys(workspaceID, currentUser)
+	if err != nil {
+		utils.Error(currentUser.ID, "list_service_keys", "api_tokens", workspaceID, err.Error(), "")
+		utils.RespondError(c, 403, err)
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Daftar service key berhasil diambil",
+		Data:    keys,
+	})
+}
+
+// CreateServiceKey - Buat service key untuk workspace, key hanya ditampilkan sekali
+func (tc *APITokenController) CreateServiceKey(c *gin.Context) {
+	workspaceID, err := ParseUintParam(c, "workspace_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	var input services.CreateAPITokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+	created, err := tc.Service.CreateServiceKey(workspaceID, input, currentUser)
+	if err != nil {
+		utils.Error(currentUser.ID, "CREATE_SERVICE_KEY", "api_tokens", workspaceID, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	utils.ActivityLog(currentUser.ID, "CREATE_SERVICE_KEY", "api_tokens", created.APIToken.ID, nil, created.APIToken)
+
+	c.JSON(201, APIResponse{
+		Success: true,
+		Code:    201,
+		Message: "Service key berhasil dibuat, simpan key karena tidak akan ditampilkan lagi",
+		Data:    created,
+	})
+}
+
+// RevokeServiceKey - Cabut service key workspace
+func (tc *APITokenController) RevokeServiceKey(c *gin.Context) {
+	workspaceID, err := ParseUintParam(c, "workspace_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	tokenID, err := ParseUintParam(c, "token_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+	if err := tc.Service.RevokeServiceKey(workspaceID, tokenID, currentUser); err != nil {
+		utils.Error(currentUser.ID, "REVOKE_SERVICE_KEY", "api_tokens", tokenID, err.Error(), "")
+		utils.RespondError(c, 404, err)
+		return
+	}
+
+	utils.ActivityLog(currentUser.ID, "REVOKE_SERVICE_KEY", "api_tokens", tokenID, nil, nil)
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Service key berhasil dicabut",
+	})
+}
@@ -1,11 +1,13 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/services"
 	"project-management-backend/utils"
@@ -38,7 +40,7 @@ func (c *AttendanceController) SubmitAttendance(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("workspace_id")
 	workspaceID, err := strconv.ParseUint(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeInvalidParam, "workspace_id")
 		return
 	}
 
@@ -46,7 +48,11 @@ func (c *AttendanceController) SubmitAttendance(ctx *gin.Context) {
 
 	form, err := ctx.MultipartForm()
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Error parsing form: %v", err)})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeFormInvalid, err.Error())
+		return
+	}
+	if len(form.Value["activity"]) == 0 {
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeParamRequired, "activity")
 		return
 	}
 
@@ -63,21 +69,21 @@ func (c *AttendanceController) SubmitAttendance(ctx *gin.Context) {
 	}
 
 	if err := c.service.SubmitAttendance(&attendance); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to submit attendance: %v", err)})
+		respondAttendanceError(ctx, currentUser.ID, "submit_attendance", err, i18n.CodeAttendanceSubmitFailed)
 		return
 	}
 
 	files := form.File["images"]
 	for _, file := range files {
 		if _, err := c.attendanceImgService.UploadImage(attendance.ID, file); err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to upload image: %v", err)})
+			respondAttendanceError(ctx, currentUser.ID, "upload_attendance_image", err, i18n.CodeAttendanceImageUploadFailed)
 			return
 		}
 	}
 
 	createdAttendance, err := c.service.GetAttendanceByID(attendance.ID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve created attendance"})
+		utils.RespondCode(ctx, http.StatusInternalServerError, i18n.CodeFetchFailed, "attendance")
 		return
 	}
 	response := utils.ToAttendanceResponse(*createdAttendance)
@@ -94,32 +100,36 @@ func (c *AttendanceController) ExportAttendances(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("workspace_id")
 	workspaceID, err := strconv.ParseUint(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeInvalidParam, "workspace_id")
 		return
 	}
 
 	currentUser := GetCurrentUser(ctx)
 	date := ctx.Query("date")
 	if date == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Date query parameter is required"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeParamRequired, "date")
 		return
 	}
 
 	workspace, err := c.workspaceService.GetByID(uint(workspaceID), currentUser)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get workspace details"})
+		utils.RespondCode(ctx, http.StatusInternalServerError, i18n.CodeFetchFailed, "workspace")
 		return
 	}
 
 	attendances, err := c.service.GetAttendancesForExport(uint(workspaceID), date)
+	if errors.Is(err, services.ErrAttendanceDateInvalid) {
+		utils.RespondError(ctx, http.StatusBadRequest, err)
+		return
+	}
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get attendances for export"})
+		utils.RespondCode(ctx, http.StatusInternalServerError, i18n.CodeFetchFailed, "attendance")
 		return
 	}
 
-	pdfBytes, err := c.pdfService.CreateAttendanceReportPDF(attendances, workspace.Name, date)
+	pdfBytes, err := c.pdfService.CreateAttendanceReportPDF(attendances, workspace, date, utils.RequestLanguage(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -127,3 +137,16 @@ func (c *AttendanceController) ExportAttendances(ctx *gin.Context) {
 	ctx.Header("Content-Disposition", "attachment; filename="+fileName)
 	ctx.Data(http.StatusOK, "application/pdf", pdfBytes)
 }
+
+// respondAttendanceError answers coded (validation) errors with 400 and
+// everything else with 500 and fallbackCode, logging the original error.
+func respondAttendanceError(ctx *gin.Context, userID uint, action string, err error, fallbackCode string) {
+	var coded *i18n.Error
+	if errors.As(err, &coded) {
+		utils.RespondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.Error(userID, action, "attendance", 0, err.Error(), "")
+	utils.RespondCode(ctx, http.StatusInternalServerError, fallbackCode)
+}
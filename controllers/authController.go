@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"fmt"
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/services"
 	"project-management-backend/utils"
@@ -30,7 +31,7 @@ func (ac *AuthController) Register(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&input); err != nil {
 		utils.Error(0, "bind_json", "auth", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -96,40 +97,415 @@ func (ac *AuthController) Login(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&input); err != nil {
 		utils.Error(0, "bind_json", "auth", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
-	token, user, err := ac.AuthService.Login(input.Email, input.Password)
+	result, err := ac.AuthService.Login(input.Email, input.Password, sessionClient(c))
 	if err != nil {
 		utils.Error(0, "login", "auth", 0, err.Error(), "")
-		c.JSON(401, gin.H{"error": err.Error()})
+		utils.RespondError(c, 401, err)
 		return
 	}
 
+	// Langkah kedua: kode TOTP atau recovery code lewat /auth/login/2fa
+	if result.Tokens == nil {
+		c.JSON(200, APIResponse{
+			Success: true,
+			Code:    200,
+			Message: "Masukkan kode autentikasi dua faktor",
+			Data: gin.H{
+				"two_factor_required": true,
+				"challenge_token":     result.ChallengeToken,
+			},
+		})
+		return
+	}
+
+	respondLogin(c, result.Tokens, result.User)
+}
+
+// LoginTwoFactor - Selesaikan login dengan kode autentikasi dua faktor
+func (ac *AuthController) LoginTwoFactor(c *gin.Context) {
+	var input struct {
+		ChallengeToken string `json:"challenge_token" binding:"required"`
+		Code           string `json:"code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(0, "bind_json", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	tokens, user, err := ac.AuthService.LoginTwoFactor(input.ChallengeToken, input.Code, sessionClient(c))
+	if err != nil {
+		utils.Error(0, "login_two_factor", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 401, err)
+		return
+	}
+
+	respondLogin(c, tokens, user)
+}
+
+func sessionClient(c *gin.Context) services.SessionClient {
+	return services.SessionClient{UserAgent: c.Request.UserAgent(), IPAddress: c.ClientIP()}
+}
+
+func respondLogin(c *gin.Context, tokens *services.AuthTokens, user *models.User) {
 	c.JSON(200, gin.H{
 		"success": true,
 		"code":    200,
 		"message": "Login berhasil",
 		"data": gin.H{
-			"token": token,
+			"token":         tokens.AccessToken,
+			"refresh_token": tokens.RefreshToken,
+			"expires_in":    tokens.ExpiresIn,
+			"session_id":    tokens.SessionID,
 			"user": gin.H{
-				"id":       user.ID,
-				"name":     user.Name,
-				"email":    user.Email,
-				"role":     user.Role,
-				"position": user.Position,
+				"id":                 user.ID,
+				"name":               user.Name,
+				"email":              user.Email,
+				"role":               user.Role,
+				"position":           user.Position,
+				"two_factor_enabled": user.TwoFactorEnabled,
 			},
 		},
 	})
 }
 
+// Refresh - Tukar refresh token dengan pasangan token baru
+func (ac *AuthController) Refresh(c *gin.Context) {
+	var input struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(0, "bind_json", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	tokens, err := ac.AuthService.Refresh(input.RefreshToken)
+	if err != nil {
+		utils.Error(0, "refresh_token", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 401, err)
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Token berhasil diperbarui",
+		Data:    tokens,
+	})
+}
+
+// Logout - Cabut sesi yang sedang dipakai
+func (ac *AuthController) Logout(c *gin.Context) {
+	currentUser := GetCurrentUser(c)
+	if err := ac.AuthService.Logout(c.GetUint("sessionID")); err != nil {
+		utils.Error(currentUser.ID, "logout", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 500, err)
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Logout berhasil",
+	})
+}
+
+// LogoutAll - Cabut semua sesi milik user
+func (ac *AuthController) LogoutAll(c *gin.Context) {
+	currentUser := GetCurrentUser(c)
+	if err := ac.AuthService.LogoutAll(currentUser.ID); err != nil {
+		utils.Error(currentUser.ID, "logout_all", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 500, err)
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Logout dari semua perangkat berhasil",
+	})
+}
+
+// ListSessions - Daftar sesi aktif milik user
+func (ac *AuthController) ListSessions(c *gin.Context) {
+	currentUser := GetCurrentUser(c)
+	sessions, err := ac.AuthService.ListSessions(currentUser.ID)
+	if err != nil {
+		utils.Error(currentUser.ID, "list_sessions", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 500, err)
+		return
+	}
+
+	currentSessionID := c.GetUint("sessionID")
+	data := make([]gin.H, 0, len(sessions))
+	for _, session := range sessions {
+		data = append(data, gin.H{
+			"id":           session.ID,
+			"user_agent":   session.UserAgent,
+			"ip_address":   session.IPAddress,
+			"last_used_at": session.LastUsedAt,
+			"expires_at":   session.ExpiresAt,
+			"created_at":   session.CreatedAt,
+			"current":      session.ID == currentSessionID,
+		})
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Daftar sesi berhasil diambil",
+		Data:    data,
+	})
+}
+
+// RevokeSession - Cabut satu sesi milik user
+func (ac *AuthController) RevokeSession(c *gin.Context) {
+	sessionID, err := ParseUintParam(c, "session_id")
+	if err != nil {
+		utils.Error(0, "parse_session_id", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+	if err := ac.AuthService.RevokeSession(currentUser.ID, sessionID); err != nil {
+		utils.Error(currentUser.ID, "revoke_session", "auth", sessionID, err.Error(), "")
+		utils.RespondError(c, 404, err)
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Sesi berhasil dicabut",
+	})
+}
+
+// ChangePassword - Ganti password dengan memasukkan password saat ini
+func (ac *AuthController) ChangePassword(c *gin.Context) {
+	var input struct {
+		CurrentPassword string `json:"current_password" binding:"required"`
+		NewPassword     string `json:"new_password" binding:"required,min=6"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(0, "bind_json", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+	if err := ac.AuthService.ChangePassword(currentUser, c.GetUint("sessionID"), input.CurrentPassword, input.NewPassword); err != nil {
+		utils.Error(currentUser.ID, "change_password", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	utils.ActivityLog(currentUser.ID, "CHANGE_PASSWORD", "users", currentUser.ID, nil, nil)
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Password berhasil diubah",
+	})
+}
+
+// ForgotPassword - Kirim tautan reset password ke email user
+func (ac *AuthController) ForgotPassword(c *gin.Context) {
+	var input struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(0, "bind_json", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	if err := ac.AuthService.ForgotPassword(input.Email, c.ClientIP()); err != nil {
+		utils.Error(0, "forgot_password", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 500, err)
+		return
+	}
+
+	// Respons selalu sama agar tidak membocorkan email yang terdaftar
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Jika email terdaftar, tautan reset password telah dikirim",
+	})
+}
+
+// ResetPassword - Atur password baru menggunakan token reset
+func (ac *AuthController) ResetPassword(c *gin.Context) {
+	var input struct {
+		Token       string `json:"token" binding:"required"`
+		NewPassword string `json:"new_password" binding:"required,min=6"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(0, "bind_json", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	if err := ac.AuthService.ResetPassword(input.Token, input.NewPassword); err != nil {
+		utils.Error(0, "reset_password", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Password berhasil direset, silakan login kembali",
+	})
+}
+
+// TwoFactorStatus - Status autentikasi dua faktor user
+func (ac *AuthController) TwoFactorStatus(c *gin.Context) {
+	currentUser := GetCurrentUser(c)
+
+	var remaining int64
+	if currentUser.TwoFactorEnabled {
+		count, err := ac.AuthService.RemainingRecoveryCodes(currentUser.ID)
+		if err != nil {
+			utils.Error(currentUser.ID, "two_factor_status", "auth", 0, err.Error(), "")
+			utils.RespondError(c, 500, err)
+			return
+		}
+		remaining = count
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Status autentikasi dua faktor berhasil diambil",
+		Data: gin.H{
+			"enabled":                  currentUser.TwoFactorEnabled,
+			"recovery_codes_remaining": remaining,
+		},
+	})
+}
+
+// SetupTwoFactor - Mulai enrollment TOTP, kembalikan secret dan QR code
+func (ac *AuthController) SetupTwoFactor(c *gin.Context) {
+	currentUser := GetCurrentUser(c)
+	setup, err := ac.AuthService.SetupTwoFactor(currentUser)
+	if err != nil {
+		utils.Error(currentUser.ID, "setup_two_factor", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Pindai QR code dengan aplikasi authenticator lalu konfirmasi dengan kode",
+		Data:    setup,
+	})
+}
+
+// EnableTwoFactor - Konfirmasi enrollment dengan kode TOTP
+func (ac *AuthController) EnableTwoFactor(c *gin.Context) {
+	var input struct {
+		Code string `json:"code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(0, "bind_json", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+	codes, err := ac.AuthService.EnableTwoFactor(currentUser, input.Code)
+	if err != nil {
+		utils.Error(currentUser.ID, "enable_two_factor", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	utils.ActivityLog(currentUser.ID, "ENABLE_TWO_FACTOR", "users", currentUser.ID, nil, nil)
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Autentikasi dua faktor berhasil diaktifkan, simpan recovery code di tempat aman",
+		Data:    gin.H{"recovery_codes": codes},
+	})
+}
+
+// DisableTwoFactor - Matikan autentikasi dua faktor
+func (ac *AuthController) DisableTwoFactor(c *gin.Context) {
+	var input struct {
+		Password string `json:"password" binding:"required"`
+		Code     string `json:"code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(0, "bind_json", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+	if err := ac.AuthService.DisableTwoFactor(currentUser, input.Password, input.Code); err != nil {
+		utils.Error(currentUser.ID, "disable_two_factor", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	utils.ActivityLog(currentUser.ID, "DISABLE_TWO_FACTOR", "users", currentUser.ID, nil, nil)
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Autentikasi dua faktor berhasil dinonaktifkan",
+	})
+}
+
+// RegenerateRecoveryCodes - Buat ulang recovery code, kode lama tidak berlaku
+func (ac *AuthController) RegenerateRecoveryCodes(c *gin.Context) {
+	var input struct {
+		Code string `json:"code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(0, "bind_json", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+	codes, err := ac.AuthService.RegenerateRecoveryCodes(currentUser, input.Code)
+	if err != nil {
+		utils.Error(currentUser.ID, "regenerate_recovery_codes", "auth", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Recovery code berhasil dibuat ulang",
+		Data:    gin.H{"recovery_codes": codes},
+	})
+}
+
 // GetProfile - Get user profile
 func (ac *AuthController) GetProfile(c *gin.Context) {
 	user, exists := c.Get("currentUser")
 	if !exists {
 		utils.Error(0, "get_profile", "auth", 0, "User not authenticated", "")
-		c.JSON(401, gin.H{"error": "User tidak terautentikasi"})
+		utils.RespondCode(c, 401, i18n.CodeUnauthenticated)
 		return
 	}
 
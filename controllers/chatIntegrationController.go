@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"project-management-backend/services"
+	"project-management-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ChatIntegrationController struct {
+	Service services.ChatIntegrationService
+}
+
+func NewChatIntegrationController(service services.ChatIntegrationService) *ChatIntegrationController {
+	return &ChatIntegrationController{Service: service}
+}
+
+func (cc *ChatIntegrationController) ListIntegrations(c *gin.Context) {
+	workspaceID, err := ParseUintParam(c, "workspace_id")
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	integrations, err := cc.Service.List(workspaceID)
+	if err != nil {
+		utils.RespondCode(c, http.StatusInternalServerError, i18n.CodeFetchFailed, "chat integration")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "List integrasi chat berhasil diambil",
+		Data: gin.H{
+			"integrations": integrations,
+			"providers":    models.ChatProviders,
+			"events":       models.ChatEvents,
+		},
+	})
+}
+
+func (cc *ChatIntegrationController) CreateIntegration(c *gin.Context) {
+	workspaceID, err := ParseUintParam(c, "workspace_id")
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var input services.ChatIntegrationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	integration, err := cc.Service.Create(workspaceID, input, currentUser)
+	if err != nil {
+		utils.Error(currentUser.ID, "CREATE_CHAT_INTEGRATION", "workspace_chat_integrations", workspaceID, err.Error(), "")
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+	utils.ActivityLog(currentUser.ID, "CREATE_CHAT_INTEGRATION", "workspace_chat_integrations", integration.ID, nil, integration)
+
+	c.JSON(http.StatusCreated, APIResponse{
+		Success: true,
+		Code:    http.StatusCreated,
+		Message: "Integrasi chat berhasil dibuat",
+		Data:    integration,
+	})
+}
+
+func (cc *ChatIntegrationController) UpdateIntegration(c *gin.Context) {
+	workspaceID, integrationID, ok := parseChatIntegrationParams(c)
+	if !ok {
+		return
+	}
+
+	var input services.ChatIntegrationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	integration, err := cc.Service.Update(workspaceID, integrationID, input)
+	if err != nil {
+		utils.Error(currentUser.ID, "UPDATE_CHAT_INTEGRATION", "workspace_chat_integrations", integrationID, err.Error(), "")
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+	utils.ActivityLog(currentUser.ID, "UPDATE_CHAT_INTEGRATION", "workspace_chat_integrations", integration.ID, nil, integration)
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Integrasi chat berhasil diupdate",
+		Data:    integration,
+	})
+}
+
+func (cc *ChatIntegrationController) DeleteIntegration(c *gin.Context) {
+	workspaceID, integrationID, ok := parseChatIntegrationParams(c)
+	if !ok {
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	if err := cc.Service.Delete(workspaceID, integrationID); err != nil {
+		utils.Error(currentUser.ID, "DELETE_CHAT_INTEGRATION", "workspace_chat_integrations", integrationID, err.Error(), "")
+		utils.RespondError(c, http.StatusNotFound, err)
+		return
+	}
+	utils.ActivityLog(currentUser.ID, "DELETE_CHAT_INTEGRATION", "workspace_chat_integrations", integrationID, nil, nil)
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Integrasi chat berhasil dihapus",
+	})
+}
+
+func (cc *ChatIntegrationController) SendTest(c *gin.Context) {
+	workspaceID, integrationID, ok := parseChatIntegrationParams(c)
+	if !ok {
+		return
+	}
+
+	if err := cc.Service.SendTest(workspaceID, integrationID); err != nil {
+		status := http.StatusNotFound
+		var coded *i18n.Error
+		if errors.As(err, &coded) && coded.Code == i18n.CodeChatTestFailed {
+			status = http.StatusBadGateway
+		}
+		utils.RespondError(c, status, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Pesan test berhasil dikirim",
+	})
+}
+
+func parseChatIntegrationParams(c *gin.Context) (uint, uint, bool) {
+	workspaceID, err := ParseUintParam(c, "workspace_id")
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return 0, 0, false
+	}
+
+	integrationID, err := ParseUintParam(c, "integration_id")
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return 0, 0, false
+	}
+
+	return workspaceID, integrationID, true
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"net/http"
+	"project-management-backend/i18n"
 	"project-management-backend/services"
 	"project-management-backend/utils"
 
@@ -22,7 +23,7 @@ func (dc *DashboardController) GetUserDashboard(c *gin.Context) {
 	tasks, err := dc.Service.GetAllTasksForUser(currentUser.ID)
 	if err != nil {
 		utils.Error(currentUser.ID, "get_user_dashboard", "dashboard", 0, err.Error(), "")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get dashboard data"})
+		utils.RespondCode(c, http.StatusInternalServerError, i18n.CodeFetchFailed, "dashboard")
 		return
 	}
 
@@ -42,7 +43,7 @@ func (dc *DashboardController) GetAdminDashboard(c *gin.Context) {
 	tasks, err := dc.Service.GetAllTaskForAdmin()
 	if err != nil {
 		utils.Error(currentUser.ID, "get_admin_dashboard", "dashboard", 0, err.Error(), "")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get dashboard data"})
+		utils.RespondCode(c, http.StatusInternalServerError, i18n.CodeFetchFailed, "dashboard")
 		return
 	}
 
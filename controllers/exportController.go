@@ -1,10 +1,13 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"project-management-backend/i18n"
 	"project-management-backend/services"
+	"project-management-backend/utils"
 
 	"github.com/gin-gonic/gin"
 )
@@ -23,15 +26,15 @@ func NewExportController(projectService services.ProjectService) *ExportControll
 func (c *ExportController) ExportWeeklyBackward(ctx *gin.Context) {
 	projectID, err := strconv.Atoi(ctx.Param("project_id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "project ID tidak ditemukan"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeParamRequired, "project_id")
 		return
 	}
 
 	currentUser := GetCurrentUser(ctx)
 
-	pdfBytes, err := c.projectService.ExportWeeklyBackward(uint(projectID), currentUser.ID)
+	pdfBytes, err := c.projectService.ExportWeeklyBackward(uint(projectID), currentUser.ID, utils.RequestLanguage(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -43,15 +46,15 @@ func (c *ExportController) ExportWeeklyBackward(ctx *gin.Context) {
 func (c *ExportController) ExportWeeklyForward(ctx *gin.Context) {
 	projectID, err := strconv.Atoi(ctx.Param("project_id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "project ID tidak ditemukan"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeParamRequired, "project_id")
 		return
 	}
 
 	currentUser := GetCurrentUser(ctx)
 
-	pdfBytes, err := c.projectService.ExportWeeklyForward(uint(projectID), currentUser.ID)
+	pdfBytes, err := c.projectService.ExportWeeklyForward(uint(projectID), currentUser.ID, utils.RequestLanguage(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -63,15 +66,15 @@ func (c *ExportController) ExportWeeklyForward(ctx *gin.Context) {
 func (c *ExportController) ExportDaily(ctx *gin.Context) {
 	projectID, err := strconv.Atoi(ctx.Param("project_id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "project ID tidak ditemukan"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeParamRequired, "project_id")
 		return
 	}
 
 	currentUser := GetCurrentUser(ctx)
 
-	pdfBytes, err := c.projectService.ExportDaily(uint(projectID), currentUser.ID)
+	pdfBytes, err := c.projectService.ExportDaily(uint(projectID), currentUser.ID, utils.RequestLanguage(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -82,18 +85,120 @@ func (c *ExportController) ExportDaily(ctx *gin.Context) {
 func (c *ExportController) ExportMonitoring(ctx *gin.Context) {
 	projectID, err := strconv.Atoi(ctx.Param("project_id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "project ID tidak ditemukan"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeParamRequired, "project_id")
 		return
 	}
 
 	currentUser := GetCurrentUser(ctx)
 
-	pdfBytes, err := c.projectService.ExportMonitoring(uint(projectID), currentUser.ID)
+	pdfBytes, err := c.projectService.ExportMonitoring(uint(projectID), currentUser.ID, utils.RequestLanguage(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	ctx.Header("Content-Disposition", "attachment; filename=project_report_weekly_monitoring.pdf")
 	ctx.Data(http.StatusOK, "application/pdf", pdfBytes)
 }
+
+// JSON handlers returning the same data the PDF exports are built from.
+
+func (c *ExportController) WeeklyBackwardData(ctx *gin.Context) {
+	projectID, err := ParseUintParam(ctx, "project_id")
+	if err != nil {
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeInvalidParam, "project_id")
+		return
+	}
+
+	report, err := c.projectService.GetWeeklyBackwardData(projectID)
+	if err != nil {
+		respondReportDataError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Data report weekly backward berhasil diambil",
+		Data:    report,
+	})
+}
+
+func (c *ExportController) WeeklyForwardData(ctx *gin.Context) {
+	projectID, err := ParseUintParam(ctx, "project_id")
+	if err != nil {
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeInvalidParam, "project_id")
+		return
+	}
+
+	report, err := c.projectService.GetWeeklyForwardData(projectID)
+	if err != nil {
+		respondReportDataError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Data report weekly forward berhasil diambil",
+		Data:    report,
+	})
+}
+
+func (c *ExportController) DailyData(ctx *gin.Context) {
+	projectID, err := ParseUintParam(ctx, "project_id")
+	if err != nil {
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeInvalidParam, "project_id")
+		return
+	}
+
+	report, err := c.projectService.GetDailyData(projectID)
+	if err != nil {
+		respondReportDataError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Data report harian berhasil diambil",
+		Data:    report,
+	})
+}
+
+func (c *ExportController) MonitoringData(ctx *gin.Context) {
+	projectID, err := ParseUintParam(ctx, "project_id")
+	if err != nil {
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeInvalidParam, "project_id")
+		return
+	}
+
+	report, err := c.projectService.GetMonitoringData(projectID)
+	if err != nil {
+		respondReportDataError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Data report monitoring berhasil diambil",
+		Data:    report,
+	})
+}
+
+// respondReportDataError maps coded errors to client statuses and keeps 500
+// for failures while building the report.
+func respondReportDataError(ctx *gin.Context, err error) {
+	var coded *i18n.Error
+	if !errors.As(err, &coded) {
+		utils.RespondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	status := http.StatusBadRequest
+	if coded.Code == i18n.CodeProjectNotFound {
+		status = http.StatusNotFound
+	}
+	utils.RespondError(ctx, status, err)
+}
@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"project-management-backend/i18n"
+	"project-management-backend/services"
+	"project-management-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationController struct {
+	Service services.NotificationService
+}
+
+func NewNotificationController(service services.NotificationService) *NotificationController {
+	return &NotificationController{Service: service}
+}
+
+func (nc *NotificationController) ListNotifications(c *gin.Context) {
+	currentUser := GetCurrentUser(c)
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	unreadOnly := c.Query("unread") == "true"
+
+	notifications, total, err := nc.Service.List(currentUser, unreadOnly, limit, (page-1)*limit)
+	if err != nil {
+		utils.Error(currentUser.ID, "list_notifications", "notifications", 0, err.Error(), "")
+		utils.RespondCode(c, http.StatusInternalServerError, i18n.CodeFetchFailed, "notification")
+		return
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "List notifikasi berhasil diambil",
+		Data: gin.H{
+			"notifications": notifications,
+			"pagination": gin.H{
+				"total":       total,
+				"page":        page,
+				"limit":       limit,
+				"total_pages": totalPages,
+				"has_next":    page < totalPages,
+				"has_prev":    page > 1,
+			},
+		},
+	})
+}
+
+func (nc *NotificationController) UnreadCount(c *gin.Context) {
+	currentUser := GetCurrentUser(c)
+
+	count, err := nc.Service.UnreadCount(currentUser)
+	if err != nil {
+		utils.Error(currentUser.ID, "count_unread_notifications", "notifications", 0, err.Error(), "")
+		utils.RespondCode(c, http.StatusInternalServerError, i18n.CodeFetchFailed, "notification")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Jumlah notifikasi belum dibaca berhasil diambil",
+		Data:    gin.H{"unread": count},
+	})
+}
+
+func (nc *NotificationController) MarkRead(c *gin.Context) {
+	notificationID, err := ParseUintParam(c, "notification_id")
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	if err := nc.Service.MarkRead(notificationID, currentUser); err != nil {
+		utils.Error(currentUser.ID, "mark_notification_read", "notifications", notificationID, err.Error(), "")
+		status := http.StatusInternalServerError
+		var coded *i18n.Error
+		if errors.As(err, &coded) && coded.Code == i18n.CodeNotificationNotFound {
+			status = http.StatusNotFound
+		}
+		utils.RespondError(c, status, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Notifikasi ditandai sudah dibaca",
+	})
+}
+
+func (nc *NotificationController) MarkAllRead(c *gin.Context) {
+	currentUser := GetCurrentUser(c)
+
+	updated, err := nc.Service.MarkAllRead(currentUser)
+	if err != nil {
+		utils.Error(currentUser.ID, "mark_all_notifications_read", "notifications", 0, err.Error(), "")
+		utils.RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Semua notifikasi ditandai sudah dibaca",
+		Data:    gin.H{"updated": updated},
+	})
+}
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"project-management-backend/i18n"
+	"project-management-backend/services"
+	"project-management-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationOutboxController struct {
+	Service services.NotificationOutboxService
+}
+
+func NewNotificationOutboxController(service services.NotificationOutboxService) *NotificationOutboxController {
+	return &NotificationOutboxController{Service: service}
+}
+
+// ListOutbox menampilkan antrean pengiriman notifikasi, default hanya
+// yang sudah masuk dead letter
+func (oc *NotificationOutboxController) ListOutbox(c *gin.Context) {
+	currentUser := GetCurrentUser(c)
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	status := c.DefaultQuery("status", "dead")
+	if status == "all" {
+		status = ""
+	}
+
+	entries, total, err := oc.Service.List(status, limit, (page-1)*limit)
+	if err != nil {
+		utils.Error(currentUser.ID, "list_notification_outbox", "notification_outbox", 0, err.Error(), "")
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Antrean notifikasi berhasil diambil",
+		Data: gin.H{
+			"entries": entries,
+			"pagination": gin.H{
+				"total":       total,
+				"page":        page,
+				"limit":       limit,
+				"total_pages": totalPages,
+				"has_next":    page < totalPages,
+				"has_prev":    page > 1,
+			},
+		},
+	})
+}
+
+func (oc *NotificationOutboxController) Retry(c *gin.Context) {
+	entryID, err := ParseUintParam(c, "outbox_id")
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	if err := oc.Service.Retry(entryID); err != nil {
+		utils.Error(currentUser.ID, "retry_notification_outbox", "notification_outbox", entryID, err.Error(), "")
+		utils.RespondError(c, http.StatusNotFound, err)
+		return
+	}
+	utils.ActivityLog(currentUser.ID, "RETRY_NOTIFICATION", "notification_outbox", entryID, nil, nil)
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Notifikasi dijadwalkan ulang",
+	})
+}
+
+func (oc *NotificationOutboxController) RetryAllDead(c *gin.Context) {
+	currentUser := GetCurrentUser(c)
+
+	requeued, err := oc.Service.RetryAllDead()
+	if err != nil {
+		utils.Error(currentUser.ID, "retry_all_notification_outbox", "notification_outbox", 0, err.Error(), "")
+		utils.RespondCode(c, http.StatusInternalServerError, i18n.CodeOutboxRetryFailed)
+		return
+	}
+	utils.ActivityLog(currentUser.ID, "RETRY_ALL_NOTIFICATIONS", "notification_outbox", 0, nil, gin.H{"requeued": requeued})
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Semua notifikasi gagal dijadwalkan ulang",
+		Data:    gin.H{"requeued": requeued},
+	})
+}
@@ -1,7 +1,9 @@
 package controllers
 
 import (
+	"project-management-backend/i18n"
 	"project-management-backend/services"
+	"project-management-backend/utils"
 
 	"github.com/gin-gonic/gin"
 )
@@ -19,7 +21,7 @@ func (pc *ProfileController) ListProfile(c *gin.Context) {
 
 	profile, err := pc.Service.GetProfile(currentUser.ID)
 	if err != nil {
-		c.JSON(500, gin.H{"error": "Failed to get profile data"})
+		utils.RespondCode(c, 500, i18n.CodeFetchFailed, "profile")
 		return
 	}
 
@@ -33,6 +35,12 @@ func (pc *ProfileController) ListProfile(c *gin.Context) {
 			"position":         profile.Position,
 			"avatar":           profile.ProfileImage,
 			"telegram_chat_id": profile.TelegramChatID,
+			"language":         profile.Language,
+
+			"notification_preferences": profile.NotificationPreferences.Resolved(),
+			"notification_webhook_url": profile.NotificationWebhookURL,
+			"digest_time":              profile.DigestTime,
+			"timezone":                 profile.Timezone,
 		},
 	})
 }
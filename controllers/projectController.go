@@ -3,6 +3,7 @@ package controllers
 
 import (
 	"fmt"
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/services"
 	"project-management-backend/utils"
@@ -25,7 +26,7 @@ func (pc *ProjectController) ListProjects(c *gin.Context) {
 	projects, err := pc.Service.GetAllProjects(currentUser)
 	if err != nil {
 		utils.Error(currentUser.ID, "list_projects", "project", 0, err.Error(), "")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -99,7 +100,7 @@ func (pc *ProjectController) CreateProject(c *gin.Context) {
 	}
 	if err := c.ShouldBindJSON(&input); err != nil {
 		utils.Error(0, "bind_json", "project", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -113,7 +114,7 @@ func (pc *ProjectController) CreateProject(c *gin.Context) {
 
 	if err := pc.Service.CreateProject(&project, currentUser); err != nil {
 		utils.Error(currentUser.ID, "create_project", "project", 0, err.Error(), "")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -136,7 +137,7 @@ func (pc *ProjectController) DetailProject(c *gin.Context) {
 	projectID, err := ParseUintParam(c, "project_id")
 	if err != nil {
 		utils.Error(0, "parse_project_id", "project", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -145,7 +146,7 @@ func (pc *ProjectController) DetailProject(c *gin.Context) {
 	project, err := pc.Service.GetByID(projectID, currentUser)
 	if err != nil {
 		utils.Error(currentUser.ID, "get_project_by_id", "project", projectID, err.Error(), "")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -210,7 +211,7 @@ func (pc *ProjectController) UpdateProject(c *gin.Context) {
 	projectID, err := ParseUintParam(c, "project_id")
 	if err != nil {
 		utils.Error(0, "parse_project_id", "project", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -220,7 +221,7 @@ func (pc *ProjectController) UpdateProject(c *gin.Context) {
 	}
 	if err := c.ShouldBindJSON(&input); err != nil {
 		utils.Error(0, "bind_json", "project", projectID, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -230,7 +231,7 @@ func (pc *ProjectController) UpdateProject(c *gin.Context) {
 	oldProject, err := pc.Service.GetByID(projectID, currentUser)
 	if err != nil {
 		utils.Error(currentUser.ID, "get_project_before_update", "project", projectID, err.Error(), "")
-		c.JSON(403, gin.H{"error": "Project tidak ditemukan"})
+		utils.RespondCode(c, 403, i18n.CodeProjectNotFound)
 		return
 	}
 
@@ -242,7 +243,7 @@ func (pc *ProjectController) UpdateProject(c *gin.Context) {
 
 	if err := pc.Service.UpdateProject(&project, currentUser); err != nil {
 		utils.Error(currentUser.ID, "update_project", "project", projectID, err.Error(), "")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -250,7 +251,7 @@ func (pc *ProjectController) UpdateProject(c *gin.Context) {
 	updatedProject, err := pc.Service.GetByID(projectID, currentUser)
 	if err != nil {
 		utils.Error(currentUser.ID, "get_project_after_update", "project", projectID, err.Error(), "")
-		c.JSON(403, gin.H{"error": "Gagal mengambil data project setelah update"})
+		utils.RespondCode(c, 403, i18n.CodeFetchFailed, "project")
 		return
 	}
 
@@ -273,7 +274,7 @@ func (pc *ProjectController) SoftDeleteProject(c *gin.Context) {
 	projectID, err := ParseUintParam(c, "project_id")
 	if err != nil {
 		utils.Error(0, "parse_project_id", "project", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -282,13 +283,13 @@ func (pc *ProjectController) SoftDeleteProject(c *gin.Context) {
 	oldProject, err := pc.Service.GetByID(projectID, currentUser)
 	if err != nil {
 		utils.Error(currentUser.ID, "get_project_before_soft_delete", "project", projectID, err.Error(), "")
-		c.JSON(403, gin.H{"error": "Project tidak ditemukan"})
+		utils.RespondCode(c, 403, i18n.CodeProjectNotFound)
 		return
 	}
 
 	if err := pc.Service.SoftDeleteProject(projectID, currentUser); err != nil {
 		utils.Error(currentUser.ID, "soft_delete_project", "project", projectID, err.Error(), "")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -305,7 +306,7 @@ func (pc *ProjectController) DeleteProject(c *gin.Context) {
 	projectID, err := ParseUintParam(c, "project_id")
 	if err != nil {
 		utils.Error(0, "parse_project_id", "project", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -314,7 +315,7 @@ func (pc *ProjectController) DeleteProject(c *gin.Context) {
 	project, err := pc.Service.GetByID(projectID, currentUser)
 	if err != nil {
 		utils.Error(currentUser.ID, "get_project_before_hard_delete", "project", projectID, err.Error(), "")
-		c.JSON(404, gin.H{"error": "Project tidak ditemukan"})
+		utils.RespondCode(c, 404, i18n.CodeProjectNotFound)
 		return
 	}
 
@@ -339,7 +340,7 @@ func (pc *ProjectController) DeleteProject(c *gin.Context) {
 
 	if err := pc.Service.DeleteProject(projectID, currentUser); err != nil {
 		utils.Error(currentUser.ID, "hard_delete_project", "project", projectID, err.Error(), "")
-		c.JSON(500, gin.H{"error": err.Error()})
+		utils.RespondError(c, 500, err)
 		return
 	}
 
@@ -363,7 +364,7 @@ func (pc *ProjectController) AddMember(c *gin.Context) {
 	projectID, err := ParseUintParam(c, "project_id")
 	if err != nil {
 		utils.Error(0, "parse_project_id", "project", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -376,7 +377,7 @@ func (pc *ProjectController) AddMember(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&input); err != nil {
 		utils.Error(0, "bind_json_add_member", "project", projectID, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -392,7 +393,7 @@ func (pc *ProjectController) AddMember(c *gin.Context) {
 
 	if err := pc.Service.AddMembers(projectID, projectMembers, currentUser); err != nil {
 		utils.Error(currentUser.ID, "add_members", "project", projectID, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -429,7 +430,7 @@ func (pc *ProjectController) GetMembers(c *gin.Context) {
 	projectID, err := ParseUintParam(c, "project_id")
 	if err != nil {
 		utils.Error(0, "parse_project_id", "project", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -438,7 +439,7 @@ func (pc *ProjectController) GetMembers(c *gin.Context) {
 	members, err := pc.Service.GetMembers(projectID, currentUser)
 	if err != nil {
 		utils.Error(currentUser.ID, "get_members", "project", projectID, err.Error(), "")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -465,7 +466,7 @@ func (pc *ProjectController) RemoveMember(c *gin.Context) {
 	projectID, err := ParseUintParam(c, "project_id")
 	if err != nil {
 		utils.Error(0, "parse_project_id", "project", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -475,7 +476,7 @@ func (pc *ProjectController) RemoveMember(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&input); err != nil {
 		utils.Error(0, "bind_json_remove_member", "project", projectID, err.Error(), "")
-		c.JSON(400, gin.H{"error": "Format data tidak valid: " + err.Error()})
+		utils.RespondCode(c, 400, i18n.CodeInvalidPayload, err.Error())
 		return
 	}
 
@@ -484,13 +485,13 @@ func (pc *ProjectController) RemoveMember(c *gin.Context) {
 	if len(input.UserIDs) == 1 {
 		if err := pc.Service.RemoveMember(projectID, input.UserIDs[0], currentUser); err != nil {
 			utils.Error(currentUser.ID, "remove_single_member", "project", projectID, err.Error(), "")
-			c.JSON(400, gin.H{"error": err.Error()})
+			utils.RespondError(c, 400, err)
 			return
 		}
 	} else {
 		if err := pc.Service.RemoveMembers(projectID, input.UserIDs, currentUser); err != nil {
 			utils.Error(currentUser.ID, "remove_multiple_members", "project", projectID, err.Error(), "")
-			c.JSON(400, gin.H{"error": err.Error()})
+			utils.RespondError(c, 400, err)
 			return
 		}
 	}
@@ -516,21 +517,21 @@ func (pc *ProjectController) RemoveSingleMember(c *gin.Context) {
 	projectID, err := ParseUintParam(c, "project_id")
 	if err != nil {
 		utils.Error(0, "parse_project_id", "project", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	memberIDStr := c.Param("user_id")
 	if memberIDStr == "" {
 		utils.Error(0, "get_user_id_param", "project", projectID, "user_id is required", "")
-		c.JSON(400, gin.H{"error": "user_id is required"})
+		utils.RespondCode(c, 400, i18n.CodeParamRequired, "user_id")
 		return
 	}
 
 	memberID, err := strconv.ParseUint(memberIDStr, 10, 32)
 	if err != nil {
 		utils.Error(0, "parse_user_id", "project", projectID, "invalid user_id", "")
-		c.JSON(400, gin.H{"error": "invalid user_id"})
+		utils.RespondCode(c, 400, i18n.CodeInvalidParam, "user_id")
 		return
 	}
 
@@ -538,7 +539,7 @@ func (pc *ProjectController) RemoveSingleMember(c *gin.Context) {
 
 	if err := pc.Service.RemoveMember(projectID, uint(memberID), currentUser); err != nil {
 		utils.Error(currentUser.ID, "remove_single_member_param", "project", projectID, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
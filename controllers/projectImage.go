@@ -2,6 +2,7 @@
 package controllers
 
 import (
+	"project-management-backend/i18n"
 	"project-management-backend/services"
 	"project-management-backend/utils"
 
@@ -19,7 +20,7 @@ func NewProjectImageController(service services.ProjectImageService) *ProjectIma
 func (pic *ProjectImageController) GetProjectImages(c *gin.Context) {
 	projectID, err := ParseUintParam(c, "project_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -27,7 +28,7 @@ func (pic *ProjectImageController) GetProjectImages(c *gin.Context) {
 
 	images, err := pic.Service.GetProjectImages(projectID, currentUser.ID)
 	if err != nil {
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -53,14 +54,14 @@ func (pic *ProjectImageController) GetProjectImages(c *gin.Context) {
 func (pic *ProjectImageController) UploadProjectImage(c *gin.Context) {
 	projectID, err := ParseUintParam(c, "project_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	// Get file dari form-data
 	file, err := c.FormFile("image")
 	if err != nil {
-		c.JSON(400, gin.H{"error": "File image diperlukan"})
+		utils.RespondCode(c, 400, i18n.CodeFileRequired, "image")
 		return
 	}
 
@@ -69,7 +70,7 @@ func (pic *ProjectImageController) UploadProjectImage(c *gin.Context) {
 	// Upload image
 	projectImage, err := pic.Service.UploadProjectImage(projectID, file, currentUser.ID)
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -92,14 +93,14 @@ func (pic *ProjectImageController) UploadProjectImage(c *gin.Context) {
 func (pic *ProjectImageController) DeleteProjectImage(c *gin.Context) {
 	imageID, err := ParseUintParam(c, "image_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	currentUser := GetCurrentUser(c)
 
 	if err := pic.Service.DeleteProjectImage(imageID, currentUser.ID); err != nil {
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
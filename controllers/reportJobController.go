@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"project-management-backend/i18n"
+	"project-management-backend/services"
+	"project-management-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportJobController struct {
+	Service services.ReportJobService
+}
+
+func NewReportJobController(service services.ReportJobService) *ReportJobController {
+	return &ReportJobController{Service: service}
+}
+
+func (rc *ReportJobController) CreateJob(c *gin.Context) {
+	projectID, err := ParseUintParam(c, "project_id")
+	if err != nil {
+		utils.Error(0, "parse_project_id", "report_jobs", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	var input struct {
+		Type string `json:"type" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(0, "bind_json", "report_jobs", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	job, err := rc.Service.CreateJob(projectID, input.Type, currentUser, utils.RequestLanguage(c))
+	if err != nil {
+		utils.Error(currentUser.ID, "create_report_job", "report_jobs", 0, err.Error(), "")
+		status := 400
+		if errors.Is(err, services.ErrReportQueueBusy) {
+			status = http.StatusServiceUnavailable
+		}
+		utils.RespondError(c, status, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, APIResponse{
+		Success: true,
+		Code:    http.StatusAccepted,
+		Message: i18n.T(utils.RequestLanguage(c), i18n.LabelMsgReportJobQueued),
+		Data:    job,
+	})
+}
+
+func (rc *ReportJobController) ListJobs(c *gin.Context) {
+	currentUser := GetCurrentUser(c)
+
+	jobs, err := rc.Service.GetJobs(currentUser)
+	if err != nil {
+		utils.Error(currentUser.ID, "list_report_jobs", "report_jobs", 0, err.Error(), "")
+		utils.RespondCode(c, 500, i18n.CodeFetchFailed, "report")
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "List report berhasil diambil",
+		Data:    jobs,
+	})
+}
+
+func (rc *ReportJobController) GetJob(c *gin.Context) {
+	jobID, err := ParseUintParam(c, "job_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	job, err := rc.Service.GetJob(jobID, currentUser)
+	if err != nil {
+		utils.RespondError(c, 404, err)
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Status report berhasil diambil",
+		Data:    job,
+	})
+}
+
+func (rc *ReportJobController) Download(c *gin.Context) {
+	jobID, err := ParseUintParam(c, "job_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	job, err := rc.Service.GetArtifact(jobID, currentUser)
+	if err != nil {
+		status := 404
+		if errors.Is(err, services.ErrReportNotReady) {
+			status = http.StatusConflict
+		}
+		utils.RespondError(c, status, err)
+		return
+	}
+
+	c.FileAttachment(job.FilePath, job.FileName)
+}
@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"project-management-backend/i18n"
+	"project-management-backend/services"
+	"project-management-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportScheduleController struct {
+	Service services.ReportScheduleService
+}
+
+func NewReportScheduleController(service services.ReportScheduleService) *ReportScheduleController {
+	return &ReportScheduleController{Service: service}
+}
+
+func (rc *ReportScheduleController) ListSchedules(c *gin.Context) {
+	currentUser := GetCurrentUser(c)
+
+	schedules, err := rc.Service.GetSchedules()
+	if err != nil {
+		utils.Error(currentUser.ID, "list_report_schedules", "report_schedules", 0, err.Error(), "")
+		utils.RespondCode(c, 500, i18n.CodeFetchFailed, "report schedule")
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "List jadwal report berhasil diambil",
+		Data:    schedules,
+	})
+}
+
+func (rc *ReportScheduleController) CreateSchedule(c *gin.Context) {
+	var input services.ReportScheduleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(0, "bind_json", "report_schedules", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	schedule, err := rc.Service.CreateSchedule(input, currentUser)
+	if err != nil {
+		utils.Error(currentUser.ID, "create_report_schedule", "report_schedules", 0, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	utils.ActivityLog(currentUser.ID, "CREATE_REPORT_SCHEDULE", "report_schedules", schedule.ID, nil, schedule)
+
+	c.JSON(201, APIResponse{
+		Success: true,
+		Code:    201,
+		Message: "Jadwal report berhasil dibuat",
+		Data:    schedule,
+	})
+}
+
+func (rc *ReportScheduleController) DetailSchedule(c *gin.Context) {
+	scheduleID, err := ParseUintParam(c, "schedule_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	schedule, err := rc.Service.GetByID(scheduleID)
+	if err != nil {
+		utils.RespondError(c, 404, err)
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Detail jadwal report berhasil diambil",
+		Data:    schedule,
+	})
+}
+
+func (rc *ReportScheduleController) UpdateSchedule(c *gin.Context) {
+	scheduleID, err := ParseUintParam(c, "schedule_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	var input services.ReportScheduleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Error(0, "bind_json", "report_schedules", scheduleID, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	schedule, err := rc.Service.UpdateSchedule(scheduleID, input)
+	if err != nil {
+		utils.Error(currentUser.ID, "update_report_schedule", "report_schedules", scheduleID, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Jadwal report berhasil diupdate",
+		Data:    schedule,
+	})
+}
+
+func (rc *ReportScheduleController) DeleteSchedule(c *gin.Context) {
+	scheduleID, err := ParseUintParam(c, "schedule_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	if err := rc.Service.DeleteSchedule(scheduleID); err != nil {
+		utils.Error(currentUser.ID, "delete_report_schedule", "report_schedules", scheduleID, err.Error(), "")
+		utils.RespondError(c, 404, err)
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Jadwal report berhasil dihapus",
+		Data:    gin.H{"schedule_id": scheduleID},
+	})
+}
+
+func (rc *ReportScheduleController) RunSchedule(c *gin.Context) {
+	scheduleID, err := ParseUintParam(c, "schedule_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	if err := rc.Service.RunNow(scheduleID); err != nil {
+		utils.RespondError(c, 404, err)
+		return
+	}
+
+	c.JSON(202, APIResponse{
+		Success: true,
+		Code:    202,
+		Message: "Jadwal report sedang dijalankan",
+		Data:    gin.H{"schedule_id": scheduleID},
+	})
+}
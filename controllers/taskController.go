@@ -2,6 +2,7 @@
 package controllers
 
 import (
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/services"
 	"project-management-backend/utils"
@@ -22,14 +23,14 @@ func (tc *TaskController) ListTasks(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
 		utils.Error(0, "parse_workspace_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	projectID, err := ParseUintParam(c, "project_id")
 	if err != nil {
 		utils.Error(0, "parse_project_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -37,7 +38,7 @@ func (tc *TaskController) ListTasks(c *gin.Context) {
 	tasks, err := tc.Service.GetAllTasks(projectID, workspaceID, currentUser)
 	if err != nil {
 		utils.Error(currentUser.ID, "list_tasks", "task", 0, err.Error(), "")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -54,14 +55,14 @@ func (tc *TaskController) CreateTask(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
 		utils.Error(0, "parse_workspace_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	projectID, err := ParseUintParam(c, "project_id")
 	if err != nil {
 		utils.Error(0, "parse_project_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -77,7 +78,7 @@ func (tc *TaskController) CreateTask(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&input); err != nil {
 		utils.Error(0, "bind_json", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -96,7 +97,7 @@ func (tc *TaskController) CreateTask(c *gin.Context) {
 
 	if err := tc.Service.CreateTask(&task, workspaceID, currentUser); err != nil {
 		utils.Error(currentUser.ID, "create_task", "task", 0, err.Error(), "")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -115,14 +116,14 @@ func (tc *TaskController) DetailTask(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
 		utils.Error(0, "parse_workspace_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	taskID, err := ParseUintParam(c, "task_id")
 	if err != nil {
 		utils.Error(0, "parse_task_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -131,7 +132,7 @@ func (tc *TaskController) DetailTask(c *gin.Context) {
 	task, err := tc.Service.GetByID(taskID, workspaceID, currentUser)
 	if err != nil {
 		utils.Error(currentUser.ID, "get_task_by_id", "task", taskID, err.Error(), "")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -148,21 +149,21 @@ func (tc *TaskController) UpdateTask(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
 		utils.Error(0, "parse_workspace_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	taskID, err := ParseUintParam(c, "task_id")
 	if err != nil {
 		utils.Error(0, "parse_task_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	var updates map[string]interface{}
 	if err := c.ShouldBindJSON(&updates); err != nil {
 		utils.Error(0, "bind_json", "task", taskID, err.Error(), "")
-		c.JSON(400, gin.H{"error": "Invalid request body"})
+		utils.RespondCode(c, 400, i18n.CodeInvalidRequestBody)
 		return
 	}
 
@@ -171,13 +172,13 @@ func (tc *TaskController) UpdateTask(c *gin.Context) {
 	oldTask, err := tc.Service.GetByID(taskID, workspaceID, currentUser)
 	if err != nil {
 		utils.Error(currentUser.ID, "get_task_before_update", "task", taskID, err.Error(), "")
-		c.JSON(403, gin.H{"error": "Task tidak ditemukan"})
+		utils.RespondCode(c, 403, i18n.CodeTaskNotFound)
 		return
 	}
 
 	if err := tc.Service.UpdateTask(taskID, updates, workspaceID, currentUser); err != nil {
 		utils.Error(currentUser.ID, "update_task", "task", taskID, err.Error(), "")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -185,7 +186,7 @@ func (tc *TaskController) UpdateTask(c *gin.Context) {
 	updatedTask, err := tc.Service.GetByID(taskID, workspaceID, currentUser)
 	if err != nil {
 		utils.Error(currentUser.ID, "get_task_after_update", "task", taskID, err.Error(), "")
-		c.JSON(403, gin.H{"error": "Gagal mengambil data task setelah update"})
+		utils.RespondCode(c, 403, i18n.CodeFetchFailed, "task")
 		return
 	}
 
@@ -205,14 +206,14 @@ func (tc *TaskController) SoftDeleteTask(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
 		utils.Error(0, "parse_workspace_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	taskID, err := ParseUintParam(c, "task_id")
 	if err != nil {
 		utils.Error(0, "parse_task_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -220,7 +221,7 @@ func (tc *TaskController) SoftDeleteTask(c *gin.Context) {
 
 	if err := tc.Service.SoftDeleteTask(taskID, workspaceID, currentUser); err != nil {
 		utils.Error(currentUser.ID, "soft_delete_task", "task", taskID, err.Error(), "")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -236,14 +237,14 @@ func (tc *TaskController) DeleteTask(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
 		utils.Error(0, "parse_workspace_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	taskID, err := ParseUintParam(c, "task_id")
 	if err != nil {
 		utils.Error(0, "parse_task_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -253,7 +254,7 @@ func (tc *TaskController) DeleteTask(c *gin.Context) {
 	task, err := tc.Service.GetByID(taskID, workspaceID, currentUser)
 	if err != nil {
 		utils.Error(currentUser.ID, "get_task_before_hard_delete", "task", taskID, err.Error(), "")
-		c.JSON(404, gin.H{"error": "Task tidak ditemukan"})
+		utils.RespondCode(c, 404, i18n.CodeTaskNotFound)
 		return
 	}
 
@@ -277,7 +278,7 @@ func (tc *TaskController) DeleteTask(c *gin.Context) {
 
 	if err := tc.Service.DeleteTask(taskID, workspaceID, currentUser); err != nil {
 		utils.Error(currentUser.ID, "hard_delete_task", "task", taskID, err.Error(), "")
-		c.JSON(500, gin.H{"error": err.Error()})
+		utils.RespondError(c, 500, err)
 		return
 	}
 
@@ -298,21 +299,21 @@ func (tc *TaskController) AddMember(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
 		utils.Error(0, "parse_workspace_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	taskID, err := ParseUintParam(c, "task_id")
 	if err != nil {
 		utils.Error(0, "parse_task_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	ProjectID, err := ParseUintParam(c, "project_id")
 	if err != nil {
 		utils.Error(0, "parse_project_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -323,7 +324,7 @@ func (tc *TaskController) AddMember(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&input); err != nil {
 		utils.Error(0, "bind_json_add_member", "task", taskID, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -331,7 +332,7 @@ func (tc *TaskController) AddMember(c *gin.Context) {
 
 	if err := tc.Service.AddMember(taskID, ProjectID, workspaceID, input.UserID, input.Role, currentUser); err != nil {
 		utils.Error(currentUser.ID, "add_member_task", "task", taskID, err.Error(), "")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -353,21 +354,21 @@ func (tc *TaskController) GetMembers(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
 		utils.Error(0, "parse_workspace_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	projectID, err := ParseUintParam(c, "project_id")
 	if err != nil {
 		utils.Error(0, "parse_project_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	taskID, err := ParseUintParam(c, "task_id")
 	if err != nil {
 		utils.Error(0, "parse_task_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -376,7 +377,7 @@ func (tc *TaskController) GetMembers(c *gin.Context) {
 	members, err := tc.Service.GetMembers(taskID, projectID, workspaceID, currentUser)
 	if err != nil {
 		utils.Error(currentUser.ID, "get_members_task", "task", taskID, err.Error(), "")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -404,21 +405,21 @@ func (tc *TaskController) DeleteMember(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
 		utils.Error(0, "parse_workspace_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	projectID, err := ParseUintParam(c, "project_id")
 	if err != nil {
 		utils.Error(0, "parse_project_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	taskID, err := ParseUintParam(c, "task_id")
 	if err != nil {
 		utils.Error(0, "parse_task_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -427,13 +428,13 @@ func (tc *TaskController) DeleteMember(c *gin.Context) {
 	userID, err := ParseUintParam(c, "user_id")
 	if err != nil {
 		utils.Error(0, "parse_user_id", "task", 0, err.Error(), "")
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	if err := tc.Service.DeleteMember(taskID, projectID, workspaceID, userID, currentUser); err != nil {
 		utils.Error(currentUser.ID, "delete_member_task", "task", taskID, err.Error(), "")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
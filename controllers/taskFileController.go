@@ -4,7 +4,9 @@ import (
 	"net/http"
 	"strconv"
 
+	"project-management-backend/i18n"
 	"project-management-backend/services"
+	"project-management-backend/utils"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,30 +22,30 @@ func NewTaskFileController(taskFileService *services.TaskFileService) *TaskFileC
 func (c *TaskFileController) UploadFile(ctx *gin.Context) {
 	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeInvalidParam, "workspace_id")
 		return
 	}
 
 	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeInvalidParam, "project_id")
 		return
 	}
 
 	taskID, err := strconv.ParseUint(ctx.Param("task_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeInvalidParam, "task_id")
 		return
 	}
 	currentUser := GetCurrentUser(ctx)
 	file, err := ctx.FormFile("file")
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeFileRequired, "file")
 		return
 	}
 	taskFile, err := c.taskFileService.UploadFile(uint(workspaceID), uint(projectID), uint(taskID), currentUser.ID, file)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload file"})
+		utils.RespondCode(ctx, http.StatusInternalServerError, i18n.CodeFileSaveFailed, "upload")
 		return
 	}
 
@@ -53,24 +55,24 @@ func (c *TaskFileController) UploadFile(ctx *gin.Context) {
 func (c *TaskFileController) ListFiles(ctx *gin.Context) {
 	workspaceID, err := strconv.ParseUint(ctx.Param("workspace_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeInvalidParam, "workspace_id")
 		return
 	}
 
 	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeInvalidParam, "project_id")
 		return
 	}
 	taskID, err := strconv.ParseUint(ctx.Param("task_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeInvalidParam, "task_id")
 		return
 	}
 
 	files, err := c.taskFileService.GetFilesByTaskID(uint(taskID), uint(projectID), uint(workspaceID))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch files"})
+		utils.RespondCode(ctx, http.StatusInternalServerError, i18n.CodeFetchFailed, "files")
 		return
 	}
 
@@ -85,13 +87,13 @@ func (c *TaskFileController) ListFiles(ctx *gin.Context) {
 func (c *TaskFileController) DownloadFile(ctx *gin.Context) {
 	fileID, err := strconv.ParseUint(ctx.Param("fileId"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file ID"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeInvalidParam, "file_id")
 		return
 	}
 
 	fileData, mimeType, filename, err := c.taskFileService.DownloadFile(uint(fileID))
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		utils.RespondCode(ctx, http.StatusNotFound, i18n.CodeFileNotFound)
 		return
 	}
 
@@ -102,13 +104,13 @@ func (c *TaskFileController) DownloadFile(ctx *gin.Context) {
 func (c *TaskFileController) ViewFile(ctx *gin.Context) {
 	fileID, err := strconv.ParseUint(ctx.Param("fileId"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file ID"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeInvalidParam, "file_id")
 		return
 	}
 
 	fileData, mimeType, _, err := c.taskFileService.DownloadFile(uint(fileID))
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		utils.RespondCode(ctx, http.StatusNotFound, i18n.CodeFileNotFound)
 		return
 	}
 
@@ -119,7 +121,7 @@ func (c *TaskFileController) ViewFile(ctx *gin.Context) {
 func (c *TaskFileController) DeleteFile(ctx *gin.Context) {
 	fileID, err := strconv.ParseUint(ctx.Param("fileId"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file ID"})
+		utils.RespondCode(ctx, http.StatusBadRequest, i18n.CodeInvalidParam, "file_id")
 		return
 	}
 
@@ -127,7 +129,7 @@ func (c *TaskFileController) DeleteFile(ctx *gin.Context) {
 
 	err = c.taskFileService.DeleteFile(uint(fileID), userID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete file"})
+		utils.RespondCode(ctx, http.StatusInternalServerError, i18n.CodeFileDeleteFailed, "delete")
 		return
 	}
 
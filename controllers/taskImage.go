@@ -2,6 +2,7 @@
 package controllers
 
 import (
+	"project-management-backend/i18n"
 	"project-management-backend/services"
 	"project-management-backend/utils"
 
@@ -19,19 +20,19 @@ func NewTaskImageController(service services.TaskImageService) *TaskImageControl
 func (tic *TaskImageController) GetTaskImages(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	projectID, err := ParseUintParam(c, "project_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	taskID, err := ParseUintParam(c, "task_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -39,7 +40,7 @@ func (tic *TaskImageController) GetTaskImages(c *gin.Context) {
 
 	images, err := tic.Service.GetTaskImages(taskID, projectID, workspaceID, currentUser)
 	if err != nil {
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -76,19 +77,19 @@ func (tic *TaskImageController) UploadTaskImageAfter(c *gin.Context) {
 func (tic *TaskImageController) uploadTaskImageWithType(c *gin.Context, imgType string) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	taskID, err := ParseUintParam(c, "task_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	file, err := c.FormFile("image")
 	if err != nil {
-		c.JSON(400, gin.H{"error": "File image diperlukan"})
+		utils.RespondCode(c, 400, i18n.CodeFileRequired, "image")
 		return
 	}
 
@@ -96,7 +97,7 @@ func (tic *TaskImageController) uploadTaskImageWithType(c *gin.Context, imgType
 
 	taskImage, err := tic.Service.UploadTaskImageWithType(taskID, workspaceID, file, currentUser, imgType)
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -120,20 +121,20 @@ func (tic *TaskImageController) uploadTaskImageWithType(c *gin.Context, imgType
 func (tic *TaskImageController) DeleteTaskImage(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	imageID, err := ParseUintParam(c, "image_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	currentUser := GetCurrentUser(c)
 
 	if err := tic.Service.DeleteTaskImage(imageID, workspaceID, currentUser); err != nil {
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
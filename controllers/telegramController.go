@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"project-management-backend/services"
+	"project-management-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TelegramController struct {
+	Service       services.TelegramBotService
+	webhookSecret string
+}
+
+func NewTelegramController(service services.TelegramBotService) *TelegramController {
+	return &TelegramController{
+		Service:       service,
+		webhookSecret: os.Getenv("TELEGRAM_WEBHOOK_SECRET"),
+	}
+}
+
+// Webhook receives updates from Telegram. The request must carry the
+// TELEGRAM_WEBHOOK_SECRET registered as secret_token with setWebhook; every
+// update is rejected while the secret is not configured.
+func (tc *TelegramController) Webhook(c *gin.Context) {
+	if tc.webhookSecret == "" {
+		utils.RespondCode(c, http.StatusServiceUnavailable, i18n.CodeTelegramWebhookDisabled)
+		return
+	}
+
+	secret := c.GetHeader("X-Telegram-Bot-Api-Secret-Token")
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(tc.webhookSecret)) != 1 {
+		utils.RespondCode(c, http.StatusUnauthorized, i18n.CodeTelegramSecretInvalid)
+		return
+	}
+
+	var update models.TelegramUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		utils.RespondCode(c, http.StatusBadRequest, i18n.CodeInvalidPayload)
+		return
+	}
+
+	tc.Service.HandleUpdate(&update)
+
+	// Telegram hanya butuh 200, balasan dikirim lewat sendMessage
+	c.Status(http.StatusOK)
+}
+
+func (tc *TelegramController) CreateLinkCode(c *gin.Context) {
+	currentUser := GetCurrentUser(c)
+
+	result, err := tc.Service.CreateLinkCode(currentUser)
+	if err != nil {
+		utils.Error(currentUser.ID, "create_telegram_link_code", "telegram_link_codes", 0, err.Error(), "")
+		utils.RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, APIResponse{
+		Success: true,
+		Code:    http.StatusCreated,
+		Message: i18n.T(utils.RequestLanguage(c), i18n.LabelMsgTelegramLinkCode),
+		Data:    result,
+	})
+}
+
+func (tc *TelegramController) Unlink(c *gin.Context) {
+	currentUser := GetCurrentUser(c)
+
+	if err := tc.Service.Unlink(currentUser); err != nil {
+		utils.Error(currentUser.ID, "unlink_telegram", "users", currentUser.ID, err.Error(), "")
+		utils.RespondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Telegram berhasil diputuskan",
+	})
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"fmt"
 	"net/http"
+	"project-management-backend/i18n"
 	"project-management-backend/services"
 	"project-management-backend/utils"
 	"strconv"
@@ -51,7 +52,7 @@ func (uc *UserController) GetAllUsers(c *gin.Context) {
 
 	result, err := uc.Service.GetAllUsersWithFilters(filters, currentUser)
 	if err != nil {
-		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		utils.RespondError(c, http.StatusForbidden, err)
 		return
 	}
 
@@ -117,7 +118,7 @@ func (uc *UserController) GetAllUsers(c *gin.Context) {
 func (uc *UserController) GetUserByID(c *gin.Context) {
 	userID, err := ParseUintParam(c, "user_id")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -125,7 +126,7 @@ func (uc *UserController) GetUserByID(c *gin.Context) {
 
 	user, err := uc.Service.GetUserByID(userID, currentUser)
 	if err != nil {
-		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		utils.RespondError(c, http.StatusForbidden, err)
 		return
 	}
 
@@ -155,7 +156,7 @@ func (uc *UserController) GetUserByID(c *gin.Context) {
 func (uc *UserController) SearchUsers(c *gin.Context) {
 	query := c.DefaultQuery("q", "")
 	if query == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "query pencarian diperlukan"})
+		utils.RespondCode(c, http.StatusBadRequest, i18n.CodeParamRequired, "query")
 		return
 	}
 
@@ -163,7 +164,7 @@ func (uc *UserController) SearchUsers(c *gin.Context) {
 
 	users, err := uc.Service.SearchUsers(query, currentUser)
 	if err != nil {
-		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		utils.RespondError(c, http.StatusForbidden, err)
 		return
 	}
 
@@ -199,14 +200,14 @@ func (uc *UserController) GetUserStats(c *gin.Context) {
 	currentUser := GetCurrentUser(c)
 
 	if currentUser.Role != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "hanya admin yang bisa mengakses statistik"})
+		utils.RespondCode(c, http.StatusForbidden, i18n.CodeAdminOnly)
 		return
 	}
 
 	// Get all users untuk statistik
 	users, err := uc.Service.GetAllUsers(currentUser)
 	if err != nil {
-		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		utils.RespondError(c, http.StatusForbidden, err)
 		return
 	}
 
@@ -242,7 +243,7 @@ func (uc *UserController) GetUsersByIDs(c *gin.Context) {
 	currentUser := GetCurrentUser(c)
 
 	if currentUser.Role != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "hanya admin yang bisa mengakses"})
+		utils.RespondCode(c, http.StatusForbidden, i18n.CodeAdminOnly)
 		return
 	}
 
@@ -251,19 +252,19 @@ func (uc *UserController) GetUsersByIDs(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(c, http.StatusBadRequest, err)
 		return
 	}
 
 	// Limit jumlah user yang bisa di-request sekaligus
 	if len(input.UserIDs) > 100 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "maksimal 100 user per request"})
+		utils.RespondCode(c, http.StatusBadRequest, i18n.CodeMaxUsersPerRequest, 100)
 		return
 	}
 
 	users, err := uc.Service.GetUsersWithDetails(input.UserIDs, currentUser)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -299,7 +300,7 @@ func (uc *UserController) GetUsersByIDs(c *gin.Context) {
 func (uc *UserController) GetOnlineUsers(c *gin.Context) {
 	users, err := uc.Service.GetOnlineUsers()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get online users"})
+		utils.RespondCode(c, http.StatusInternalServerError, i18n.CodeFetchFailed, "online users")
 		return
 	}
 
@@ -339,13 +340,13 @@ func (uc *UserController) GetOnlineUsers(c *gin.Context) {
 func (uc *UserController) GetOnlineWorkspaceMembers(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(c, http.StatusBadRequest, err)
 		return
 	}
 
 	users, err := uc.Service.GetOnlineWorkspaceMembers(workspaceID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get online workspace members"})
+		utils.RespondCode(c, http.StatusInternalServerError, i18n.CodeFetchFailed, "online members")
 		return
 	}
 
@@ -385,13 +386,13 @@ func (uc *UserController) GetOnlineWorkspaceMembers(c *gin.Context) {
 func (uc *UserController) DeleteUser(c *gin.Context) {
 	userID, err := ParseUintParam(c, "user_id")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		utils.RespondError(c, http.StatusBadRequest, err)
 		return
 	}
 	currentUser := GetCurrentUser(c)
 
 	if err := uc.Service.DeleteUser(userID, currentUser); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		utils.RespondError(c, http.StatusInternalServerError, err)
 		return
 	}
 	utils.ActivityLog(currentUser.ID, "Delete_user", "User", userID, userID, nil)
@@ -0,0 +1,246 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"project-management-backend/services"
+	"project-management-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookController struct {
+	Service services.WebhookService
+}
+
+func NewWebhookController(service services.WebhookService) *WebhookController {
+	return &WebhookController{Service: service}
+}
+
+func (wc *WebhookController) ListWebhooks(c *gin.Context) {
+	workspaceID, err := ParseUintParam(c, "workspace_id")
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	webhooks, err := wc.Service.List(workspaceID)
+	if err != nil {
+		utils.RespondCode(c, http.StatusInternalServerError, i18n.CodeFetchFailed, "webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "List webhook berhasil diambil",
+		Data: gin.H{
+			"webhooks": webhooks,
+			"events":   models.WebhookEvents,
+		},
+	})
+}
+
+func (wc *WebhookController) CreateWebhook(c *gin.Context) {
+	workspaceID, err := ParseUintParam(c, "workspace_id")
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var input services.WebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	webhook, err := wc.Service.Create(workspaceID, input, currentUser)
+	if err != nil {
+		utils.Error(currentUser.ID, "CREATE_WEBHOOK", "workspace_webhooks", workspaceID, err.Error(), "")
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+	utils.ActivityLog(currentUser.ID, "CREATE_WEBHOOK", "workspace_webhooks", webhook.ID, nil, webhook)
+
+	// Secret hanya ditampilkan saat dibuat atau dirotasi
+	c.JSON(http.StatusCreated, APIResponse{
+		Success: true,
+		Code:    http.StatusCreated,
+		Message: "Webhook berhasil dibuat",
+		Data: gin.H{
+			"webhook": webhook,
+			"secret":  webhook.Secret,
+		},
+	})
+}
+
+func (wc *WebhookController) UpdateWebhook(c *gin.Context) {
+	workspaceID, webhookID, ok := parseWebhookParams(c)
+	if !ok {
+		return
+	}
+
+	var input services.WebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	webhook, err := wc.Service.Update(workspaceID, webhookID, input)
+	if err != nil {
+		utils.Error(currentUser.ID, "UPDATE_WEBHOOK", "workspace_webhooks", webhookID, err.Error(), "")
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+	utils.ActivityLog(currentUser.ID, "UPDATE_WEBHOOK", "workspace_webhooks", webhook.ID, nil, webhook)
+
+	data := gin.H{"webhook": webhook}
+	if input.RotateSecret {
+		data["secret"] = webhook.Secret
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Webhook berhasil diupdate",
+		Data:    data,
+	})
+}
+
+func (wc *WebhookController) DeleteWebhook(c *gin.Context) {
+	workspaceID, webhookID, ok := parseWebhookParams(c)
+	if !ok {
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	if err := wc.Service.Delete(workspaceID, webhookID); err != nil {
+		utils.Error(currentUser.ID, "DELETE_WEBHOOK", "workspace_webhooks", webhookID, err.Error(), "")
+		utils.RespondError(c, http.StatusNotFound, err)
+		return
+	}
+	utils.ActivityLog(currentUser.ID, "DELETE_WEBHOOK", "workspace_webhooks", webhookID, nil, nil)
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Webhook berhasil dihapus",
+	})
+}
+
+func (wc *WebhookController) ListDeliveries(c *gin.Context) {
+	workspaceID, webhookID, ok := parseWebhookParams(c)
+	if !ok {
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	deliveries, total, err := wc.Service.Deliveries(workspaceID, webhookID, limit, (page-1)*limit)
+	if err != nil {
+		utils.RespondError(c, http.StatusNotFound, err)
+		return
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Riwayat pengiriman webhook berhasil diambil",
+		Data: gin.H{
+			"deliveries": deliveries,
+			"pagination": gin.H{
+				"total":       total,
+				"page":        page,
+				"limit":       limit,
+				"total_pages": totalPages,
+				"has_next":    page < totalPages,
+				"has_prev":    page > 1,
+			},
+		},
+	})
+}
+
+func (wc *WebhookController) SendTest(c *gin.Context) {
+	workspaceID, webhookID, ok := parseWebhookParams(c)
+	if !ok {
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	delivery, err := wc.Service.SendTest(workspaceID, webhookID, currentUser)
+	if err != nil {
+		utils.RespondError(c, http.StatusNotFound, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Test event webhook telah dikirim",
+		Data:    delivery,
+	})
+}
+
+func (wc *WebhookController) Redeliver(c *gin.Context) {
+	workspaceID, webhookID, ok := parseWebhookParams(c)
+	if !ok {
+		return
+	}
+
+	deliveryID, err := ParseUintParam(c, "delivery_id")
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	delivery, err := wc.Service.Redeliver(workspaceID, webhookID, deliveryID)
+	if err != nil {
+		utils.RespondError(c, http.StatusNotFound, err)
+		return
+	}
+	utils.ActivityLog(currentUser.ID, "REDELIVER_WEBHOOK", "webhook_deliveries", delivery.ID, nil, nil)
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Message: "Webhook telah dikirim ulang",
+		Data:    delivery,
+	})
+}
+
+func parseWebhookParams(c *gin.Context) (uint, uint, bool) {
+	workspaceID, err := ParseUintParam(c, "workspace_id")
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return 0, 0, false
+	}
+
+	webhookID, err := ParseUintParam(c, "webhook_id")
+	if err != nil {
+		utils.RespondError(c, http.StatusBadRequest, err)
+		return 0, 0, false
+	}
+
+	return workspaceID, webhookID, true
+}
@@ -1,10 +1,17 @@
 package controllers
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"project-management-backend/i18n"
 	"project-management-backend/services"
+	"project-management-backend/utils"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
@@ -23,6 +30,9 @@ func NewWebSocketController(authService services.AuthService, webSocketService s
 	return &WebSocketController{AuthService: authService, WebSocketService: webSocketService, UserService: userService}
 }
 
+// Komentar SSE berkala agar proxy tidak menutup koneksi yang diam
+const sseKeepAlive = 25 * time.Second
+
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
@@ -50,15 +60,20 @@ func (wsc *WebSocketController) ServeWs(c *gin.Context) {
 		return
 	}
 
-	if user.Role != "admin" {
-		workspaceID, err := strconv.ParseUint(workspaceIDStr, 10, 64)
+	// workspace_id opsional; client bisa subscribe ke workspace, project
+	// atau task lain setelah terhubung
+	var workspaceID uint64
+	if workspaceIDStr != "" {
+		workspaceID, err = strconv.ParseUint(workspaceIDStr, 10, 64)
 		if err != nil {
 			msg := websocket.FormatCloseMessage(websocket.CloseInvalidFramePayloadData, "Invalid workspace ID format")
 			conn.WriteMessage(websocket.CloseMessage, msg)
 			conn.Close()
 			return
 		}
+	}
 
+	if workspaceID != 0 && user.Role != "admin" {
 		isMember, err := wsc.UserService.IsUserMemberOfWorkspace(user.ID, uint(workspaceID))
 		if err != nil || !isMember {
 			msg := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "Access Denied: Not a workspace member")
@@ -66,21 +81,73 @@ func (wsc *WebSocketController) ServeWs(c *gin.Context) {
 			conn.Close()
 			return
 		}
+	}
 
-		wsc.WebSocketService.RegisterAndServeClient(conn, user.ID, uint(workspaceID))
-	} else {
-		var workspaceID uint64 = 0
-		if workspaceIDStr != "" {
-			parsedWorkspaceID, err := strconv.ParseUint(workspaceIDStr, 10, 64)
-			if err != nil {
-				msg := websocket.FormatCloseMessage(websocket.CloseInvalidFramePayloadData, "Invalid workspace ID format")
-				conn.WriteMessage(websocket.CloseMessage, msg)
-				conn.Close()
-				return
-			}
-			workspaceID = parsedWorkspaceID
+	// last_event_id dipakai saat reconnect untuk menerima event yang terlewat
+	wsc.WebSocketService.RegisterAndServeClient(conn, user, uint(workspaceID), c.Query("last_event_id"))
+}
+
+// ServeSSE streams the realtime events as Server-Sent Events for networks
+// that block WebSocket upgrades. Topics are passed as a comma separated
+// list, e.g. ?topics=workspace:1,task:42, and the browser resumes with the
+// Last-Event-ID header after a reconnect.
+func (wsc *WebSocketController) ServeSSE(c *gin.Context) {
+	user, err := wsc.AuthService.GetUserFromToken(c.Query("token"))
+	if err != nil {
+		utils.RespondCode(c, http.StatusUnauthorized, i18n.CodeInvalidToken)
+		return
+	}
+
+	var topics []string
+	if workspaceID := c.Query("workspace_id"); workspaceID != "" {
+		topics = append(topics, "workspace:"+workspaceID)
+	}
+	for _, topic := range strings.Split(c.Query("topics"), ",") {
+		if topic = strings.TrimSpace(topic); topic != "" {
+			topics = append(topics, topic)
 		}
-		wsc.WebSocketService.RegisterAndServeClient(conn, user.ID, uint(workspaceID))
 	}
 
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	stream, err := wsc.WebSocketService.OpenStream(user, topics, lastEventID)
+	if err != nil {
+		status := http.StatusBadRequest
+		var coded *i18n.Error
+		if errors.As(err, &coded) && coded.Code == i18n.CodeWsTopicForbidden {
+			status = http.StatusForbidden
+		}
+		utils.RespondError(c, status, err)
+		return
+	}
+	defer wsc.WebSocketService.CloseStream(stream)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // nonaktifkan buffering nginx
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case message, ok := <-stream.Messages():
+			if !ok {
+				return false
+			}
+			if id := stream.Track(message); id != "" {
+				fmt.Fprintf(w, "id: %s\n", id)
+			}
+			fmt.Fprintf(w, "data: %s\n\n", message)
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case <-c.Request.Context().Done():
+			return false
+		}
+		return true
+	})
 }
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"project-management-backend/i18n"
+	"project-management-backend/services"
+	"project-management-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkspaceBrandingController struct {
+	Service services.WorkspaceBrandingService
+}
+
+func NewWorkspaceBrandingController(service services.WorkspaceBrandingService) *WorkspaceBrandingController {
+	return &WorkspaceBrandingController{Service: service}
+}
+
+func (bc *WorkspaceBrandingController) GetBranding(c *gin.Context) {
+	workspaceID, err := ParseUintParam(c, "workspace_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	branding, err := bc.Service.GetBranding(workspaceID, currentUser)
+	if err != nil {
+		utils.RespondError(c, 403, err)
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Branding workspace berhasil diambil",
+		Data:    branding,
+	})
+}
+
+func (bc *WorkspaceBrandingController) UpdateBranding(c *gin.Context) {
+	workspaceID, err := ParseUintParam(c, "workspace_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	var input services.WorkspaceBrandingInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	branding, err := bc.Service.UpdateBranding(workspaceID, input, currentUser)
+	if err != nil {
+		utils.Error(currentUser.ID, "UPDATE_WORKSPACE_BRANDING", "workspace_brandings", workspaceID, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	utils.ActivityLog(currentUser.ID, "UPDATE_WORKSPACE_BRANDING", "workspace_brandings", branding.ID, nil, branding)
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Branding workspace berhasil diupdate",
+		Data:    branding,
+	})
+}
+
+func (bc *WorkspaceBrandingController) UploadLogo(c *gin.Context) {
+	workspaceID, err := ParseUintParam(c, "workspace_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	file, err := c.FormFile("logo")
+	if err != nil {
+		utils.RespondCode(c, 400, i18n.CodeFileRequired, "logo")
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	branding, err := bc.Service.UploadLogo(workspaceID, file, currentUser)
+	if err != nil {
+		utils.Error(currentUser.ID, "UPLOAD_WORKSPACE_LOGO", "workspace_brandings", workspaceID, err.Error(), "")
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	utils.ActivityLog(currentUser.ID, "UPLOAD_WORKSPACE_LOGO", "workspace_brandings", branding.ID, nil, branding)
+
+	c.JSON(201, APIResponse{
+		Success: true,
+		Code:    201,
+		Message: "Logo workspace berhasil diupload",
+		Data:    branding,
+	})
+}
+
+func (bc *WorkspaceBrandingController) DeleteLogo(c *gin.Context) {
+	workspaceID, err := ParseUintParam(c, "workspace_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	branding, err := bc.Service.DeleteLogo(workspaceID, currentUser)
+	if err != nil {
+		utils.RespondError(c, 404, err)
+		return
+	}
+
+	utils.ActivityLog(currentUser.ID, "DELETE_WORKSPACE_LOGO", "workspace_brandings", branding.ID, nil, "deleted logo")
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Logo workspace berhasil dihapus",
+		Data:    branding,
+	})
+}
+
+// PreviewBranding merender contoh halaman laporan dengan branding workspace.
+func (bc *WorkspaceBrandingController) PreviewBranding(c *gin.Context) {
+	workspaceID, err := ParseUintParam(c, "workspace_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+
+	pdfBytes, err := bc.Service.Preview(workspaceID, currentUser, utils.RequestLanguage(c))
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=branding_preview_%d.pdf", workspaceID))
+	c.Data(http.StatusOK, "application/pdf", pdfBytes)
+}
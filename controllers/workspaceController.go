@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"fmt"
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/services"
 	"project-management-backend/utils"
@@ -49,7 +50,7 @@ func (wc *WorkspaceController) ListWorkspaces(c *gin.Context) {
 	workspaces, err := wc.Service.GetAllWorkspaces(currentUser)
 	if err != nil {
 		utils.Error(0, "GET_WORKSPACE", "workspaces", 403, err.Error(), "Failed to get workspaces")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 	workspaceList := make([]gin.H, 0)
@@ -76,7 +77,7 @@ func (wc *WorkspaceController) CreateWorkspace(c *gin.Context) {
 		Color       string `json:"color"`
 	}
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -90,7 +91,7 @@ func (wc *WorkspaceController) CreateWorkspace(c *gin.Context) {
 
 	if err := wc.Service.CreateWorkspace(&workspace, currentUser); err != nil {
 		utils.Error(currentUser.ID, "CREATE_WORKSPACE", "workspaces", 403, err.Error(), "Failed to create workspaces")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 	utils.ActivityLog(currentUser.ID, "CREATE_WORKSPACE", "workspace", currentUser.ID, nil, workspace)
@@ -110,7 +111,7 @@ func (wc *WorkspaceController) CreateWorkspace(c *gin.Context) {
 func (wc *WorkspaceController) DetailWorkspace(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -118,7 +119,7 @@ func (wc *WorkspaceController) DetailWorkspace(c *gin.Context) {
 
 	ws, err := wc.Service.GetByID(workspaceID, currentUser)
 	if err != nil {
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -139,7 +140,7 @@ func (wc *WorkspaceController) DetailWorkspace(c *gin.Context) {
 func (wc *WorkspaceController) UpdateWorkspace(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -149,7 +150,7 @@ func (wc *WorkspaceController) UpdateWorkspace(c *gin.Context) {
 		Color       string `json:"color"`
 	}
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -157,7 +158,7 @@ func (wc *WorkspaceController) UpdateWorkspace(c *gin.Context) {
 
 	oldWorkspace, err := wc.Service.GetByID(workspaceID, currentUser)
 	if err != nil {
-		c.JSON(403, gin.H{"error": "Workspace tidak ditemukan"})
+		utils.RespondCode(c, 403, i18n.CodeWorkspaceNotFound)
 		return
 	}
 
@@ -170,14 +171,14 @@ func (wc *WorkspaceController) UpdateWorkspace(c *gin.Context) {
 
 	if err := wc.Service.UpdateWorkspace(&workspace, currentUser); err != nil {
 		utils.Error(currentUser.ID, "UPDATE_WORKSPACE", "workspaces", 403, err.Error(), "Failed to update workspace")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
 	// Get updated workspace
 	updatedWorkspace, err := wc.Service.GetByID(workspaceID, currentUser)
 	if err != nil {
-		c.JSON(403, gin.H{"error": "Gagal mengambil data workspace setelah update"})
+		utils.RespondCode(c, 403, i18n.CodeFetchFailed, "workspace")
 		return
 	}
 
@@ -196,10 +197,46 @@ func (wc *WorkspaceController) UpdateWorkspace(c *gin.Context) {
 	})
 }
 
+// UpdateTwoFactorPolicy - Wajibkan (atau tidak) 2FA untuk admin workspace
+func (wc *WorkspaceController) UpdateTwoFactorPolicy(c *gin.Context) {
+	workspaceID, err := ParseUintParam(c, "workspace_id")
+	if err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	var input struct {
+		RequireAdminTwoFactor *bool `json:"require_admin_two_factor" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.RespondError(c, 400, err)
+		return
+	}
+
+	currentUser := GetCurrentUser(c)
+	if err := wc.Service.SetRequireAdminTwoFactor(workspaceID, *input.RequireAdminTwoFactor, currentUser); err != nil {
+		utils.Error(currentUser.ID, "UPDATE_WORKSPACE_TWO_FACTOR", "workspaces", workspaceID, err.Error(), "Failed to update two-factor policy")
+		utils.RespondError(c, 403, err)
+		return
+	}
+
+	utils.ActivityLog(currentUser.ID, "UPDATE_WORKSPACE_TWO_FACTOR", "workspace", workspaceID, nil, input)
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Code:    200,
+		Message: "Kebijakan autentikasi dua faktor workspace berhasil diupdate",
+		Data: gin.H{
+			"workspace_id":             workspaceID,
+			"require_admin_two_factor": *input.RequireAdminTwoFactor,
+		},
+	})
+}
+
 func (wc *WorkspaceController) SoftDeleteWorkspace(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -207,7 +244,7 @@ func (wc *WorkspaceController) SoftDeleteWorkspace(c *gin.Context) {
 
 	if err := wc.Service.SoftDeleteWorkspace(workspaceID, currentUser); err != nil {
 		utils.Error(currentUser.ID, "SOFT_DELETE_WORKSPACE", "workspaces", 403, err.Error(), "Failed to soft delete workspace")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -224,7 +261,7 @@ func (wc *WorkspaceController) SoftDeleteWorkspace(c *gin.Context) {
 func (wc *WorkspaceController) DeleteWorkspace(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -232,7 +269,7 @@ func (wc *WorkspaceController) DeleteWorkspace(c *gin.Context) {
 
 	workspace, err := wc.Service.GetByID(workspaceID, currentUser)
 	if err != nil {
-		c.JSON(404, gin.H{"error": "Workspace tidak ditemukan"})
+		utils.RespondCode(c, 404, i18n.CodeWorkspaceNotFound)
 		return
 	}
 	var input struct {
@@ -255,7 +292,7 @@ func (wc *WorkspaceController) DeleteWorkspace(c *gin.Context) {
 
 	if err := wc.Service.DeleteWorkspace(workspaceID, currentUser); err != nil {
 		utils.Error(currentUser.ID, "DELETE_WORKSPACE", "workspaces", 403, err.Error(), "Failed to delete workspace")
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -277,7 +314,7 @@ func (wc *WorkspaceController) DeleteWorkspace(c *gin.Context) {
 func (wc *WorkspaceController) AddMembers(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -289,7 +326,7 @@ func (wc *WorkspaceController) AddMembers(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(400, gin.H{"error": "Format data tidak valid: " + err.Error()})
+		utils.RespondCode(c, 400, i18n.CodeInvalidPayload, err.Error())
 		return
 	}
 
@@ -304,7 +341,7 @@ func (wc *WorkspaceController) AddMembers(c *gin.Context) {
 	currentUser := GetCurrentUser(c)
 
 	if err := wc.Service.AddMembers(workspaceID, workspaceMembers, currentUser); err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -340,7 +377,7 @@ func (wc *WorkspaceController) AddMembers(c *gin.Context) {
 func (wc *WorkspaceController) GetMembers(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -348,7 +385,7 @@ func (wc *WorkspaceController) GetMembers(c *gin.Context) {
 
 	members, err := wc.Service.GetMembers(workspaceID, currentUser)
 	if err != nil {
-		c.JSON(403, gin.H{"error": err.Error()})
+		utils.RespondError(c, 403, err)
 		return
 	}
 
@@ -373,7 +410,7 @@ func (wc *WorkspaceController) GetMembers(c *gin.Context) {
 func (wc *WorkspaceController) RemoveMember(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
@@ -382,7 +419,7 @@ func (wc *WorkspaceController) RemoveMember(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(400, gin.H{"error": "Format data tidak valid: " + err.Error()})
+		utils.RespondCode(c, 400, i18n.CodeInvalidPayload, err.Error())
 		return
 	}
 
@@ -390,12 +427,12 @@ func (wc *WorkspaceController) RemoveMember(c *gin.Context) {
 
 	if len(input.UserIDs) == 1 {
 		if err := wc.Service.RemoveMember(workspaceID, input.UserIDs[0], currentUser); err != nil {
-			c.JSON(400, gin.H{"error": err.Error()})
+			utils.RespondError(c, 400, err)
 			return
 		}
 	} else {
 		if err := wc.Service.RemoveMembers(workspaceID, input.UserIDs, currentUser); err != nil {
-			c.JSON(400, gin.H{"error": err.Error()})
+			utils.RespondError(c, 400, err)
 			return
 		}
 	}
@@ -420,20 +457,20 @@ func (wc *WorkspaceController) RemoveMember(c *gin.Context) {
 func (wc *WorkspaceController) RemoveSingleMember(c *gin.Context) {
 	workspaceID, err := ParseUintParam(c, "workspace_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	memberID, err := ParseUintParam(c, "user_id")
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
 	currentUser := GetCurrentUser(c)
 
 	if err := wc.Service.RemoveMember(workspaceID, memberID, currentUser); err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		utils.RespondError(c, 400, err)
 		return
 	}
 
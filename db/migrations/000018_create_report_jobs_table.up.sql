@@ -0,0 +1,21 @@
+CREATE TABLE `report_jobs` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `project_id` bigint(20) unsigned NOT NULL,
+  `report_type` varchar(50) NOT NULL,
+  `status` varchar(20) NOT NULL DEFAULT 'queued',
+  `requested_by` bigint(20) unsigned NOT NULL,
+  `file_path` varchar(255) DEFAULT NULL,
+  `file_name` varchar(255) DEFAULT NULL,
+  `file_size` bigint(20) DEFAULT 0,
+  `error_msg` text,
+  `started_at` datetime(3) DEFAULT NULL,
+  `completed_at` datetime(3) DEFAULT NULL,
+  `expires_at` datetime(3) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_report_jobs_status` (`status`),
+  KEY `idx_report_jobs_expires_at` (`expires_at`),
+  CONSTRAINT `fk_report_jobs_project` FOREIGN KEY (`project_id`) REFERENCES `projects` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_report_jobs_user` FOREIGN KEY (`requested_by`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
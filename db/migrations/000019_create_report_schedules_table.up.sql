@@ -0,0 +1,22 @@
+CREATE TABLE `report_schedules` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL,
+  `report_type` varchar(50) NOT NULL,
+  `project_id` bigint(20) unsigned DEFAULT NULL,
+  `workspace_id` bigint(20) unsigned DEFAULT NULL,
+  `cadence` varchar(100) NOT NULL,
+  `recipients` text,
+  `channels` text,
+  `enabled` tinyint(1) DEFAULT 1,
+  `last_run_at` datetime(3) DEFAULT NULL,
+  `next_run_at` datetime(3) DEFAULT NULL,
+  `last_error` text,
+  `created_by` bigint(20) unsigned NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_report_schedules_next_run_at` (`enabled`, `next_run_at`),
+  CONSTRAINT `fk_report_schedules_project` FOREIGN KEY (`project_id`) REFERENCES `projects` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_report_schedules_workspace` FOREIGN KEY (`workspace_id`) REFERENCES `workspaces` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_report_schedules_user` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,14 @@
+CREATE TABLE `workspace_brandings` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `workspace_id` bigint(20) unsigned NOT NULL,
+  `logo_url` varchar(255) DEFAULT NULL,
+  `organisation_name` varchar(255) DEFAULT NULL,
+  `address_line` varchar(255) DEFAULT NULL,
+  `footer_text` varchar(255) DEFAULT NULL,
+  `accent_color` varchar(7) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_workspace_brandings_workspace_id` (`workspace_id`),
+  CONSTRAINT `fk_workspace_brandings_workspace` FOREIGN KEY (`workspace_id`) REFERENCES `workspaces` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
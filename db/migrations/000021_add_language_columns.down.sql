@@ -0,0 +1,3 @@
+ALTER TABLE `report_schedules` DROP COLUMN `language`;
+ALTER TABLE `report_jobs` DROP COLUMN `language`;
+ALTER TABLE `users` DROP COLUMN `language`;
@@ -0,0 +1,3 @@
+ALTER TABLE `users` ADD COLUMN `language` VARCHAR(5) NULL;
+ALTER TABLE `report_jobs` ADD COLUMN `language` VARCHAR(5) NOT NULL DEFAULT 'id';
+ALTER TABLE `report_schedules` ADD COLUMN `language` VARCHAR(5) NOT NULL DEFAULT 'id';
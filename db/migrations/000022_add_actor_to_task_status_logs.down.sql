@@ -0,0 +1,5 @@
+ALTER TABLE `task_status_logs`
+  DROP FOREIGN KEY `fk_task_status_logs_changed_by`,
+  DROP KEY `idx_task_status_logs_created_at`,
+  DROP COLUMN `changed_by`,
+  DROP COLUMN `previous_status`;
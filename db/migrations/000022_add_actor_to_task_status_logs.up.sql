@@ -0,0 +1,34 @@
+ALTER TABLE `task_status_logs`
+  ADD COLUMN `previous_status` varchar(255) DEFAULT NULL AFTER `status`,
+  ADD COLUMN `changed_by` bigint(20) unsigned DEFAULT NULL AFTER `previous_status`,
+  ADD KEY `idx_task_status_logs_created_at` (`created_at`),
+  ADD CONSTRAINT `fk_task_status_logs_changed_by` FOREIGN KEY (`changed_by`) REFERENCES `users` (`id`) ON DELETE SET NULL;
+
+-- Backfill previous_status from the preceding log of the same task.
+UPDATE `task_status_logs` l
+JOIN (
+  SELECT cur.`id`,
+    (SELECT prev.`status` FROM `task_status_logs` prev
+      WHERE prev.`task_id` = cur.`task_id`
+        AND (prev.`created_at` < cur.`created_at` OR (prev.`created_at` = cur.`created_at` AND prev.`id` < cur.`id`))
+      ORDER BY prev.`created_at` DESC, prev.`id` DESC
+      LIMIT 1) AS `previous_status`
+  FROM `task_status_logs` cur
+) p ON p.`id` = l.`id`
+SET l.`previous_status` = p.`previous_status`;
+
+-- Backfill changed_by from the activity log written alongside each status log.
+UPDATE `task_status_logs` l
+JOIN (
+  SELECT cur.`id`,
+    (SELECT a.`user_id` FROM `activity_logs` a
+      WHERE a.`table_name` = 'tasks'
+        AND a.`item_id` = cur.`task_id`
+        AND (a.`action` LIKE 'User changed status of task %' OR a.`action` LIKE 'User created task %')
+        AND a.`created_at` BETWEEN cur.`created_at` - INTERVAL 5 SECOND AND cur.`created_at` + INTERVAL 5 SECOND
+      ORDER BY ABS(TIMESTAMPDIFF(MICROSECOND, a.`created_at`, cur.`created_at`))
+      LIMIT 1) AS `user_id`
+  FROM `task_status_logs` cur
+) p ON p.`id` = l.`id`
+JOIN `users` u ON u.`id` = p.`user_id`
+SET l.`changed_by` = p.`user_id`;
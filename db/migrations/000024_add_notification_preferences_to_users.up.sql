@@ -0,0 +1,2 @@
+ALTER TABLE `users` ADD COLUMN `notification_preferences` JSON NULL;
+ALTER TABLE `users` ADD COLUMN `notification_webhook_url` VARCHAR(255) NULL;
@@ -0,0 +1,4 @@
+DROP INDEX `idx_tasks_due_date` ON `tasks`;
+ALTER TABLE `tasks` DROP COLUMN `escalated_at`;
+ALTER TABLE `tasks` DROP COLUMN `overdue_notified_at`;
+ALTER TABLE `tasks` DROP COLUMN `reminder_sent_at`;
@@ -0,0 +1,4 @@
+ALTER TABLE `tasks` ADD COLUMN `reminder_sent_at` datetime(3) NULL;
+ALTER TABLE `tasks` ADD COLUMN `overdue_notified_at` datetime(3) NULL;
+ALTER TABLE `tasks` ADD COLUMN `escalated_at` datetime(3) NULL;
+CREATE INDEX `idx_tasks_due_date` ON `tasks` (`due_date`);
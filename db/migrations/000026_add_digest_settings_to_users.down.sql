@@ -0,0 +1,3 @@
+ALTER TABLE `users` DROP COLUMN `last_digest_sent_at`;
+ALTER TABLE `users` DROP COLUMN `timezone`;
+ALTER TABLE `users` DROP COLUMN `digest_time`;
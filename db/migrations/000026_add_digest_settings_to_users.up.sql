@@ -0,0 +1,3 @@
+ALTER TABLE `users` ADD COLUMN `digest_time` VARCHAR(5) NULL;
+ALTER TABLE `users` ADD COLUMN `timezone` VARCHAR(64) NULL;
+ALTER TABLE `users` ADD COLUMN `last_digest_sent_at` datetime(3) NULL;
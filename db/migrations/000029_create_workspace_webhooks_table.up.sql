@@ -0,0 +1,35 @@
+CREATE TABLE `workspace_webhooks` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `workspace_id` bigint(20) unsigned NOT NULL,
+  `url` varchar(2048) NOT NULL,
+  `secret` varchar(128) NOT NULL,
+  `events` json NOT NULL,
+  `is_active` tinyint(1) NOT NULL DEFAULT 1,
+  `created_by` bigint(20) unsigned NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_workspace_webhooks_workspace_id` (`workspace_id`),
+  CONSTRAINT `fk_workspace_webhooks_workspace` FOREIGN KEY (`workspace_id`) REFERENCES `workspaces` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE `webhook_deliveries` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `webhook_id` bigint(20) unsigned NOT NULL,
+  `event` varchar(64) NOT NULL,
+  `payload` text NOT NULL,
+  `status` varchar(20) NOT NULL DEFAULT 'pending',
+  `attempts` int NOT NULL DEFAULT 0,
+  `next_attempt_at` datetime(3) NOT NULL,
+  `response_status` int DEFAULT NULL,
+  `response_body` text,
+  `last_error` text,
+  `duration_ms` bigint NOT NULL DEFAULT 0,
+  `delivered_at` datetime(3) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_webhook_deliveries_webhook_id` (`webhook_id`),
+  KEY `idx_webhook_deliveries_due` (`status`, `next_attempt_at`),
+  CONSTRAINT `fk_webhook_deliveries_webhook` FOREIGN KEY (`webhook_id`) REFERENCES `workspace_webhooks` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
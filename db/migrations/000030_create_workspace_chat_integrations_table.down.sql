@@ -0,0 +1 @@
+DROP TABLE `workspace_chat_integrations`;
@@ -0,0 +1,15 @@
+CREATE TABLE `workspace_chat_integrations` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `workspace_id` bigint(20) unsigned NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `provider` varchar(20) NOT NULL,
+  `webhook_url` varchar(2048) NOT NULL,
+  `events` json NOT NULL,
+  `is_active` tinyint(1) NOT NULL DEFAULT 1,
+  `created_by` bigint(20) unsigned NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_workspace_chat_integrations_workspace_id` (`workspace_id`),
+  CONSTRAINT `fk_workspace_chat_integrations_workspace` FOREIGN KEY (`workspace_id`) REFERENCES `workspaces` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
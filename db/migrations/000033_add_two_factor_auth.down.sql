@@ -0,0 +1,9 @@
+DROP TABLE `user_recovery_codes`;
+
+ALTER TABLE `workspaces`
+  DROP COLUMN `require_admin_two_factor`;
+
+ALTER TABLE `users`
+  DROP COLUMN `two_factor_enabled`,
+  DROP COLUMN `two_factor_secret`,
+  DROP COLUMN `two_factor_last_counter`;
@@ -0,0 +1,19 @@
+ALTER TABLE `users`
+  ADD COLUMN `two_factor_enabled` tinyint(1) NOT NULL DEFAULT 0,
+  ADD COLUMN `two_factor_secret` varchar(64) DEFAULT NULL,
+  ADD COLUMN `two_factor_last_counter` bigint(20) NOT NULL DEFAULT 0;
+
+ALTER TABLE `workspaces`
+  ADD COLUMN `require_admin_two_factor` tinyint(1) NOT NULL DEFAULT 0;
+
+CREATE TABLE `user_recovery_codes` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) unsigned NOT NULL,
+  `code_hash` varchar(64) NOT NULL,
+  `used_at` datetime(3) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_user_recovery_codes_code_hash` (`code_hash`),
+  KEY `idx_user_recovery_codes_user_id` (`user_id`),
+  CONSTRAINT `fk_user_recovery_codes_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
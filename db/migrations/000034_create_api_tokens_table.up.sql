@@ -0,0 +1,23 @@
+CREATE TABLE `api_tokens` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `kind` varchar(16) NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `prefix` varchar(16) NOT NULL,
+  `token_hash` varchar(64) NOT NULL,
+  `scopes` json NOT NULL,
+  `user_id` bigint(20) unsigned NOT NULL,
+  `workspace_id` bigint(20) unsigned DEFAULT NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `last_used_at` datetime(3) DEFAULT NULL,
+  `last_used_ip` varchar(64) NOT NULL DEFAULT '',
+  `revoked_at` datetime(3) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_api_tokens_token_hash` (`token_hash`),
+  KEY `idx_api_tokens_kind` (`kind`),
+  KEY `idx_api_tokens_user_id` (`user_id`),
+  KEY `idx_api_tokens_workspace_id` (`workspace_id`),
+  CONSTRAINT `fk_api_tokens_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_api_tokens_workspace` FOREIGN KEY (`workspace_id`) REFERENCES `workspaces` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
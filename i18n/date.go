@@ -0,0 +1,41 @@
+package i18n
+
+import (
+	"fmt"
+	"time"
+)
+
+var idMonths = [...]string{"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+
+var idShortMonths = [...]string{"Jan", "Feb", "Mar", "Apr", "Mei", "Jun", "Jul", "Agu", "Sep", "Okt", "Nov", "Des"}
+
+// FormatDate formats a numeric date, e.g. 31-12-2024 (id) or 12/31/2024 (en).
+func FormatDate(lang string, t time.Time) string {
+	if lang == LangEN {
+		return t.Format("01/02/2006")
+	}
+	return t.Format("02-01-2006")
+}
+
+// FormatDateTime formats a numeric date followed by the 24h time.
+func FormatDateTime(lang string, t time.Time) string {
+	return FormatDate(lang, t) + " " + t.Format("15:04")
+}
+
+// FormatMediumDate formats a date with an abbreviated month, e.g. 31 Des 2024
+// (id) or Dec 31, 2024 (en).
+func FormatMediumDate(lang string, t time.Time) string {
+	if lang == LangEN {
+		return t.Format("Jan 02, 2006")
+	}
+	return fmt.Sprintf("%02d %s %d", t.Day(), idShortMonths[t.Month()-1], t.Year())
+}
+
+// FormatLongDate formats a date with the full month name, e.g. 31 Desember
+// 2024 (id) or December 31, 2024 (en).
+func FormatLongDate(lang string, t time.Time) string {
+	if lang == LangEN {
+		return t.Format("January 02, 2006")
+	}
+	return fmt.Sprintf("%02d %s %d", t.Day(), idMonths[t.Month()-1], t.Year())
+}
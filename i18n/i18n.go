@@ -0,0 +1,122 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	LangID = "id"
+	LangEN = "en"
+
+	DefaultLang = LangID
+)
+
+// IsSupported reports whether lang has a catalog.
+func IsSupported(lang string) bool {
+	return lang == LangID || lang == LangEN
+}
+
+// Normalize reduces a language tag such as "en-US" to a supported language,
+// returning an empty string when it is not supported.
+func Normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	if IsSupported(tag) {
+		return tag
+	}
+	return ""
+}
+
+// ParseAcceptLanguage picks the supported language with the highest q value
+// from an Accept-Language header.
+func ParseAcceptLanguage(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		lang := Normalize(fields[0])
+		if lang == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > bestQ {
+			best, bestQ = lang, q
+		}
+	}
+	return best
+}
+
+// Resolve picks the language for a request. An explicit override (the ?lang
+// query parameter) wins, then the user's saved preference, then the
+// Accept-Language header, then DefaultLang.
+func Resolve(override string, preferred string, acceptLanguage string) string {
+	if lang := Normalize(override); lang != "" {
+		return lang
+	}
+	if lang := Normalize(preferred); lang != "" {
+		return lang
+	}
+	if lang := ParseAcceptLanguage(acceptLanguage); lang != "" {
+		return lang
+	}
+	return DefaultLang
+}
+
+// T returns the message for code in lang, falling back to DefaultLang and
+// finally to the code itself.
+func T(lang string, code string, args ...interface{}) string {
+	entry, ok := messages[code]
+	if !ok {
+		entry, ok = labels[code]
+	}
+	if !ok {
+		return code
+	}
+
+	msg, ok := entry[lang]
+	if !ok {
+		msg = entry[DefaultLang]
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Error is an error carrying a stable code. Error() renders it in
+// DefaultLang so it still reads naturally in logs.
+type Error struct {
+	Code string
+	Args []interface{}
+}
+
+func NewError(code string, args ...interface{}) *Error {
+	return &Error{Code: code, Args: args}
+}
+
+func (e *Error) Error() string {
+	return T(DefaultLang, e.Code, e.Args...)
+}
+
+// Localize returns the code and localized message for err. Errors without a
+// code are returned unchanged with an empty code.
+func Localize(lang string, err error) (string, string) {
+	var coded *Error
+	if errors.As(err, &coded) {
+		return coded.Code, T(lang, coded.Code, coded.Args...)
+	}
+	return "", err.Error()
+}
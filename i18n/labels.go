@@ -0,0 +1,237 @@
+package i18n
+
+// Label keys used by the PDF templates.
+const (
+	LabelWeeklyTitle     = "pdf.weekly.title"
+	LabelDailyTitle      = "pdf.daily.title"
+	LabelMonitoringTitle = "pdf.monitoring.title"
+	LabelAttendanceTitle = "pdf.attendance.title"
+	LabelPreviewTitle    = "pdf.preview.title"
+
+	LabelDivision       = "pdf.division"
+	LabelReportTitle    = "pdf.meta.report_title"
+	LabelReportSubject  = "pdf.meta.report_subject"
+	LabelPeriod         = "pdf.meta.period"
+	LabelWorkingDays    = "pdf.meta.working_days"
+	LabelWorkingDaysVal = "pdf.meta.working_days_value"
+	LabelAgenda         = "pdf.meta.agenda"
+	LabelDailyPeriod    = "pdf.meta.daily_period"
+
+	LabelNo             = "pdf.col.no"
+	LabelTask           = "pdf.col.task"
+	LabelAssignee       = "pdf.col.assignee"
+	LabelStatus         = "pdf.col.status"
+	LabelPriority       = "pdf.col.priority"
+	LabelStartDate      = "pdf.col.start_date"
+	LabelStartDay       = "pdf.col.start_day"
+	LabelDeadline       = "pdf.col.deadline"
+	LabelFinishedAt     = "pdf.col.finished_at"
+	LabelNotes          = "pdf.col.notes"
+	LabelLastUpdated    = "pdf.col.last_updated"
+	LabelSubAgenda      = "pdf.col.sub_agenda"
+	LabelLastStatus     = "pdf.col.last_status"
+	LabelResolutionTime = "pdf.col.resolution_time"
+	LabelEstimate       = "pdf.col.estimate"
+	LabelDuration       = "pdf.col.duration"
+
+	LabelPreparedBy     = "pdf.footer.prepared_by"
+	LabelPersonInCharge = "pdf.footer.person_in_charge"
+
+	LabelEmployeeData   = "pdf.attendance.employee_data"
+	LabelEmployeeName   = "pdf.attendance.employee_name"
+	LabelClockIn        = "pdf.attendance.clock_in"
+	LabelActivity       = "pdf.attendance.activity"
+	LabelObstacle       = "pdf.attendance.obstacle"
+	LabelPhotoEvidence  = "pdf.attendance.photo"
+	LabelNoImage        = "pdf.attendance.no_image"
+	LabelAttendancePage = "pdf.attendance.page"
+
+	LabelSampleTask   = "pdf.preview.sample_task"
+	LabelSampleMember = "pdf.preview.sample_member"
+
+	LabelDurationDays    = "duration.days"
+	LabelDurationHours   = "duration.hours"
+	LabelDurationMinutes = "duration.minutes"
+	LabelDurationLess    = "duration.less_than_minute"
+
+	LabelNotifAssignedTitle      = "notification.assigned.title"
+	LabelNotifAssignedMessage    = "notification.assigned.message"
+	LabelNotifStatusTitle        = "notification.status_changed.title"
+	LabelNotifStatusMessage      = "notification.status_changed.message"
+	LabelNotifMentionTitle       = "notification.mention.title"
+	LabelNotifMentionMessage     = "notification.mention.message"
+	LabelNotifDueSoonTitle       = "notification.due_soon.title"
+	LabelNotifDueSoonMessage     = "notification.due_soon.message"
+	LabelNotifOverdueTitle       = "notification.overdue.title"
+	LabelNotifOverdueMessage     = "notification.overdue.message"
+	LabelNotifEscalatedTitle     = "notification.overdue_escalated.title"
+	LabelNotifEscalatedMessage   = "notification.overdue_escalated.message"
+	LabelNotifReportReadyTitle   = "notification.report_ready.title"
+	LabelNotifReportReadyMessage = "notification.report_ready.message"
+
+	LabelDigestTitle             = "digest.title"
+	LabelDigestDueToday          = "digest.due_today"
+	LabelDigestOverdue           = "digest.overdue"
+	LabelDigestAssignedYesterday = "digest.assigned_yesterday"
+	LabelDigestAttendanceMissing = "digest.attendance_missing"
+	LabelDigestTaskLine          = "digest.task_line"
+
+	LabelTelegramHelp           = "telegram.help"
+	LabelTelegramNotLinked      = "telegram.not_linked"
+	LabelTelegramLinkUsage      = "telegram.link_usage"
+	LabelTelegramLinkInvalid    = "telegram.link_invalid"
+	LabelTelegramLinked         = "telegram.linked"
+	LabelTelegramNoTasks        = "telegram.no_tasks"
+	LabelTelegramMyTasks        = "telegram.my_tasks"
+	LabelTelegramTaskLine       = "telegram.task_line"
+	LabelTelegramDoneUsage      = "telegram.done_usage"
+	LabelTelegramDone           = "telegram.done"
+	LabelTelegramAttendUsage    = "telegram.attend_usage"
+	LabelTelegramAttendChoose   = "telegram.attend_choose_workspace"
+	LabelTelegramAttended       = "telegram.attended"
+	LabelTelegramNoWorkspace    = "telegram.no_workspace"
+	LabelTelegramUnknownCommand = "telegram.unknown_command"
+	LabelTelegramFailed         = "telegram.failed"
+
+	LabelMailGreeting             = "mail.greeting"
+	LabelMailFooter               = "mail.footer"
+	LabelMailOpenApp              = "mail.open_app"
+	LabelMailNotificationSettings = "mail.notification_settings"
+	LabelMailReportSubject        = "mail.report.subject"
+	LabelMailReportBody           = "mail.report.body"
+	LabelMailReportAttachment     = "mail.report.attachment"
+	LabelMailInvitationSubject    = "mail.invitation.subject"
+	LabelMailInvitationBody       = "mail.invitation.body"
+	LabelMailPasswordResetSubject = "mail.password_reset.subject"
+	LabelMailPasswordResetBody    = "mail.password_reset.body"
+	LabelMailPasswordResetButton  = "mail.password_reset.button"
+	LabelMailPasswordResetIgnore  = "mail.password_reset.ignore"
+
+	LabelChatAssigned      = "chat.assigned"
+	LabelChatStatusChanged = "chat.status_changed"
+	LabelChatOverdue       = "chat.overdue"
+	LabelChatTestTitle     = "chat.test.title"
+	LabelChatTestMessage   = "chat.test.message"
+
+	// Pesan sukses API
+	LabelMsgReportJobQueued     = "msg.report_job.queued"
+	LabelMsgProfileImageDeleted = "msg.profile.image_deleted"
+	LabelMsgTelegramLinkCode    = "msg.telegram.link_code"
+)
+
+var labels = map[string]map[string]string{
+	LabelWeeklyTitle:     {LangID: "LAPORAN HASIL KERJA MINGGUAN", LangEN: "WEEKLY WORK REPORT"},
+	LabelDailyTitle:      {LangID: "LAPORAN HASIL KERJA HARIAN", LangEN: "DAILY WORK REPORT"},
+	LabelMonitoringTitle: {LangID: "LAPORAN HASIL MONITORING MINGGUAN", LangEN: "WEEKLY MONITORING REPORT"},
+	LabelAttendanceTitle: {LangID: "LAPORAN ABSENSI HARIAN", LangEN: "DAILY ATTENDANCE REPORT"},
+	LabelPreviewTitle:    {LangID: "PRATINJAU LAPORAN", LangEN: "REPORT PREVIEW"},
+
+	LabelDivision:       {LangID: "Divisi", LangEN: "Division"},
+	LabelReportTitle:    {LangID: "Judul Laporan", LangEN: "Report Title"},
+	LabelReportSubject:  {LangID: "Laporan Hasil Kerja Tim %s", LangEN: "%s Team Work Report"},
+	LabelPeriod:         {LangID: "Periode Kerja", LangEN: "Work Period"},
+	LabelWorkingDays:    {LangID: "Hari Kerja", LangEN: "Working Days"},
+	LabelWorkingDaysVal: {LangID: "Senin - Sabtu", LangEN: "Monday - Saturday"},
+	LabelAgenda:         {LangID: "Agenda", LangEN: "Agenda"},
+	LabelDailyPeriod:    {LangID: "Laporan Harian - %s", LangEN: "Daily Report - %s"},
+
+	LabelNo:             {LangID: "No", LangEN: "No"},
+	LabelTask:           {LangID: "Tugas", LangEN: "Task"},
+	LabelAssignee:       {LangID: "Penanggung Jawab", LangEN: "Assignee"},
+	LabelStatus:         {LangID: "Status", LangEN: "Status"},
+	LabelPriority:       {LangID: "Kondisi", LangEN: "Priority"},
+	LabelStartDate:      {LangID: "Tgl Mulai", LangEN: "Start Date"},
+	LabelStartDay:       {LangID: "Hari/Tanggal\nMulai", LangEN: "Start\nDate"},
+	LabelDeadline:       {LangID: "Deadline", LangEN: "Deadline"},
+	LabelFinishedAt:     {LangID: "Waktu Selesai", LangEN: "Finished At"},
+	LabelNotes:          {LangID: "Catatan", LangEN: "Notes"},
+	LabelLastUpdated:    {LangID: "Terakhir Diperbarui", LangEN: "Last Updated"},
+	LabelSubAgenda:      {LangID: "Sub-Agenda", LangEN: "Sub-Agenda"},
+	LabelLastStatus:     {LangID: "Status Terakhir", LangEN: "Last Status"},
+	LabelResolutionTime: {LangID: "Wkt Resolusi (Menit)", LangEN: "Resolution Time"},
+	LabelEstimate:       {LangID: "Estimasi", LangEN: "Estimate"},
+	LabelDuration:       {LangID: "Durasi", LangEN: "Duration"},
+
+	LabelPreparedBy:     {LangID: "Disusun oleh,", LangEN: "Prepared by,"},
+	LabelPersonInCharge: {LangID: "Person In Charge", LangEN: "Person In Charge"},
+
+	LabelEmployeeData:   {LangID: "DATA KARYAWAN", LangEN: "EMPLOYEE DATA"},
+	LabelEmployeeName:   {LangID: "Nama Karyawan", LangEN: "Employee Name"},
+	LabelClockIn:        {LangID: "Waktu Absen", LangEN: "Clock-in Time"},
+	LabelActivity:       {LangID: "Kegiatan yang Dilakukan :", LangEN: "Activities Performed :"},
+	LabelObstacle:       {LangID: "Kendala yang Dihadapi    :", LangEN: "Obstacles Encountered :"},
+	LabelPhotoEvidence:  {LangID: "Bukti Foto:", LangEN: "Photo Evidence:"},
+	LabelNoImage:        {LangID: "(Tidak ada gambar)", LangEN: "(No image)"},
+	LabelAttendancePage: {LangID: "Halaman %d dari %d | Diterbitkan: %s", LangEN: "Page %d of %d | Issued: %s"},
+
+	LabelSampleTask:   {LangID: "Contoh tugas %d", LangEN: "Sample task %d"},
+	LabelSampleMember: {LangID: "Nama Anggota", LangEN: "Member Name"},
+
+	LabelDurationDays:    {LangID: "%d Hari", LangEN: "%d Days"},
+	LabelDurationHours:   {LangID: "%d Jam %dm", LangEN: "%dh %dm"},
+	LabelDurationMinutes: {LangID: "%dm", LangEN: "%dm"},
+	LabelDurationLess:    {LangID: "< 1 menit", LangEN: "< 1 minute"},
+
+	LabelNotifAssignedTitle:      {LangID: "Tugas baru", LangEN: "New task"},
+	LabelNotifAssignedMessage:    {LangID: "%s menugaskan Anda ke task \"%s\", kerjakan sebelum %s", LangEN: "%s assigned you to \"%s\", due %s"},
+	LabelNotifStatusTitle:        {LangID: "Status task berubah", LangEN: "Task status changed"},
+	LabelNotifStatusMessage:      {LangID: "%s mengubah status \"%s\" dari %s menjadi %s", LangEN: "%s changed \"%s\" from %s to %s"},
+	LabelNotifMentionTitle:       {LangID: "Anda disebut", LangEN: "You were mentioned"},
+	LabelNotifMentionMessage:     {LangID: "%s menyebut Anda di task \"%s\"", LangEN: "%s mentioned you in \"%s\""},
+	LabelNotifDueSoonTitle:       {LangID: "Deadline segera tiba", LangEN: "Task due soon"},
+	LabelNotifDueSoonMessage:     {LangID: "Task \"%s\" harus selesai sebelum %s", LangEN: "\"%s\" is due %s"},
+	LabelNotifOverdueTitle:       {LangID: "Task terlambat", LangEN: "Task overdue"},
+	LabelNotifOverdueMessage:     {LangID: "Task \"%s\" melewati deadline %s", LangEN: "\"%s\" passed its deadline of %s"},
+	LabelNotifEscalatedTitle:     {LangID: "Eskalasi task terlambat", LangEN: "Overdue task escalated"},
+	LabelNotifEscalatedMessage:   {LangID: "Task \"%s\" masih belum selesai sejak deadline %s (penanggung jawab: %s)", LangEN: "\"%s\" is still open since its deadline of %s (assignees: %s)"},
+	LabelNotifReportReadyTitle:   {LangID: "Report siap", LangEN: "Report ready"},
+	LabelNotifReportReadyMessage: {LangID: "Report %s siap diunduh", LangEN: "Report %s is ready to download"},
+
+	LabelDigestTitle:             {LangID: "Ringkasan harian %s", LangEN: "Daily digest for %s"},
+	LabelDigestDueToday:          {LangID: "Deadline hari ini", LangEN: "Due today"},
+	LabelDigestOverdue:           {LangID: "Terlambat", LangEN: "Overdue"},
+	LabelDigestAssignedYesterday: {LangID: "Ditugaskan kemarin", LangEN: "Assigned yesterday"},
+	LabelDigestAttendanceMissing: {LangID: "Anda belum mengirim absensi hari ini.", LangEN: "You have not submitted today's attendance yet."},
+	LabelDigestTaskLine:          {LangID: "- %s (%s), deadline %s", LangEN: "- %s (%s), due %s"},
+
+	LabelTelegramHelp:           {LangID: "Perintah yang tersedia:\n/mytasks - daftar task Anda yang belum selesai\n/done <id> - tandai task selesai\n/attend <kegiatan> - kirim absensi hari ini\n/link <kode> - hubungkan chat ini ke akun Anda", LangEN: "Available commands:\n/mytasks - list your open tasks\n/done <id> - mark a task as done\n/attend <activity> - submit today's attendance\n/link <code> - connect this chat to your account"},
+	LabelTelegramNotLinked:      {LangID: "Chat ini belum terhubung ke akun. Buat kode di halaman profil lalu kirim /link <kode>.", LangEN: "This chat is not linked to an account yet. Create a code on your profile page and send /link <code>."},
+	LabelTelegramLinkUsage:      {LangID: "Gunakan: /link <kode>", LangEN: "Usage: /link <code>"},
+	LabelTelegramLinkInvalid:    {LangID: "Kode tidak valid atau sudah kedaluwarsa.", LangEN: "The code is invalid or has expired."},
+	LabelTelegramLinked:         {LangID: "Chat ini sekarang terhubung ke akun %s.", LangEN: "This chat is now linked to %s."},
+	LabelTelegramNoTasks:        {LangID: "Tidak ada task yang belum selesai.", LangEN: "You have no open tasks."},
+	LabelTelegramMyTasks:        {LangID: "Task Anda (%d):", LangEN: "Your tasks (%d):"},
+	LabelTelegramTaskLine:       {LangID: "#%d %s [%s], deadline %s", LangEN: "#%d %s [%s], due %s"},
+	LabelTelegramDoneUsage:      {LangID: "Gunakan: /done <id task>", LangEN: "Usage: /done <task id>"},
+	LabelTelegramDone:           {LangID: "Task #%d \"%s\" ditandai selesai.", LangEN: "Task #%d \"%s\" marked as done."},
+	LabelTelegramAttendUsage:    {LangID: "Gunakan: /attend <kegiatan> atau /attend #<id workspace> <kegiatan>", LangEN: "Usage: /attend <activity> or /attend #<workspace id> <activity>"},
+	LabelTelegramAttendChoose:   {LangID: "Anda tergabung di beberapa workspace. Kirim /attend #<id workspace> <kegiatan>:\n%s", LangEN: "You belong to several workspaces. Send /attend #<workspace id> <activity>:\n%s"},
+	LabelTelegramAttended:       {LangID: "Absensi di workspace %s tercatat pukul %s.", LangEN: "Attendance in %s recorded at %s."},
+	LabelTelegramNoWorkspace:    {LangID: "Anda belum tergabung di workspace mana pun.", LangEN: "You are not a member of any workspace."},
+	LabelTelegramUnknownCommand: {LangID: "Perintah tidak dikenal. Kirim /help untuk daftar perintah.", LangEN: "Unknown command. Send /help for the list of commands."},
+	LabelTelegramFailed:         {LangID: "Gagal: %s", LangEN: "Failed: %s"},
+
+	LabelMailGreeting:             {LangID: "Halo %s,", LangEN: "Hi %s,"},
+	LabelMailFooter:               {LangID: "Email ini dikirim otomatis oleh %s. Mohon tidak membalas email ini.", LangEN: "This email was sent automatically by %s. Please do not reply."},
+	LabelMailOpenApp:              {LangID: "Buka aplikasi", LangEN: "Open the app"},
+	LabelMailNotificationSettings: {LangID: "Notifikasi email dapat diatur di halaman profil.", LangEN: "You can change email notifications on your profile page."},
+	LabelMailReportSubject:        {LangID: "Report terjadwal: %s (%s)", LangEN: "Scheduled report: %s (%s)"},
+	LabelMailReportBody:           {LangID: "Terlampir report terjadwal \"%s\".", LangEN: "Attached is the scheduled report \"%s\"."},
+	LabelMailReportAttachment:     {LangID: "Lampiran: %s", LangEN: "Attachment: %s"},
+	LabelMailInvitationSubject:    {LangID: "Anda ditambahkan ke workspace %s", LangEN: "You were added to the %s workspace"},
+	LabelMailInvitationBody:       {LangID: "%s menambahkan Anda ke workspace \"%s\". Masuk untuk melihat project dan task di dalamnya.", LangEN: "%s added you to the \"%s\" workspace. Sign in to see its projects and tasks."},
+	LabelMailPasswordResetSubject: {LangID: "Reset password", LangEN: "Reset your password"},
+	LabelMailPasswordResetBody:    {LangID: "Kami menerima permintaan reset password untuk akun Anda. Gunakan tautan berikut dalam %d menit:", LangEN: "We received a request to reset the password of your account. Use the link below within %d minutes:"},
+	LabelMailPasswordResetButton:  {LangID: "Buat password baru", LangEN: "Choose a new password"},
+	LabelMailPasswordResetIgnore:  {LangID: "Abaikan email ini jika Anda tidak meminta reset password.", LangEN: "If you did not request a password reset, you can ignore this email."},
+
+	LabelChatAssigned:      {LangID: "%s menugaskan %s ke task \"%s\" (deadline %s)", LangEN: "%s assigned %s to \"%s\" (due %s)"},
+	LabelChatStatusChanged: {LangID: "%s mengubah status task \"%s\" dari %s menjadi %s", LangEN: "%s moved \"%s\" from %s to %s"},
+	LabelChatOverdue:       {LangID: "Task \"%s\" melewati deadline %s (penanggung jawab: %s)", LangEN: "\"%s\" passed its deadline of %s (assignees: %s)"},
+	LabelChatTestTitle:     {LangID: "Tes integrasi chat", LangEN: "Chat integration test"},
+	LabelChatTestMessage:   {LangID: "Integrasi \"%s\" berhasil terhubung.", LangEN: "The \"%s\" integration is connected."},
+
+	LabelMsgReportJobQueued:     {LangID: "Report sedang diproses", LangEN: "The report is being generated"},
+	LabelMsgProfileImageDeleted: {LangID: "Foto profil berhasil dihapus", LangEN: "Profile image deleted successfully"},
+	LabelMsgTelegramLinkCode:    {LangID: "Kirim /link <kode> ke bot Telegram untuk menghubungkan akun", LangEN: "Send /link <code> to the Telegram bot to link your account"},
+}
@@ -0,0 +1,369 @@
+package i18n
+
+// Error codes are stable identifiers returned to clients alongside the
+// localized message. Never rename a code once it has been released.
+const (
+	CodeAccentColorInvalid                = "ACCENT_COLOR_INVALID"
+	CodeProjectMembersAccessDenied        = "PROJECT_MEMBERS_ACCESS_DENIED"
+	CodeReportJobAccessDenied             = "REPORT_JOB_ACCESS_DENIED"
+	CodeTaskAccessDenied                  = "TASK_ACCESS_DENIED"
+	CodeWorkspaceAccessDenied             = "WORKSPACE_ACCESS_DENIED"
+	CodeNotTaskMember                     = "NOT_TASK_MEMBER"
+	CodeNotTaskMemberUpdate               = "NOT_TASK_MEMBER_UPDATE"
+	CodeNotWorkspaceMember                = "NOT_WORKSPACE_MEMBER"
+	CodeNotMember                         = "NOT_MEMBER"
+	CodeTaskUpdateFieldsRestricted        = "TASK_UPDATE_FIELDS_RESTRICTED"
+	CodeProjectAccessDenied               = "PROJECT_ACCESS_DENIED"
+	CodeAttendanceAlreadySubmitted        = "ATTENDANCE_ALREADY_SUBMITTED"
+	CodeInvalidCredentials                = "INVALID_CREDENTIALS"
+	CodeEmailAlreadyRegistered            = "EMAIL_ALREADY_REGISTERED"
+	CodeReportExpired                     = "REPORT_EXPIRED"
+	CodeReportFileNotFound                = "REPORT_FILE_NOT_FOUND"
+	CodeFileTooLarge                      = "FILE_TOO_LARGE"
+	CodeFileTypeNotAllowed                = "FILE_TYPE_NOT_ALLOWED"
+	CodeLogoTypeNotAllowed                = "LOGO_TYPE_NOT_ALLOWED"
+	CodeImageTypeNotAllowed               = "IMAGE_TYPE_NOT_ALLOWED"
+	CodeTokenGenerationFailed             = "TOKEN_GENERATION_FAILED"
+	CodeUploadPathFailed                  = "UPLOAD_PATH_FAILED"
+	CodeUploadDirFailed                   = "UPLOAD_DIR_FAILED"
+	CodeReportJobCreateFailed             = "REPORT_JOB_CREATE_FAILED"
+	CodeReportQueueBusy                   = "REPORT_QUEUE_BUSY"
+	CodeProjectMembershipCheckFailed      = "PROJECT_MEMBERSHIP_CHECK_FAILED"
+	CodeProjectAdminCheckFailed           = "PROJECT_ADMIN_CHECK_FAILED"
+	CodeProjectAccessCheckFailed          = "PROJECT_ACCESS_CHECK_FAILED"
+	CodeTaskMemberCheckFailed             = "TASK_MEMBER_CHECK_FAILED"
+	CodeMemberCheckFailed                 = "MEMBER_CHECK_FAILED"
+	CodeProjectCreatorMemberFailed        = "PROJECT_CREATOR_MEMBER_FAILED"
+	CodeWorkspaceCreatorMemberFailed      = "WORKSPACE_CREATOR_MEMBER_FAILED"
+	CodeProjectFetchFailed                = "PROJECT_FETCH_FAILED"
+	CodePasswordHashFailed                = "PASSWORD_HASH_FAILED"
+	CodeReportScheduleUpdateFailed        = "REPORT_SCHEDULE_UPDATE_FAILED"
+	CodeReportScheduleSaveFailed          = "REPORT_SCHEDULE_SAVE_FAILED"
+	CodeAdminOnlySearchUsers              = "ADMIN_ONLY_SEARCH_USERS"
+	CodeAdminOnlyMultipleUsers            = "ADMIN_ONLY_MULTIPLE_USERS"
+	CodeAdminOnlyAllUsers                 = "ADMIN_ONLY_ALL_USERS"
+	CodeAdminOnlyCreateWorkspace          = "ADMIN_ONLY_CREATE_WORKSPACE"
+	CodeOwnProfileOnly                    = "OWN_PROFILE_ONLY"
+	CodeWorkspaceCreatorOnlyHardDelete    = "WORKSPACE_CREATOR_ONLY_HARD_DELETE"
+	CodeWorkspaceCreatorOnlyUpdate        = "WORKSPACE_CREATOR_ONLY_UPDATE"
+	CodeWorkspaceCreatorOnlySoftDelete    = "WORKSPACE_CREATOR_ONLY_SOFT_DELETE"
+	CodeProjectMemberOnlyTasks            = "PROJECT_MEMBER_ONLY_TASKS"
+	CodeProjectMemberOnlyImages           = "PROJECT_MEMBER_ONLY_IMAGES"
+	CodeProjectMemberOnlyTaskMembers      = "PROJECT_MEMBER_ONLY_TASK_MEMBERS"
+	CodeProjectMemberOnlyUpload           = "PROJECT_MEMBER_ONLY_UPLOAD"
+	CodeWorkspaceMemberOnlyProjectMembers = "WORKSPACE_MEMBER_ONLY_PROJECT_MEMBERS"
+	CodeTaskMemberOnlyImages              = "TASK_MEMBER_ONLY_IMAGES"
+	CodeTaskMemberOnlyUpload              = "TASK_MEMBER_ONLY_UPLOAD"
+	CodeUploaderOnlyDelete                = "UPLOADER_ONLY_DELETE"
+	CodeImageNotFound                     = "IMAGE_NOT_FOUND"
+	CodeReportScheduleNotFound            = "REPORT_SCHEDULE_NOT_FOUND"
+	CodeWorkspaceLogoNotFound             = "WORKSPACE_LOGO_NOT_FOUND"
+	CodeProjectMemberNotFound             = "PROJECT_MEMBER_NOT_FOUND"
+	CodeWorkspaceMemberNotFound           = "WORKSPACE_MEMBER_NOT_FOUND"
+	CodeSearchQueryTooShort               = "SEARCH_QUERY_TOO_SHORT"
+	CodeReportChannelRequired             = "REPORT_CHANNEL_REQUIRED"
+	CodeReportRecipientRequired           = "REPORT_RECIPIENT_REQUIRED"
+	CodeProjectNotInWorkspace             = "PROJECT_NOT_IN_WORKSPACE"
+	CodeProjectNotFound                   = "PROJECT_NOT_FOUND"
+	CodeProjectWorkspaceDeleted           = "PROJECT_WORKSPACE_DELETED"
+	CodeReportProjectRequired             = "REPORT_PROJECT_REQUIRED"
+	CodeReportNotReady                    = "REPORT_NOT_READY"
+	CodeReportJobNotFound                 = "REPORT_JOB_NOT_FOUND"
+	CodeNotificationNotFound              = "NOTIFICATION_NOT_FOUND"
+	CodeNotificationPrefsInvalid          = "NOTIFICATION_PREFERENCES_INVALID"
+	CodeNotificationEventUnknown          = "NOTIFICATION_EVENT_UNKNOWN"
+	CodeNotificationChannelUnknown        = "NOTIFICATION_CHANNEL_UNKNOWN"
+	CodeWebhookURLInvalid                 = "WEBHOOK_URL_INVALID"
+	CodeWebhookURLBlocked                 = "WEBHOOK_URL_BLOCKED"
+	CodeWebhookURLUnresolvable            = "WEBHOOK_URL_UNRESOLVABLE"
+	CodeWebhookNotFound                   = "WEBHOOK_NOT_FOUND"
+	CodeWebhookEventsRequired             = "WEBHOOK_EVENTS_REQUIRED"
+	CodeWebhookEventUnknown               = "WEBHOOK_EVENT_UNKNOWN"
+	CodeWebhookDeliveryNotFound           = "WEBHOOK_DELIVERY_NOT_FOUND"
+	CodeWebhookDeliveryInProgress         = "WEBHOOK_DELIVERY_IN_PROGRESS"
+	CodeChatIntegrationNotFound           = "CHAT_INTEGRATION_NOT_FOUND"
+	CodeChatIntegrationRequired           = "CHAT_INTEGRATION_REQUIRED"
+	CodeChatProviderUnknown               = "CHAT_PROVIDER_UNKNOWN"
+	CodeChatEventUnknown                  = "CHAT_EVENT_UNKNOWN"
+	CodeChatTestFailed                    = "CHAT_TEST_FAILED"
+	CodeWsMessageInvalid                  = "WS_MESSAGE_INVALID"
+	CodeWsActionUnknown                   = "WS_ACTION_UNKNOWN"
+	CodeWsTopicInvalid                    = "WS_TOPIC_INVALID"
+	CodeWsTopicForbidden                  = "WS_TOPIC_FORBIDDEN"
+	CodeWsTooManyTopics                   = "WS_TOO_MANY_TOPICS"
+	CodeWsPresenceInvalid                 = "WS_PRESENCE_INVALID"
+	CodeWsIndicatorInvalid                = "WS_INDICATOR_INVALID"
+	CodeWsNotSubscribed                   = "WS_NOT_SUBSCRIBED"
+	CodeRefreshTokenInvalid               = "REFRESH_TOKEN_INVALID"
+	CodeRefreshTokenReused                = "REFRESH_TOKEN_REUSED"
+	CodeSessionRevoked                    = "SESSION_REVOKED"
+	CodeSessionNotFound                   = "SESSION_NOT_FOUND"
+	CodeCurrentPasswordWrong              = "CURRENT_PASSWORD_WRONG"
+	CodePasswordUnchanged                 = "PASSWORD_UNCHANGED"
+	CodeResetTokenInvalid                 = "RESET_TOKEN_INVALID"
+	CodeTwoFactorCodeInvalid              = "TWO_FACTOR_CODE_INVALID"
+	CodeTwoFactorChallengeInvalid         = "TWO_FACTOR_CHALLENGE_INVALID"
+	CodeTwoFactorTooManyAttempts          = "TWO_FACTOR_TOO_MANY_ATTEMPTS"
+	CodeTwoFactorAlreadyEnabled           = "TWO_FACTOR_ALREADY_ENABLED"
+	CodeTwoFactorNotEnabled               = "TWO_FACTOR_NOT_ENABLED"
+	CodeTwoFactorSetupRequired            = "TWO_FACTOR_SETUP_REQUIRED"
+	CodeWorkspaceTwoFactorRequired        = "WORKSPACE_TWO_FACTOR_REQUIRED"
+	CodeAPITokenInvalid                   = "API_TOKEN_INVALID"
+	CodeAPITokenNotFound                  = "API_TOKEN_NOT_FOUND"
+	CodeAPITokenNameRequired              = "API_TOKEN_NAME_REQUIRED"
+	CodeAPITokenScopesInvalid             = "API_TOKEN_SCOPES_INVALID"
+	CodeAPITokenExpiryInvalid             = "API_TOKEN_EXPIRY_INVALID"
+	CodeAPITokenScopeInsufficient         = "API_TOKEN_SCOPE_INSUFFICIENT"
+	CodeAPITokenWorkspaceMismatch         = "API_TOKEN_WORKSPACE_MISMATCH"
+	CodeSessionAuthRequired               = "SESSION_AUTH_REQUIRED"
+	CodeMemberNotInWorkspace              = "MEMBER_NOT_IN_WORKSPACE"
+	CodeMemberValidationFailed            = "MEMBER_VALIDATION_FAILED"
+	CodeMemberAlreadyInProject            = "MEMBER_ALREADY_IN_PROJECT"
+	CodeMemberAlreadyInWorkspace          = "MEMBER_ALREADY_IN_WORKSPACE"
+	CodeMemberAddFailed                   = "MEMBER_ADD_FAILED"
+	CodeMemberNotInProject                = "MEMBER_NOT_IN_PROJECT"
+	CodeMemberNotFoundInWorkspace         = "MEMBER_NOT_FOUND_IN_WORKSPACE"
+	CodeMemberUserNotFound                = "MEMBER_USER_NOT_FOUND"
+	CodeCannotRemoveSelfID                = "CANNOT_REMOVE_SELF_ID"
+	CodeCannotRemoveProjectAdminID        = "CANNOT_REMOVE_PROJECT_ADMIN_ID"
+	CodeFormInvalid                       = "FORM_INVALID"
+	CodeAttendanceSubmitFailed            = "ATTENDANCE_SUBMIT_FAILED"
+	CodeAttendanceImageUploadFailed       = "ATTENDANCE_IMAGE_UPLOAD_FAILED"
+	CodeProjectDeleteFailed               = "PROJECT_DELETE_FAILED"
+	CodeProjectTasksDeleteFailed          = "PROJECT_TASKS_DELETE_FAILED"
+	CodeProfileUpdateFailed               = "PROFILE_UPDATE_FAILED"
+	CodeWorkspaceAccessCheckFailed        = "WORKSPACE_ACCESS_CHECK_FAILED"
+	CodeDateFormatInvalid                 = "DATE_FORMAT_INVALID"
+	CodeOutboxEntryNotFound               = "OUTBOX_ENTRY_NOT_FOUND"
+	CodeOutboxStatusInvalid               = "OUTBOX_STATUS_INVALID"
+	CodeOutboxRetryFailed                 = "OUTBOX_RETRY_FAILED"
+	CodeDigestTimeInvalid                 = "DIGEST_TIME_INVALID"
+	CodeTimezoneInvalid                   = "TIMEZONE_INVALID"
+	CodeTelegramLinkCodeFailed            = "TELEGRAM_LINK_CODE_FAILED"
+	CodeTelegramSecretInvalid             = "TELEGRAM_SECRET_INVALID"
+	CodeTelegramWebhookDisabled           = "TELEGRAM_WEBHOOK_DISABLED"
+	CodeTelegramLinkFailed                = "TELEGRAM_LINK_FAILED"
+	CodeMemberRemoveRoleInsufficient      = "MEMBER_REMOVE_ROLE_INSUFFICIENT"
+	CodeTaskNotInProject                  = "TASK_NOT_IN_PROJECT"
+	CodeTaskNotInWorkspace                = "TASK_NOT_IN_WORKSPACE"
+	CodeTaskNotFound                      = "TASK_NOT_FOUND"
+	CodeInternalError                     = "INTERNAL_ERROR"
+	CodeNoUpdatableFields                 = "NO_UPDATABLE_FIELDS"
+	CodeCannotRemoveProjectAdmin          = "CANNOT_REMOVE_PROJECT_ADMIN"
+	CodeCannotRemoveSelf                  = "CANNOT_REMOVE_SELF"
+	CodeCannotRemoveWorkspaceOwner        = "CANNOT_REMOVE_WORKSPACE_OWNER"
+	CodeProjectWorkspaceAccessDenied      = "PROJECT_WORKSPACE_ACCESS_DENIED"
+	CodeInvalidToken                      = "INVALID_TOKEN"
+	CodeUserNotWorkspaceMember            = "USER_NOT_WORKSPACE_MEMBER"
+	CodeUserAlreadyProjectMember          = "USER_ALREADY_PROJECT_MEMBER"
+	CodeUserAlreadyTaskMember             = "USER_ALREADY_TASK_MEMBER"
+	CodeUserNotFound                      = "USER_NOT_FOUND"
+	CodeWorkspaceNotFound                 = "WORKSPACE_NOT_FOUND"
+	CodeReportWorkspaceRequired           = "REPORT_WORKSPACE_REQUIRED"
+	CodeFileSaveFailed                    = "FILE_SAVE_FAILED"
+	CodeFileDeleteFailed                  = "FILE_DELETE_FAILED"
+	CodeImageSaveFailed                   = "IMAGE_SAVE_FAILED"
+	CodeBrandingSaveFailed                = "BRANDING_SAVE_FAILED"
+	CodeReportFailed                      = "REPORT_FAILED"
+	CodeAuthHeaderRequired                = "AUTH_HEADER_REQUIRED"
+	CodeAuthHeaderInvalid                 = "AUTH_HEADER_INVALID"
+	CodeTokenInvalidDetail                = "TOKEN_INVALID_DETAIL"
+	CodeUnauthenticated                   = "UNAUTHENTICATED"
+	CodeAdminOnly                         = "ADMIN_ONLY"
+	CodeInvalidPayload                    = "INVALID_PAYLOAD"
+	CodeInvalidRequestBody                = "INVALID_REQUEST_BODY"
+	CodeInvalidParam                      = "INVALID_PARAM"
+	CodeParamRequired                     = "PARAM_REQUIRED"
+	CodeFileRequired                      = "FILE_REQUIRED"
+	CodeFileNotFound                      = "FILE_NOT_FOUND"
+	CodeFetchFailed                       = "FETCH_FAILED"
+	CodeUnsupportedLanguage               = "UNSUPPORTED_LANGUAGE"
+	CodeReportTypeUnknown                 = "REPORT_TYPE_UNKNOWN"
+	CodeChannelUnsupported                = "CHANNEL_UNSUPPORTED"
+	CodeReportFormatUnsupported           = "REPORT_FORMAT_UNSUPPORTED"
+	CodeCadenceInvalid                    = "CADENCE_INVALID"
+	CodeMaxUsersPerRequest                = "MAX_USERS_PER_REQUEST"
+)
+
+var messages = map[string]map[string]string{
+	CodeAccentColorInvalid:                {LangID: "accent_color harus dalam format #RRGGBB", LangEN: "accent_color must use the #RRGGBB format"},
+	CodeProjectMembersAccessDenied:        {LangID: "akses ditolak untuk melihat members project", LangEN: "access denied to view project members"},
+	CodeReportJobAccessDenied:             {LangID: "akses ditolak untuk report job ini", LangEN: "access denied to this report job"},
+	CodeTaskAccessDenied:                  {LangID: "akses ditolak untuk task ini", LangEN: "access denied to this task"},
+	CodeWorkspaceAccessDenied:             {LangID: "akses ditolak untuk workspace ini", LangEN: "access denied to this workspace"},
+	CodeNotTaskMember:                     {LangID: "anda bukan member dari task ini", LangEN: "you are not a member of this task"},
+	CodeNotTaskMemberUpdate:               {LangID: "anda bukan member dari task ini, tidak bisa mengupdate", LangEN: "you are not a member of this task and cannot update it"},
+	CodeNotWorkspaceMember:                {LangID: "anda bukan member workspace ini", LangEN: "you are not a member of this workspace"},
+	CodeNotMember:                         {LangID: "anda bukan member", LangEN: "you are not a member"},
+	CodeTaskUpdateFieldsRestricted:        {LangID: "anda hanya diizinkan untuk mengupdate status dan notes", LangEN: "you are only allowed to update status and notes"},
+	CodeProjectAccessDenied:               {LangID: "anda tidak memiliki akses ke project ini", LangEN: "you do not have access to this project"},
+	CodeAttendanceAlreadySubmitted:        {LangID: "absensi untuk hari ini sudah dikirim", LangEN: "attendance for this day already submitted"},
+	CodeInvalidCredentials:                {LangID: "email atau password salah", LangEN: "invalid email or password"},
+	CodeEmailAlreadyRegistered:            {LangID: "email sudah terdaftar", LangEN: "email is already registered"},
+	CodeReportExpired:                     {LangID: "file report sudah kedaluwarsa", LangEN: "report file has expired"},
+	CodeReportFileNotFound:                {LangID: "file report tidak ditemukan", LangEN: "report file not found"},
+	CodeFileTooLarge:                      {LangID: "ukuran file maksimal %s", LangEN: "file size must not exceed %s"},
+	CodeFileTypeNotAllowed:                {LangID: "format file tidak didukung", LangEN: "file type not allowed"},
+	CodeLogoTypeNotAllowed:                {LangID: "format file tidak didukung. Hanya JPEG dan PNG", LangEN: "unsupported file format. Only JPEG and PNG are allowed"},
+	CodeImageTypeNotAllowed:               {LangID: "format file tidak didukung. Hanya JPEG, PNG, GIF, WebP", LangEN: "unsupported file format. Only JPEG, PNG, GIF and WebP are allowed"},
+	CodeTokenGenerationFailed:             {LangID: "gagal generate token", LangEN: "failed to generate token"},
+	CodeUploadPathFailed:                  {LangID: "gagal membuat absolute path", LangEN: "failed to resolve upload path"},
+	CodeUploadDirFailed:                   {LangID: "gagal membuat directory upload", LangEN: "failed to create upload directory"},
+	CodeReportJobCreateFailed:             {LangID: "gagal membuat report job", LangEN: "failed to create report job"},
+	CodeReportQueueBusy:                   {LangID: "antrean report sedang penuh, coba lagi nanti", LangEN: "the report queue is full, try again later"},
+	CodeProjectMembershipCheckFailed:      {LangID: "gagal memeriksa keanggotaan project", LangEN: "failed to check project membership"},
+	CodeProjectAdminCheckFailed:           {LangID: "gagal memvalidasi admin project", LangEN: "failed to validate project admin"},
+	CodeProjectAccessCheckFailed:          {LangID: "gagal memvalidasi akses project", LangEN: "failed to validate project access"},
+	CodeTaskMemberCheckFailed:             {LangID: "gagal memvalidasi member task", LangEN: "failed to validate task member"},
+	CodeMemberCheckFailed:                 {LangID: "gagal memvalidasi member", LangEN: "failed to validate member"},
+	CodeProjectCreatorMemberFailed:        {LangID: "gagal menambahkan creator sebagai member project", LangEN: "failed to add the creator as a project member"},
+	CodeWorkspaceCreatorMemberFailed:      {LangID: "gagal menambahkan creator sebagai member workspace", LangEN: "failed to add the creator as a workspace member"},
+	CodeProjectFetchFailed:                {LangID: "gagal mengambil data projects", LangEN: "failed to fetch projects"},
+	CodePasswordHashFailed:                {LangID: "gagal mengenkripsi password", LangEN: "failed to hash password"},
+	CodeReportScheduleUpdateFailed:        {LangID: "gagal mengupdate jadwal report", LangEN: "failed to update report schedule"},
+	CodeReportScheduleSaveFailed:          {LangID: "gagal menyimpan jadwal report", LangEN: "failed to save report schedule"},
+	CodeAdminOnlySearchUsers:              {LangID: "hanya admin yang bisa mencari semua user", LangEN: "only admins can search all users"},
+	CodeAdminOnlyMultipleUsers:            {LangID: "hanya admin yang bisa mengakses detail multiple users", LangEN: "only admins can access multiple user details"},
+	CodeAdminOnlyAllUsers:                 {LangID: "hanya admin yang bisa mengakses semua user", LangEN: "only admins can access all users"},
+	CodeAdminOnlyCreateWorkspace:          {LangID: "hanya admin yang boleh buat workspace", LangEN: "only admins can create workspaces"},
+	CodeOwnProfileOnly:                    {LangID: "hanya bisa melihat profil sendiri", LangEN: "you can only view your own profile"},
+	CodeWorkspaceCreatorOnlyHardDelete:    {LangID: "hanya creator workspace yang boleh hard delete", LangEN: "only the workspace creator can permanently delete it"},
+	CodeWorkspaceCreatorOnlyUpdate:        {LangID: "hanya creator workspace yang boleh mengupdate", LangEN: "only the workspace creator can update it"},
+	CodeWorkspaceCreatorOnlySoftDelete:    {LangID: "hanya creator workspace yang boleh soft delete", LangEN: "only the workspace creator can delete it"},
+	CodeProjectMemberOnlyTasks:            {LangID: "hanya member project yang boleh lihat tasks", LangEN: "only project members can view tasks"},
+	CodeProjectMemberOnlyImages:           {LangID: "hanya member project yang boleh melihat images", LangEN: "only project members can view images"},
+	CodeProjectMemberOnlyTaskMembers:      {LangID: "hanya member project yang boleh melihat members task", LangEN: "only project members can view task members"},
+	CodeProjectMemberOnlyUpload:           {LangID: "hanya member project yang boleh upload image", LangEN: "only project members can upload images"},
+	CodeWorkspaceMemberOnlyProjectMembers: {LangID: "hanya member workspace yang boleh melihat members project", LangEN: "only workspace members can view project members"},
+	CodeTaskMemberOnlyImages:              {LangID: "hanya task member yang boleh melihat image", LangEN: "only task members can view images"},
+	CodeTaskMemberOnlyUpload:              {LangID: "hanya task member yang boleh upload image", LangEN: "only task members can upload images"},
+	CodeUploaderOnlyDelete:                {LangID: "hanya uploader yang boleh menghapus image", LangEN: "only the uploader can delete this image"},
+	CodeImageNotFound:                     {LangID: "image tidak ditemukan", LangEN: "image not found"},
+	CodeReportScheduleNotFound:            {LangID: "jadwal report tidak ditemukan", LangEN: "report schedule not found"},
+	CodeWorkspaceLogoNotFound:             {LangID: "logo workspace tidak ditemukan", LangEN: "workspace logo not found"},
+	CodeProjectMemberNotFound:             {LangID: "member tidak ditemukan di project ini", LangEN: "member not found in this project"},
+	CodeWorkspaceMemberNotFound:           {LangID: "member tidak ditemukan di workspace ini", LangEN: "member not found in this workspace"},
+	CodeSearchQueryTooShort:               {LangID: "minimal 2 karakter untuk pencarian", LangEN: "search query must be at least 2 characters"},
+	CodeReportChannelRequired:             {LangID: "minimal satu channel pengiriman harus dipilih", LangEN: "at least one delivery channel must be selected"},
+	CodeReportRecipientRequired:           {LangID: "minimal satu penerima harus diisi", LangEN: "at least one recipient is required"},
+	CodeProjectNotInWorkspace:             {LangID: "project tidak ditemukan di workspace ini", LangEN: "project not found in this workspace"},
+	CodeProjectNotFound:                   {LangID: "project tidak ditemukan", LangEN: "project not found"},
+	CodeProjectWorkspaceDeleted:           {LangID: "project tidak ditemukan atau workspace sudah dihapus", LangEN: "project not found or its workspace has been deleted"},
+	CodeReportProjectRequired:             {LangID: "project_id wajib diisi untuk report project", LangEN: "project_id is required for project reports"},
+	CodeReportNotReady:                    {LangID: "report belum selesai dibuat", LangEN: "report is not ready yet"},
+	CodeReportJobNotFound:                 {LangID: "report job tidak ditemukan", LangEN: "report job not found"},
+	CodeNotificationNotFound:              {LangID: "notifikasi tidak ditemukan", LangEN: "notification not found"},
+	CodeNotificationPrefsInvalid:          {LangID: "notification_preferences harus berupa objek JSON event ke daftar channel", LangEN: "notification_preferences must be a JSON object mapping events to channel lists"},
+	CodeNotificationEventUnknown:          {LangID: "event notifikasi tidak dikenal: %s", LangEN: "unknown notification event: %s"},
+	CodeNotificationChannelUnknown:        {LangID: "channel notifikasi tidak dikenal: %s", LangEN: "unknown notification channel: %s"},
+	CodeWebhookURLInvalid:                 {LangID: "URL webhook harus diawali http:// atau https://", LangEN: "webhook URL must start with http:// or https://"},
+	CodeWebhookURLBlocked:                 {LangID: "URL webhook tidak boleh mengarah ke alamat internal", LangEN: "webhook URL must not point to an internal address"},
+	CodeWebhookURLUnresolvable:            {LangID: "host webhook %s tidak dapat ditemukan", LangEN: "webhook host %s could not be resolved"},
+	CodeWebhookNotFound:                   {LangID: "webhook tidak ditemukan", LangEN: "webhook not found"},
+	CodeWebhookEventsRequired:             {LangID: "pilih minimal satu event webhook", LangEN: "at least one webhook event is required"},
+	CodeWebhookEventUnknown:               {LangID: "event webhook tidak dikenal: %s", LangEN: "unknown webhook event: %s"},
+	CodeWebhookDeliveryNotFound:           {LangID: "riwayat pengiriman webhook tidak ditemukan", LangEN: "webhook delivery not found"},
+	CodeWebhookDeliveryInProgress:         {LangID: "pengiriman webhook sedang diproses", LangEN: "webhook delivery is being processed"},
+	CodeChatIntegrationNotFound:           {LangID: "integrasi chat tidak ditemukan", LangEN: "chat integration not found"},
+	CodeChatIntegrationRequired:           {LangID: "provider dan webhook_url wajib diisi", LangEN: "provider and webhook_url are required"},
+	CodeChatProviderUnknown:               {LangID: "provider chat tidak dikenal: %s (slack, discord, mattermost)", LangEN: "unknown chat provider: %s (slack, discord, mattermost)"},
+	CodeChatEventUnknown:                  {LangID: "event chat tidak dikenal: %s", LangEN: "unknown chat event: %s"},
+	CodeChatTestFailed:                    {LangID: "pesan test gagal dikirim: %s", LangEN: "test message could not be sent: %s"},
+	CodeWsMessageInvalid:                  {LangID: "pesan harus berupa JSON dengan field action", LangEN: "message must be JSON with an action field"},
+	CodeWsActionUnknown:                   {LangID: "aksi tidak dikenal: %s (subscribe, unsubscribe, heartbeat, indicator)", LangEN: "unknown action: %s (subscribe, unsubscribe, heartbeat, indicator)"},
+	CodeWsTopicInvalid:                    {LangID: "topic tidak valid: %s (workspace:<id>, project:<id>, task:<id>, user:me)", LangEN: "invalid topic: %s (workspace:<id>, project:<id>, task:<id>, user:me)"},
+	CodeWsTopicForbidden:                  {LangID: "tidak punya akses ke topic %s", LangEN: "no access to topic %s"},
+	CodeWsTooManyTopics:                   {LangID: "maksimal %d topic per koneksi", LangEN: "at most %d topics per connection"},
+	CodeWsPresenceInvalid:                 {LangID: "state tidak valid: %s (active, away)", LangEN: "invalid state: %s (active, away)"},
+	CodeWsIndicatorInvalid:                {LangID: "jenis indikator tidak valid: %s (viewing, typing)", LangEN: "invalid indicator kind: %s (viewing, typing)"},
+	CodeWsNotSubscribed:                   {LangID: "subscribe ke topic %s terlebih dahulu", LangEN: "subscribe to topic %s first"},
+	CodeRefreshTokenInvalid:               {LangID: "refresh token tidak valid atau sudah kedaluwarsa", LangEN: "refresh token is invalid or expired"},
+	CodeRefreshTokenReused:                {LangID: "refresh token sudah pernah dipakai, sesi dicabut demi keamanan", LangEN: "refresh token was already used, the session has been revoked"},
+	CodeSessionRevoked:                    {LangID: "sesi sudah berakhir, silakan login kembali", LangEN: "session has ended, please log in again"},
+	CodeSessionNotFound:                   {LangID: "sesi tidak ditemukan", LangEN: "session not found"},
+	CodeCurrentPasswordWrong:              {LangID: "password saat ini salah", LangEN: "current password is incorrect"},
+	CodePasswordUnchanged:                 {LangID: "password baru harus berbeda dari password saat ini", LangEN: "new password must differ from the current password"},
+	CodeResetTokenInvalid:                 {LangID: "token reset password tidak valid atau sudah kedaluwarsa", LangEN: "password reset token is invalid or expired"},
+	CodeTwoFactorCodeInvalid:              {LangID: "kode autentikasi dua faktor salah", LangEN: "two-factor authentication code is incorrect"},
+	CodeTwoFactorChallengeInvalid:         {LangID: "sesi login dua faktor tidak valid atau sudah kedaluwarsa, silakan login ulang", LangEN: "two-factor login challenge is invalid or expired, please log in again"},
+	CodeTwoFactorTooManyAttempts:          {LangID: "terlalu banyak percobaan kode yang salah, coba lagi dalam beberapa menit", LangEN: "too many incorrect codes, try again in a few minutes"},
+	CodeTwoFactorAlreadyEnabled:           {LangID: "autentikasi dua faktor sudah aktif", LangEN: "two-factor authentication is already enabled"},
+	CodeTwoFactorNotEnabled:               {LangID: "autentikasi dua faktor belum aktif", LangEN: "two-factor authentication is not enabled"},
+	CodeTwoFactorSetupRequired:            {LangID: "mulai setup autentikasi dua faktor terlebih dahulu", LangEN: "start two-factor authentication setup first"},
+	CodeWorkspaceTwoFactorRequired:        {LangID: "workspace ini mewajibkan admin mengaktifkan autentikasi dua faktor", LangEN: "this workspace requires admins to enable two-factor authentication"},
+	CodeAPITokenInvalid:                   {LangID: "API token tidak valid, kedaluwarsa, atau sudah dicabut", LangEN: "API token is invalid, expired or revoked"},
+	CodeAPITokenNotFound:                  {LangID: "API token tidak ditemukan", LangEN: "API token not found"},
+	CodeAPITokenNameRequired:              {LangID: "nama API token wajib diisi", LangEN: "API token name is required"},
+	CodeAPITokenScopesInvalid:             {LangID: "scope API token harus berisi read dan/atau write", LangEN: "API token scopes must contain read and/or write"},
+	CodeAPITokenExpiryInvalid:             {LangID: "masa berlaku API token harus antara 1 dan %d hari", LangEN: "API token expiry must be between 1 and %d days"},
+	CodeAPITokenScopeInsufficient:         {LangID: "scope API token tidak mengizinkan aksi ini", LangEN: "API token scope does not allow this action"},
+	CodeAPITokenWorkspaceMismatch:         {LangID: "service key hanya berlaku untuk workspace miliknya", LangEN: "service key is only valid for its own workspace"},
+	CodeSessionAuthRequired:               {LangID: "aksi ini memerlukan login dengan akun, bukan API token", LangEN: "this action requires a user login, not an API token"},
+	CodeMemberNotInWorkspace:              {LangID: "user %d harus menjadi member workspace terlebih dahulu", LangEN: "user %d must be a member of the workspace first"},
+	CodeMemberValidationFailed:            {LangID: "gagal memvalidasi member %d", LangEN: "failed to validate member %d"},
+	CodeMemberAlreadyInProject:            {LangID: "user %d sudah menjadi member di project ini", LangEN: "user %d is already a member of this project"},
+	CodeMemberAlreadyInWorkspace:          {LangID: "user %d sudah menjadi member di workspace ini", LangEN: "user %d is already a member of this workspace"},
+	CodeMemberAddFailed:                   {LangID: "gagal menambahkan user %d", LangEN: "failed to add user %d"},
+	CodeMemberNotInProject:                {LangID: "user %d tidak ditemukan di project ini", LangEN: "user %d is not a member of this project"},
+	CodeMemberNotFoundInWorkspace:         {LangID: "user %d tidak ditemukan di workspace ini", LangEN: "user %d is not a member of this workspace"},
+	CodeMemberUserNotFound:                {LangID: "user %d tidak ditemukan", LangEN: "user %d not found"},
+	CodeCannotRemoveSelfID:                {LangID: "tidak bisa menghapus diri sendiri (user_id: %d)", LangEN: "you cannot remove yourself (user_id: %d)"},
+	CodeCannotRemoveProjectAdminID:        {LangID: "tidak bisa menghapus admin project (user_id: %d)", LangEN: "cannot remove a project admin (user_id: %d)"},
+	CodeFormInvalid:                       {LangID: "form tidak valid: %s", LangEN: "invalid form: %s"},
+	CodeAttendanceSubmitFailed:            {LangID: "gagal menyimpan absensi", LangEN: "failed to submit attendance"},
+	CodeAttendanceImageUploadFailed:       {LangID: "gagal mengunggah foto absensi", LangEN: "failed to upload attendance image"},
+	CodeProjectDeleteFailed:               {LangID: "gagal menghapus project %d", LangEN: "failed to delete project %d"},
+	CodeProjectTasksDeleteFailed:          {LangID: "gagal menghapus task di dalam project %d", LangEN: "failed to delete the tasks of project %d"},
+	CodeProfileUpdateFailed:               {LangID: "gagal mengupdate profil", LangEN: "failed to update profile"},
+	CodeWorkspaceAccessCheckFailed:        {LangID: "tidak dapat memeriksa akses pengguna ke workspace", LangEN: "could not check the user's access to the workspace"},
+	CodeDateFormatInvalid:                 {LangID: "format tanggal tidak valid, gunakan YYYY-MM-DD", LangEN: "invalid date format, use YYYY-MM-DD"},
+	CodeOutboxEntryNotFound:               {LangID: "notifikasi gagal dengan ID tersebut tidak ditemukan", LangEN: "no dead-lettered notification with that ID"},
+	CodeOutboxStatusInvalid:               {LangID: "status antrean tidak valid: %s", LangEN: "invalid outbox status: %s"},
+	CodeOutboxRetryFailed:                 {LangID: "gagal menjadwalkan ulang notifikasi", LangEN: "failed to requeue notifications"},
+	CodeDigestTimeInvalid:                 {LangID: "digest_time harus dalam format HH:MM", LangEN: "digest_time must use the HH:MM format"},
+	CodeTimezoneInvalid:                   {LangID: "timezone tidak dikenal: %s", LangEN: "unknown timezone: %s"},
+	CodeTelegramLinkCodeFailed:            {LangID: "gagal membuat kode penghubung Telegram", LangEN: "failed to create Telegram link code"},
+	CodeTelegramSecretInvalid:             {LangID: "secret token webhook Telegram tidak valid", LangEN: "invalid Telegram webhook secret token"},
+	CodeTelegramWebhookDisabled:           {LangID: "webhook Telegram belum dikonfigurasi", LangEN: "Telegram webhook is not configured"},
+	CodeTelegramLinkFailed:                {LangID: "gagal menghubungkan chat Telegram", LangEN: "failed to link Telegram chat"},
+	CodeMemberRemoveRoleInsufficient:      {LangID: "role Anda tidak cukup untuk menghapus member", LangEN: "your role is not allowed to remove members"},
+	CodeTaskNotInProject:                  {LangID: "task tidak ditemukan di project ini", LangEN: "task not found in this project"},
+	CodeTaskNotInWorkspace:                {LangID: "task tidak ditemukan di workspace ini", LangEN: "task not found in this workspace"},
+	CodeTaskNotFound:                      {LangID: "task tidak ditemukan", LangEN: "task not found"},
+	CodeInternalError:                     {LangID: "terjadi kesalahan sistem", LangEN: "an internal error occurred"},
+	CodeNoUpdatableFields:                 {LangID: "tidak ada field yang diizinkan untuk diupdate", LangEN: "no updatable fields were provided"},
+	CodeCannotRemoveProjectAdmin:          {LangID: "tidak bisa menghapus admin project lain", LangEN: "cannot remove another project admin"},
+	CodeCannotRemoveSelf:                  {LangID: "tidak bisa menghapus diri sendiri", LangEN: "you cannot remove yourself"},
+	CodeCannotRemoveWorkspaceOwner:        {LangID: "tidak bisa menghapus owner workspace", LangEN: "cannot remove the workspace owner"},
+	CodeProjectWorkspaceAccessDenied:      {LangID: "tidak memiliki akses ke workspace project ini", LangEN: "you do not have access to this project's workspace"},
+	CodeInvalidToken:                      {LangID: "token tidak valid", LangEN: "invalid token"},
+	CodeUserNotWorkspaceMember:            {LangID: "user bukan anggota workspace", LangEN: "user is not a member of the workspace"},
+	CodeUserAlreadyProjectMember:          {LangID: "user sudah menjadi member di project ini", LangEN: "user is already a member of this project"},
+	CodeUserAlreadyTaskMember:             {LangID: "user sudah menjadi member di task ini", LangEN: "user is already a member of this task"},
+	CodeUserNotFound:                      {LangID: "user tidak ditemukan", LangEN: "user not found"},
+	CodeWorkspaceNotFound:                 {LangID: "workspace tidak ditemukan", LangEN: "workspace not found"},
+	CodeReportWorkspaceRequired:           {LangID: "workspace_id wajib diisi untuk report absensi", LangEN: "workspace_id is required for attendance reports"},
+	CodeFileSaveFailed:                    {LangID: "gagal menyimpan file: %s", LangEN: "failed to save file: %s"},
+	CodeFileDeleteFailed:                  {LangID: "gagal menghapus file: %s", LangEN: "failed to delete file: %s"},
+	CodeImageSaveFailed:                   {LangID: "gagal menyimpan data image: %s", LangEN: "failed to save image data: %s"},
+	CodeBrandingSaveFailed:                {LangID: "gagal menyimpan branding: %s", LangEN: "failed to save branding: %s"},
+	CodeReportFailed:                      {LangID: "report gagal dibuat: %s", LangEN: "report generation failed: %s"},
+	CodeAuthHeaderRequired:                {LangID: "Authorization header diperlukan", LangEN: "Authorization header is required"},
+	CodeAuthHeaderInvalid:                 {LangID: "Format token tidak valid. Gunakan: Bearer <token>", LangEN: "invalid token format. Use: Bearer <token>"},
+	CodeTokenInvalidDetail:                {LangID: "Token tidak valid: %s", LangEN: "invalid token: %s"},
+	CodeUnauthenticated:                   {LangID: "User tidak terautentikasi", LangEN: "user is not authenticated"},
+	CodeAdminOnly:                         {LangID: "Hanya admin yang boleh mengakses", LangEN: "only admins can access this resource"},
+	CodeInvalidPayload:                    {LangID: "Format data tidak valid: %s", LangEN: "invalid request data: %s"},
+	CodeInvalidRequestBody:                {LangID: "Request body tidak valid", LangEN: "invalid request body"},
+	CodeInvalidParam:                      {LangID: "%s tidak valid", LangEN: "invalid %s"},
+	CodeParamRequired:                     {LangID: "%s wajib diisi", LangEN: "%s is required"},
+	CodeFileRequired:                      {LangID: "File %s diperlukan", LangEN: "%s file is required"},
+	CodeFileNotFound:                      {LangID: "File tidak ditemukan", LangEN: "file not found"},
+	CodeFetchFailed:                       {LangID: "Gagal mengambil data %s", LangEN: "failed to fetch %s"},
+	CodeUnsupportedLanguage:               {LangID: "bahasa '%s' tidak didukung", LangEN: "language '%s' is not supported"},
+	CodeReportTypeUnknown:                 {LangID: "tipe report '%s' tidak dikenal", LangEN: "unknown report type '%s'"},
+	CodeChannelUnsupported:                {LangID: "channel '%s' tidak didukung", LangEN: "channel '%s' is not supported"},
+	CodeReportFormatUnsupported:           {LangID: "format report '%s' tidak didukung, gunakan pdf", LangEN: "report format '%s' is not supported, use pdf"},
+	CodeCadenceInvalid:                    {LangID: "cadence tidak valid: %s", LangEN: "invalid cadence: %s"},
+	CodeMaxUsersPerRequest:                {LangID: "maksimal %d user per request", LangEN: "at most %d users per request"},
+}
@@ -6,6 +6,7 @@ import (
 	"project-management-backend/config"
 	"project-management-backend/models"
 	"project-management-backend/routes"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -53,8 +54,11 @@ func main() {
 
 	sqlDB.SetConnMaxLifetime(30 * time.Hour)
 
-	// Clean up any stale online statuses from a previous crash/restart
-	CleanupOnlineStatus()
+	// Clean up any stale online statuses from a previous crash/restart. With
+	// the redis realtime backend other instances may still hold connections.
+	if !strings.EqualFold(os.Getenv("REALTIME_BACKEND"), "redis") {
+		CleanupOnlineStatus()
+	}
 
 	router := gin.Default()
 
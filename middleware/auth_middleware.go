@@ -1,19 +1,29 @@
 package middleware
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/services"
+	"project-management-backend/utils"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
-func AuthMiddleware(authService services.AuthService) gin.HandlerFunc {
+// AuthMiddleware menerima JWT access token maupun API token (personal access
+// token atau service key). Service key hanya berlaku pada route dengan
+// :workspace_id miliknya.
+func AuthMiddleware(authService services.AuthService, apiTokenService services.APITokenService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get token dari header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(401, gin.H{"error": "Authorization header diperlukan"})
+			utils.RespondCode(c, 401, i18n.CodeAuthHeaderRequired)
 			c.Abort()
 			return
 		}
@@ -21,23 +31,69 @@ func AuthMiddleware(authService services.AuthService) gin.HandlerFunc {
 		// Format: "Bearer <token>"
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(401, gin.H{"error": "Format token tidak valid. Gunakan: Bearer <token>"})
+			utils.RespondCode(c, 401, i18n.CodeAuthHeaderInvalid)
 			c.Abort()
 			return
 		}
 
 		tokenString := parts[1]
 
+		if apiTokenService.IsAPIToken(tokenString) {
+			authenticateAPIToken(c, apiTokenService, tokenString)
+			return
+		}
+
 		// Validate token dan get user
-		user, err := authService.GetUserFromToken(tokenString)
+		user, sessionID, err := authService.Authenticate(tokenString)
 		if err != nil {
-			c.JSON(401, gin.H{"error": "Token tidak valid: " + err.Error()})
+			_, detail := i18n.Localize(utils.RequestLanguage(c), err)
+			utils.RespondCode(c, 401, i18n.CodeTokenInvalidDetail, detail)
 			c.Abort()
 			return
 		}
 
-		// Set user ke context
+		// Set user dan sesi ke context
 		c.Set("currentUser", user)
+		c.Set("sessionID", sessionID)
+		c.Next()
+	}
+}
+
+func authenticateAPIToken(c *gin.Context, apiTokenService services.APITokenService, tokenString string) {
+	user, apiToken, err := apiTokenService.Authenticate(tokenString, c.ClientIP())
+	if err != nil {
+		_, detail := i18n.Localize(utils.RequestLanguage(c), err)
+		utils.RespondCode(c, 401, i18n.CodeTokenInvalidDetail, detail)
+		c.Abort()
+		return
+	}
+
+	if !apiToken.AllowsMethod(c.Request.Method) {
+		utils.RespondCode(c, 403, i18n.CodeAPITokenScopeInsufficient)
+		c.Abort()
+		return
+	}
+
+	if apiToken.WorkspaceID != nil && c.Param("workspace_id") != strconv.FormatUint(uint64(*apiToken.WorkspaceID), 10) {
+		utils.RespondCode(c, 403, i18n.CodeAPITokenWorkspaceMismatch)
+		c.Abort()
+		return
+	}
+
+	c.Set("currentUser", user)
+	c.Set("apiToken", apiToken)
+	c.Next()
+}
+
+// SessionOnlyMiddleware - Tolak API token untuk route pengelolaan akun
+// (sesi, password, 2FA, pembuatan token)
+func SessionOnlyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, usingAPIToken := c.Get("apiToken"); usingAPIToken {
+			utils.RespondCode(c, 403, i18n.CodeSessionAuthRequired)
+			c.Abort()
+			return
+		}
 		c.Next()
 	}
 }
@@ -47,14 +103,14 @@ func AdminMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		user, exists := c.Get("currentUser")
 		if !exists {
-			c.JSON(401, gin.H{"error": "User tidak terautentikasi"})
+			utils.RespondCode(c, 401, i18n.CodeUnauthenticated)
 			c.Abort()
 			return
 		}
 
 		currentUser := user.(*models.User)
 		if currentUser.Role != "admin" {
-			c.JSON(403, gin.H{"error": "Hanya admin yang boleh mengakses"})
+			utils.RespondCode(c, 403, i18n.CodeAdminOnly)
 			c.Abort()
 			return
 		}
@@ -63,6 +119,110 @@ func AdminMiddleware() gin.HandlerFunc {
 	}
 }
 
+// AdminTwoFactorMiddleware - Tolak admin tanpa 2FA pada workspace yang
+// mewajibkan 2FA. Workspace diambil dari :workspace_id, dari project pada
+// :project_id, atau dari workspace_id/project_id di body JSON untuk route
+// tanpa ID di path (mis. POST /projects). Dipasang setelah AdminMiddleware.
+func AdminTwoFactorMiddleware(workspaceService services.WorkspaceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(*models.User)
+
+		var err error
+		if rawID := c.Param("workspace_id"); rawID != "" {
+			// ID tidak valid dibiarkan lolos agar handler yang membalas 400
+			if workspaceID, parseErr := strconv.ParseUint(rawID, 10, 64); parseErr == nil {
+				err = workspaceService.CheckAdminTwoFactor(uint(workspaceID), currentUser)
+			}
+		} else if rawID := c.Param("project_id"); rawID != "" {
+			if projectID, parseErr := strconv.ParseUint(rawID, 10, 64); parseErr == nil {
+				err = workspaceService.CheckProjectAdminTwoFactor(uint(projectID), currentUser)
+			}
+		} else {
+			projectID, workspaceID := bodyScope(c)
+			err = checkScopeTwoFactor(workspaceService, projectID, workspaceID, currentUser)
+		}
+
+		if abortTwoFactor(c, err) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// ReportScheduleTwoFactorMiddleware - AdminTwoFactorMiddleware untuk
+// workspace yang dilaporkan schedule pada :schedule_id. Schedule yang tidak
+// ada dibiarkan lolos agar handler yang membalas 404.
+func ReportScheduleTwoFactorMiddleware(scheduleService services.ReportScheduleService, workspaceService services.WorkspaceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(*models.User)
+
+		var err error
+		if scheduleID, parseErr := strconv.ParseUint(c.Param("schedule_id"), 10, 64); parseErr == nil {
+			if schedule, getErr := scheduleService.GetByID(uint(scheduleID)); getErr == nil {
+				err = checkScopeTwoFactor(workspaceService, schedule.ProjectID, schedule.WorkspaceID, currentUser)
+			}
+		}
+
+		if abortTwoFactor(c, err) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// bodyScope membaca project_id dan workspace_id dari body JSON tanpa
+// mengonsumsinya, sehingga handler tetap bisa melakukan bind.
+func bodyScope(c *gin.Context) (projectID, workspaceID *uint) {
+	if c.Request.Body == nil || c.ContentType() != gin.MIMEJSON {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, nil
+	}
+
+	var scope struct {
+		ProjectID   *uint `json:"project_id"`
+		WorkspaceID *uint `json:"workspace_id"`
+	}
+	// Body tidak valid dibiarkan lolos agar handler yang membalas 400
+	if err := json.Unmarshal(body, &scope); err != nil {
+		return nil, nil
+	}
+	return scope.ProjectID, scope.WorkspaceID
+}
+
+// checkScopeTwoFactor applies the two-factor policy of the project's
+// workspace, or of the workspace when no project is given.
+func checkScopeTwoFactor(workspaceService services.WorkspaceService, projectID, workspaceID *uint, user *models.User) error {
+	if projectID != nil {
+		return workspaceService.CheckProjectAdminTwoFactor(*projectID, user)
+	}
+	if workspaceID != nil {
+		return workspaceService.CheckAdminTwoFactor(*workspaceID, user)
+	}
+	return nil
+}
+
+// abortTwoFactor answers 403 when the two-factor policy blocked the admin
+// and 500 for lookup failures. It reports whether the request was aborted.
+func abortTwoFactor(c *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	status := 500
+	var coded *i18n.Error
+	if errors.As(err, &coded) && coded.Code == i18n.CodeWorkspaceTwoFactorRequired {
+		status = 403
+	}
+	utils.RespondError(c, status, err)
+	c.Abort()
+	return true
+}
+
 // OptionalAuthMiddleware - Untuk route yang bisa diakses dengan atau tanpa auth
 func OptionalAuthMiddleware(authService services.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -71,9 +231,10 @@ func OptionalAuthMiddleware(authService services.AuthService) gin.HandlerFunc {
 			parts := strings.Split(authHeader, " ")
 			if len(parts) == 2 && parts[0] == "Bearer" {
 				tokenString := parts[1]
-				user, err := authService.GetUserFromToken(tokenString)
+				user, sessionID, err := authService.Authenticate(tokenString)
 				if err == nil {
 					c.Set("currentUser", user)
+					c.Set("sessionID", sessionID)
 				}
 			}
 		}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Jenis API token
+const (
+	APITokenPersonal = "personal" // personal access token, bertindak sebagai pemiliknya
+	APITokenService  = "service"  // service key, terbatas pada satu workspace
+)
+
+// Scope API token
+const (
+	APITokenScopeRead  = "read"  // GET/HEAD/OPTIONS
+	APITokenScopeWrite = "write" // semua method
+)
+
+// APIToken is a long-lived credential for scripts and CI. Personal tokens
+// act as their owner. Service keys act as the admin who created them but
+// only on routes of their workspace. Only the SHA-256 hash is stored;
+// Prefix is kept to help users recognize a token.
+type APIToken struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Kind        string     `gorm:"index" json:"kind"`
+	Name        string     `json:"name"`
+	Prefix      string     `json:"prefix"`
+	TokenHash   string     `gorm:"uniqueIndex" json:"-"`
+	Scopes      []string   `gorm:"serializer:json" json:"scopes"`
+	UserID      uint       `gorm:"index" json:"user_id"`
+	WorkspaceID *uint      `gorm:"index" json:"workspace_id,omitempty"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	LastUsedIP  string     `json:"last_used_ip,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	User        User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Workspace   *Workspace `gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (APIToken) TableName() string { return "api_tokens" }
+
+// IsActive reports whether the token can still be used at now.
+func (t *APIToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// AllowsMethod reports whether the token's scopes cover an HTTP method.
+func (t *APIToken) AllowsMethod(method string) bool {
+	for _, scope := range t.Scopes {
+		if scope == APITokenScopeWrite {
+			return true
+		}
+		if scope == APITokenScopeRead && (method == "GET" || method == "HEAD" || method == "OPTIONS") {
+			return true
+		}
+	}
+	return false
+}
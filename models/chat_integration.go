@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Format payload incoming webhook yang didukung
+const (
+	ChatProviderSlack      = "slack"
+	ChatProviderDiscord    = "discord"
+	ChatProviderMattermost = "mattermost"
+)
+
+var ChatProviders = []string{ChatProviderSlack, ChatProviderDiscord, ChatProviderMattermost}
+
+// Event yang bisa dikirim ke chat workspace
+const (
+	ChatEventTaskAssigned      = "task.assigned"
+	ChatEventTaskStatusChanged = "task.status_changed"
+	ChatEventTaskOverdue       = "task.overdue"
+)
+
+var ChatEvents = []string{ChatEventTaskAssigned, ChatEventTaskStatusChanged, ChatEventTaskOverdue}
+
+// WorkspaceChatIntegration posts workspace events to a team chat through an
+// incoming webhook URL.
+type WorkspaceChatIntegration struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	WorkspaceID uint      `gorm:"index" json:"workspace_id"`
+	Name        string    `json:"name"`
+	Provider    string    `json:"provider"`
+	WebhookURL  string    `json:"webhook_url"`
+	Events      []string  `gorm:"serializer:json" json:"events"`
+	IsActive    bool      `json:"is_active"`
+	CreatedBy   uint      `json:"created_by"`
+	Workspace   Workspace `gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (WorkspaceChatIntegration) TableName() string { return "workspace_chat_integrations" }
+
+func (c *WorkspaceChatIntegration) Subscribes(event string) bool {
+	for _, e := range c.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
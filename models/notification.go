@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+const (
+	NotificationTaskAssigned      = "task_assigned"
+	NotificationTaskStatusChanged = "task_status_changed"
+	NotificationMention           = "mention"
+	NotificationTaskDueSoon       = "task_due_soon"
+	NotificationTaskOverdue       = "task_overdue"
+	NotificationTaskEscalated     = "task_overdue_escalated"
+	NotificationReportReady       = "report_ready"
+	NotificationDailyDigest       = "daily_digest"
+)
+
+type Notification struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"index" json:"user_id"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Message   string     `json:"message"`
+	TaskID    *uint      `json:"task_id"`
+	ProjectID *uint      `json:"project_id"`
+	ActorID   *uint      `json:"actor_id"`
+	ReadAt    *time.Time `json:"read_at"`
+	User      User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Task      *Task      `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"-"`
+	Actor     *User      `gorm:"foreignKey:ActorID;constraint:OnDelete:SET NULL" json:"actor,omitempty"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (Notification) TableName() string { return "notifications" }
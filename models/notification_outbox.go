@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+const (
+	OutboxPending    = "pending"
+	OutboxProcessing = "processing"
+	OutboxSent       = "sent"
+	OutboxDead       = "dead"
+)
+
+// NotificationOutbox is one pending delivery of a notification on one
+// channel. Rows are written in the same transaction as the change that
+// triggered the notification and delivered by the outbox worker.
+type NotificationOutbox struct {
+	ID             uint         `gorm:"primaryKey" json:"id"`
+	NotificationID uint         `json:"notification_id"`
+	UserID         uint         `json:"user_id"`
+	Channel        string       `json:"channel"`
+	Status         string       `json:"status"`
+	Attempts       int          `json:"attempts"`
+	NextAttemptAt  time.Time    `json:"next_attempt_at"`
+	LastError      string       `json:"last_error"`
+	ClaimedAt      *time.Time   `json:"claimed_at"`
+	SentAt         *time.Time   `json:"sent_at"`
+	Notification   Notification `gorm:"foreignKey:NotificationID;constraint:OnDelete:CASCADE" json:"notification"`
+	User           User         `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user"`
+	CreatedAt      time.Time    `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time    `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (NotificationOutbox) TableName() string { return "notification_outbox" }
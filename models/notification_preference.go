@@ -0,0 +1,95 @@
+package models
+
+// Event types a user can configure notification channels for.
+const (
+	NotificationEventAssigned      = "assigned"
+	NotificationEventStatusChanged = "status_changed"
+	NotificationEventComment       = "comment"
+	NotificationEventDueSoon       = "due_soon"
+	NotificationEventOverdue       = "overdue"
+	NotificationEventReportReady   = "report_ready"
+	NotificationEventDailyDigest   = "daily_digest"
+)
+
+const (
+	NotificationChannelInApp    = "in_app"
+	NotificationChannelTelegram = "telegram"
+	NotificationChannelEmail    = "email"
+	NotificationChannelWebhook  = "webhook"
+)
+
+var NotificationEvents = []string{
+	NotificationEventAssigned,
+	NotificationEventStatusChanged,
+	NotificationEventComment,
+	NotificationEventDueSoon,
+	NotificationEventOverdue,
+	NotificationEventReportReady,
+	NotificationEventDailyDigest,
+}
+
+var NotificationChannels = []string{
+	NotificationChannelInApp,
+	NotificationChannelTelegram,
+	NotificationChannelEmail,
+	NotificationChannelWebhook,
+}
+
+// NotificationPreferences maps an event type to the channels it is delivered
+// on. Events missing from the map use the default channels; an empty list
+// turns the event off.
+type NotificationPreferences map[string][]string
+
+var defaultNotificationChannels = []string{NotificationChannelInApp, NotificationChannelTelegram}
+
+// Digest dikirim di luar aplikasi, jadi default-nya Telegram dan email
+var defaultDigestChannels = []string{NotificationChannelTelegram, NotificationChannelEmail}
+
+func (p NotificationPreferences) Channels(event string) []string {
+	if channels, ok := p[event]; ok {
+		return channels
+	}
+	if event == NotificationEventDailyDigest {
+		return defaultDigestChannels
+	}
+	return defaultNotificationChannels
+}
+
+func (p NotificationPreferences) Enabled(event, channel string) bool {
+	for _, c := range p.Channels(event) {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolved returns the effective channels for every event, defaults included.
+func (p NotificationPreferences) Resolved() NotificationPreferences {
+	resolved := make(NotificationPreferences, len(NotificationEvents))
+	for _, event := range NotificationEvents {
+		resolved[event] = append([]string{}, p.Channels(event)...)
+	}
+	return resolved
+}
+
+// NotificationEvent returns the preference event a notification type belongs to.
+func NotificationEvent(notificationType string) string {
+	switch notificationType {
+	case NotificationTaskAssigned:
+		return NotificationEventAssigned
+	case NotificationTaskStatusChanged:
+		return NotificationEventStatusChanged
+	case NotificationMention:
+		return NotificationEventComment
+	case NotificationTaskDueSoon:
+		return NotificationEventDueSoon
+	case NotificationTaskOverdue, NotificationTaskEscalated:
+		return NotificationEventOverdue
+	case NotificationReportReady:
+		return NotificationEventReportReady
+	case NotificationDailyDigest:
+		return NotificationEventDailyDigest
+	}
+	return notificationType
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// PasswordResetToken is a single-use token sent to a user who forgot their
+// password. Only the SHA-256 hash of the token is stored.
+type PasswordResetToken struct {
+	ID          uint       `gorm:"primaryKey" json:"-"`
+	UserID      uint       `gorm:"index" json:"-"`
+	TokenHash   string     `gorm:"uniqueIndex" json:"-"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	UsedAt      *time.Time `json:"-"`
+	RequestedIP string     `json:"-"`
+	User        User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"-"`
+}
+
+func (PasswordResetToken) TableName() string { return "password_reset_tokens" }
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// UserRecoveryCode is a single-use code that replaces a TOTP code when the
+// user has lost their authenticator. Only the SHA-256 hash is stored.
+type UserRecoveryCode struct {
+	ID        uint       `gorm:"primaryKey" json:"-"`
+	UserID    uint       `gorm:"index" json:"-"`
+	CodeHash  string     `gorm:"uniqueIndex" json:"-"`
+	UsedAt    *time.Time `json:"-"`
+	User      User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"-"`
+}
+
+func (UserRecoveryCode) TableName() string { return "user_recovery_codes" }
@@ -3,11 +3,39 @@ package models
 import "time"
 
 type DailyActivityItem struct {
-	ActivityTime time.Time
-	User         string
-	ProjectTitle string
-	TaskTitle    string
-	TaskPriority string
-	StatusAtLog  string
-	Overdue      time.Duration
+	ActivityTime   time.Time     `json:"activity_time"`
+	User           string        `json:"user"`
+	ProjectTitle   string        `json:"project_title"`
+	TaskTitle      string        `json:"task_title"`
+	TaskPriority   string        `json:"task_priority"`
+	PreviousStatus string        `json:"previous_status"`
+	StatusAtLog    string        `json:"status_at_log"`
+	Overdue        time.Duration `json:"-"`
+	OverdueSeconds int64         `json:"overdue_seconds"`
+}
+
+// ReportPeriod is the time window a report covers.
+type ReportPeriod struct {
+	ProjectID   uint      `json:"project_id"`
+	ProjectName string    `json:"project_name"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+}
+
+// AgendaReport is the data behind the weekly PDF reports.
+type AgendaReport struct {
+	ReportPeriod
+	Items []AgendaItem `json:"items"`
+}
+
+// DailyReport is the data behind the daily PDF report.
+type DailyReport struct {
+	ReportPeriod
+	Items []DailyActivityItem `json:"items"`
+}
+
+// MonitoringReport is the data behind the monitoring PDF report.
+type MonitoringReport struct {
+	ReportPeriod
+	Tasks []TaskWithHistory `json:"tasks"`
 }
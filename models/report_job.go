@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+const (
+	ReportTypeDaily          = "daily"
+	ReportTypeWeeklyBackward = "weekly_backward"
+	ReportTypeWeeklyForward  = "weekly_forward"
+	ReportTypeMonitoring     = "monitoring"
+	ReportTypeAttendance     = "attendance"
+)
+
+const (
+	ReportJobQueued     = "queued"
+	ReportJobProcessing = "processing"
+	ReportJobDone       = "done"
+	ReportJobFailed     = "failed"
+	ReportJobExpired    = "expired"
+)
+
+type ReportJob struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	ProjectID   uint       `json:"project_id"`
+	ReportType  string     `json:"report_type"`
+	Status      string     `json:"status"`
+	RequestedBy uint       `json:"requested_by"`
+	Language    string     `gorm:"default:id" json:"language"`
+	FilePath    string     `json:"-"`
+	FileName    string     `json:"file_name"`
+	FileSize    int64      `json:"file_size"`
+	ErrorMsg    string     `json:"error_msg,omitempty"`
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	Project     Project    `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	User        User       `gorm:"foreignKey:RequestedBy;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+const (
+	DeliveryChannelTelegram = "telegram"
+	DeliveryChannelEmail    = "email"
+)
+
+// ReportRecipients lists who receives a scheduled report. Users are resolved
+// to their email and Telegram chat at delivery time.
+type ReportRecipients struct {
+	UserIDs         []uint   `json:"user_ids"`
+	Emails          []string `json:"emails"`
+	TelegramChatIDs []string `json:"telegram_chat_ids"`
+}
+
+type ReportSchedule struct {
+	ID          uint             `gorm:"primaryKey" json:"id"`
+	Name        string           `json:"name"`
+	ReportType  string           `json:"report_type"`
+	ProjectID   *uint            `json:"project_id"`
+	WorkspaceID *uint            `json:"workspace_id"`
+	Cadence     string           `json:"cadence"` // cron: menit jam tanggal bulan hari
+	Recipients  ReportRecipients `gorm:"type:text;serializer:json" json:"recipients"`
+	Channels    []string         `gorm:"type:text;serializer:json" json:"channels"`
+	Language    string           `gorm:"default:id" json:"language"`
+	Enabled     bool             `json:"enabled"`
+	LastRunAt   *time.Time       `json:"last_run_at"`
+	NextRunAt   *time.Time       `json:"next_run_at"`
+	LastError   string           `json:"last_error"`
+	CreatedBy   uint             `json:"created_by"`
+	CreatedAt   time.Time        `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
+}
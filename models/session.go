@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Alasan sesi dicabut
+const (
+	SessionRevokedLogout         = "logout"
+	SessionRevokedLogoutAll      = "logout_all"
+	SessionRevokedByUser         = "revoked"
+	SessionRevokedTokenReuse     = "refresh_token_reused"
+	SessionRevokedPasswordChange = "password_changed"
+	SessionRevokedPasswordReset  = "password_reset"
+)
+
+// UserSession is one logged-in device. Access tokens carry the session ID
+// and stop working as soon as the session is revoked or expires.
+type UserSession struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	UserID        uint       `gorm:"index" json:"user_id"`
+	UserAgent     string     `json:"user_agent"`
+	IPAddress     string     `json:"ip_address"`
+	LastUsedAt    time.Time  `json:"last_used_at"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	RevokedAt     *time.Time `json:"revoked_at"`
+	RevokedReason string     `json:"revoked_reason,omitempty"`
+	User          User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (UserSession) TableName() string { return "user_sessions" }
+
+// IsActive reports whether the session can still be used at now.
+func (s *UserSession) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RefreshToken is one refresh token of a session. Only the SHA-256 hash is
+// stored. A token is used once; presenting it again revokes the session.
+type RefreshToken struct {
+	ID        uint        `gorm:"primaryKey" json:"id"`
+	SessionID uint        `gorm:"index" json:"session_id"`
+	TokenHash string      `gorm:"uniqueIndex" json:"-"`
+	ExpiresAt time.Time   `json:"expires_at"`
+	UsedAt    *time.Time  `json:"used_at"`
+	Session   UserSession `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt time.Time   `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (RefreshToken) TableName() string { return "refresh_tokens" }
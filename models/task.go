@@ -20,6 +20,11 @@ type Task struct {
 	OverdueDuration time.Duration `json:"overdue_duration"` // Durasi keterlambatan penyelesaian tugas
 	HasBeenPending  bool          `json:"has_been_pending"` // Flag untuk menandai task pernah masuk status pending
 
+	// Diisi scheduler deadline, di-reset saat due_date diubah
+	ReminderSentAt    *time.Time `json:"reminder_sent_at"`
+	OverdueNotifiedAt *time.Time `json:"overdue_notified_at"`
+	EscalatedAt       *time.Time `json:"escalated_at"`
+
 	Project   Project        `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"project"`
 	Members   []TaskUser     `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"members"`
 	Images    []TaskImage    `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"images"`
@@ -7,15 +7,18 @@ import (
 )
 
 type TaskStatusLog struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	TaskID    uint           `json:"task_id"`
-	Status    string         `json:"status"`
-	ClockIn   time.Time      `json:"clock_in"`
-	ClockOut  *time.Time     `json:"clock_out"`
-	Duration  *int64         `json:"duration"`
-	CreatedAt time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	TaskID         uint           `json:"task_id"`
+	Status         string         `json:"status"`
+	PreviousStatus *string        `json:"previous_status"` // nil untuk log pertama saat task dibuat
+	ChangedBy      *uint          `json:"changed_by"`
+	ClockIn        time.Time      `json:"clock_in"`
+	ClockOut       *time.Time     `json:"clock_out"`
+	Duration       *int64         `json:"duration"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 
-	Task Task `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"task"`
+	Task  Task  `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"-"`
+	Actor *User `gorm:"foreignKey:ChangedBy;constraint:OnDelete:SET NULL" json:"actor,omitempty"`
 }
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// TelegramUpdate is the subset of a Telegram Bot API update the webhook uses.
+type TelegramUpdate struct {
+	UpdateID int64            `json:"update_id"`
+	Message  *TelegramMessage `json:"message"`
+}
+
+type TelegramMessage struct {
+	MessageID int64         `json:"message_id"`
+	From      *TelegramFrom `json:"from"`
+	Chat      TelegramChat  `json:"chat"`
+	Text      string        `json:"text"`
+}
+
+type TelegramFrom struct {
+	ID           int64  `json:"id"`
+	Username     string `json:"username"`
+	LanguageCode string `json:"language_code"`
+}
+
+type TelegramChat struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+}
+
+// TelegramLinkCode is a one-time code a user sends to the bot with /link to
+// connect the chat to their account.
+type TelegramLinkCode struct {
+	ID        uint       `gorm:"primaryKey" json:"-"`
+	UserID    uint       `json:"-"`
+	Code      string     `gorm:"uniqueIndex" json:"code"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"-"`
+	User      User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"-"`
+}
+
+func (TelegramLinkCode) TableName() string { return "telegram_link_codes" }
@@ -18,9 +18,23 @@ type User struct {
 	LastSeen       *time.Time     `json:"last_seen,omitempty"`
 	Workspaces     []Workspace    `gorm:"many2many:workspace_users" json:"workspaces"`
 	TelegramChatID *string        `json:"telegram_chat_id,omitempty"`
+	Language       *string        `json:"language"` // id atau en, nil = ikut Accept-Language
 	Projects       []Project      `gorm:"many2many:project_users" json:"projects"`
 	Tasks          []TaskUser     `gorm:"foreignKey:UserID" json:"tasks"`
 	CreatedAt      time.Time      `gorm:"autoCreateTime"`
 	UpdatedAt      time.Time      `gorm:"autoUpdateTime"`
 	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"` // Soft delete
+
+	// Hanya ditampilkan lewat endpoint profile
+	NotificationPreferences NotificationPreferences `gorm:"serializer:json" json:"-"`
+	NotificationWebhookURL  *string                 `json:"-"`
+	DigestTime              *string                 `json:"-"` // HH:MM waktu lokal, nil = digest mati
+	Timezone                *string                 `json:"-"` // Nama IANA, mis. Asia/Jakarta
+	LastDigestSentAt        *time.Time              `json:"-"`
+
+	// Two-factor authentication (TOTP). Secret sudah terisi saat enrollment
+	// dimulai, tapi baru berlaku setelah TwoFactorEnabled.
+	TwoFactorEnabled     bool    `gorm:"default:false" json:"two_factor_enabled"`
+	TwoFactorSecret      *string `json:"-"`
+	TwoFactorLastCounter int64   `json:"-"` // time step terakhir yang dipakai, cegah replay kode
 }
@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+// Event yang bisa di-subscribe webhook workspace
+const (
+	WebhookEventTaskCreated         = "task.created"
+	WebhookEventTaskStatusChanged   = "task.status_changed"
+	WebhookEventAttendanceSubmitted = "attendance.submitted"
+	WebhookEventProjectExported     = "project.exported"
+	WebhookEventTest                = "webhook.test"
+)
+
+var WebhookEvents = []string{
+	WebhookEventTaskCreated,
+	WebhookEventTaskStatusChanged,
+	WebhookEventAttendanceSubmitted,
+	WebhookEventProjectExported,
+}
+
+const (
+	WebhookDeliveryPending    = "pending"
+	WebhookDeliveryProcessing = "processing"
+	WebhookDeliverySuccess    = "success"
+	WebhookDeliveryFailed     = "failed"
+)
+
+// WorkspaceWebhook is an outgoing webhook subscription of a workspace.
+// Every payload is signed with Secret using HMAC-SHA256.
+type WorkspaceWebhook struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	WorkspaceID uint      `gorm:"index" json:"workspace_id"`
+	URL         string    `json:"url"`
+	Secret      string    `json:"-"`
+	Events      []string  `gorm:"serializer:json" json:"events"`
+	IsActive    bool      `json:"is_active"`
+	CreatedBy   uint      `json:"created_by"`
+	Workspace   Workspace `gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (WorkspaceWebhook) TableName() string { return "workspace_webhooks" }
+
+// Subscribes reports whether the webhook wants the given event.
+func (w *WorkspaceWebhook) Subscribes(event string) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one delivery of an event to a webhook. The payload is
+// stored so retries send exactly the same signed body.
+type WebhookDelivery struct {
+	ID             uint             `gorm:"primaryKey" json:"id"`
+	WebhookID      uint             `gorm:"index" json:"webhook_id"`
+	Event          string           `json:"event"`
+	Payload        string           `gorm:"type:text" json:"payload"`
+	Status         string           `json:"status"`
+	Attempts       int              `json:"attempts"`
+	NextAttemptAt  time.Time        `json:"next_attempt_at"`
+	ResponseStatus *int             `json:"response_status"`
+	ResponseBody   string           `gorm:"type:text" json:"response_body"`
+	LastError      string           `gorm:"type:text" json:"last_error"`
+	DurationMs     int64            `json:"duration_ms"`
+	ClaimedAt      *time.Time       `json:"claimed_at"`
+	DeliveredAt    *time.Time       `json:"delivered_at"`
+	Webhook        WorkspaceWebhook `gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt      time.Time        `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (WebhookDelivery) TableName() string { return "webhook_deliveries" }
@@ -1,20 +1,153 @@
 package models
 
 import (
+	"encoding/json"
+	"fmt"
+	"time"
+
 	"github.com/gorilla/websocket"
 )
 
+// Tipe event realtime yang dikirim ke client WebSocket
+const (
+	EventTaskCreated       = "task.created"
+	EventTaskUpdated       = "task.updated"
+	EventTaskStatusChanged = "task.status_changed"
+	EventMemberAdded       = "member.added"
+	EventCommentCreated    = "comment.created" // dicadangkan, belum ada fitur komentar
+	EventImageUploaded     = "image.uploaded"
+)
+
+// Jenis topic yang bisa di-subscribe client, ditulis sebagai "<jenis>:<id>"
+// (misalnya "project:5"). Untuk user cukup "user:me".
+const (
+	TopicWorkspace = "workspace"
+	TopicProject   = "project"
+	TopicTask      = "task"
+	TopicUser      = "user"
+)
+
+// Status presence user, digabung dari semua koneksinya
+const (
+	PresenceOnline  = "online"
+	PresenceAway    = "away"
+	PresenceIdle    = "idle"
+	PresenceOffline = "offline"
+)
+
+// Jenis indikator aktivitas user pada sebuah topic
+const (
+	IndicatorViewing = "viewing"
+	IndicatorTyping  = "typing"
+)
+
+// Topic returns the topic name for a kind and id, e.g. "task:42".
+func Topic(kind string, id uint) string {
+	return fmt.Sprintf("%s:%d", kind, id)
+}
+
 type Client struct {
+	ID          string
 	Hub         *Hub
 	Conn        *websocket.Conn
 	Send        chan []byte
 	UserID      uint
+	UserName    string
+	Role        string
+	Lang        string
+	WorkspaceID uint            // workspace saat connect, 0 jika tidak ada
+	LastEventID string          // event terakhir yang diterima sebelum reconnect
+	Topics      map[string]bool // hanya diubah oleh goroutine hub
+
+	// Hanya dipakai goroutine readPump
+	Allowed            map[string]bool // topic yang lolos cek akses
+	Presence           string
+	LastActiveAt       time.Time
+	PresenceWorkspaces []uint
+	Indicators         map[string]string // topic -> jenis indikator yang aktif
+}
+
+// DirectMessage is a message addressed to every connection of a single user.
+type DirectMessage struct {
+	UserID uint            `json:"user_id"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// EventMessage is an encoded RealtimeEvent as passed between instances.
+type EventMessage struct {
+	WorkspaceID uint            `json:"workspace_id"`
+	Seq         uint64          `json:"seq"`
+	Topics      []string        `json:"topics"`
+	Data        json.RawMessage `json:"data"`
+
+	// Event project dan task hanya untuk admin dan user di Audience,
+	// termasuk pelanggan topic workspace
+	Restricted bool   `json:"restricted,omitempty"`
+	Audience   []uint `json:"audience,omitempty"`
+}
+
+// EphemeralMessage is delivered to the subscribers of Topics without being
+// sequenced or logged, e.g. presence and typing indicators.
+type EphemeralMessage struct {
+	Topics []string        `json:"topics"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// ClientMessage is a reply addressed to a single connection.
+type ClientMessage struct {
+	Client *Client
+	Data   []byte
+}
+
+// SubscriptionRequest asks the hub to add or remove a topic of a client.
+// The hub sends Ack once the change is applied, or Rejected when the client
+// has reached its subscription limit. A subscription with LastEventID also
+// replays the events of WorkspaceID that the client missed.
+type SubscriptionRequest struct {
+	Client      *Client
+	Topic       string
+	Subscribe   bool
 	WorkspaceID uint
+	LastEventID string
+	Ack         []byte
+	Rejected    []byte
+}
+
+// RealtimeEvent is a typed event delivered to the clients subscribed to its
+// workspace, project or task. ID and Seq are assigned when the event is
+// published; Seq increases per workspace.
+type RealtimeEvent struct {
+	ID          string      `json:"id"`
+	Seq         uint64      `json:"seq"`
+	Type        string      `json:"type"`
+	WorkspaceID uint        `json:"workspace_id"`
+	ProjectID   uint        `json:"project_id,omitempty"`
+	TaskID      uint        `json:"task_id,omitempty"`
+	ActorID     uint        `json:"actor_id,omitempty"`
+	Data        interface{} `json:"data"`
+	OccurredAt  time.Time   `json:"occurred_at"`
+}
+
+// Topics returns the topics an event is published to.
+func (e *RealtimeEvent) Topics() []string {
+	topics := []string{Topic(TopicWorkspace, e.WorkspaceID)}
+	if e.ProjectID != 0 {
+		topics = append(topics, Topic(TopicProject, e.ProjectID))
+	}
+	if e.TaskID != 0 {
+		topics = append(topics, Topic(TopicTask, e.TaskID))
+	}
+	return topics
 }
 
 type Hub struct {
-	Clients    map[uint]map[*Client]bool
-	Broadcast  chan []byte
-	Register   chan *Client
-	Unregister chan *Client
+	Clients       map[*Client]bool
+	Topics        map[string]map[*Client]bool
+	Broadcast     chan *EventMessage
+	Direct        chan *DirectMessage
+	Ephemeral     chan *EphemeralMessage
+	Reply         chan *ClientMessage
+	Subscriptions chan *SubscriptionRequest
+	Register      chan *Client
+	Unregister    chan *Client
 }
@@ -7,14 +7,15 @@ import (
 )
 
 type Workspace struct {
-	ID          uint            `gorm:"primaryKey" json:"id"`
-	Name        string          `json:"name"`
-	Description string          `json:"description"`
-	CreatedBy   uint            `json:"created_by"`
-	Color       string          `json:"color"`
-	CreatedAt   time.Time       `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time       `gorm:"autoUpdateTime"`
-	DeletedAt   gorm.DeletedAt  `gorm:"index" json:"deleted_at,omitempty"`
-	Members     []WorkspaceUser `gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE" json:"members"`
-	Projects    []Project       `gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE" json:"projects"`
+	ID                    uint            `gorm:"primaryKey" json:"id"`
+	Name                  string          `json:"name"`
+	Description           string          `json:"description"`
+	CreatedBy             uint            `json:"created_by"`
+	Color                 string          `json:"color"`
+	RequireAdminTwoFactor bool            `gorm:"default:false" json:"require_admin_two_factor"` // Admin/owner wajib 2FA untuk mengelola workspace
+	CreatedAt             time.Time       `gorm:"autoCreateTime"`
+	UpdatedAt             time.Time       `gorm:"autoUpdateTime"`
+	DeletedAt             gorm.DeletedAt  `gorm:"index" json:"deleted_at,omitempty"`
+	Members               []WorkspaceUser `gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE" json:"members"`
+	Projects              []Project       `gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE" json:"projects"`
 }
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type WorkspaceBranding struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	WorkspaceID      uint      `gorm:"uniqueIndex" json:"workspace_id"`
+	LogoURL          *string   `json:"logo_url"`
+	OrganisationName string    `json:"organisation_name"`
+	AddressLine      string    `json:"address_line"`
+	FooterText       string    `json:"footer_text"`
+	AccentColor      string    `json:"accent_color"` // format #RRGGBB
+	Workspace        Workspace `gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt        time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (WorkspaceBranding) TableName() string { return "workspace_brandings" }
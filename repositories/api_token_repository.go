@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"project-management-backend/config"
+	"project-management-backend/models"
+	"time"
+)
+
+type APITokenRepository interface {
+	Create(token *models.APIToken) error
+	GetByID(tokenID uint) (*models.APIToken, error)
+	GetByHash(tokenHash string) (*models.APIToken, error)
+	ListPersonalByUserID(userID uint) ([]models.APIToken, error)
+	ListServiceByWorkspaceID(workspaceID uint) ([]models.APIToken, error)
+	Revoke(tokenID uint) error
+	RevokeAllByUserID(userID uint) error
+	TouchLastUsed(tokenID uint, usedAt time.Time, ip string) error
+}
+
+type apiTokenRepository struct{}
+
+func NewAPITokenRepository() APITokenRepository {
+	return &apiTokenRepository{}
+}
+
+func (r *apiTokenRepository) Create(token *models.APIToken) error {
+	return config.DB.Create(token).Error
+}
+
+func (r *apiTokenRepository) GetByID(tokenID uint) (*models.APIToken, error) {
+	var token models.APIToken
+	if err := config.DB.First(&token, tokenID).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetByHash finds a token by its hash. Tokens of deleted users are not
+// returned.
+func (r *apiTokenRepository) GetByHash(tokenHash string) (*models.APIToken, error) {
+	var token models.APIToken
+	if err := config.DB.
+		Joins("JOIN users ON users.id = api_tokens.user_id AND users.deleted_at IS NULL").
+		Preload("User").
+		Where("api_tokens.token_hash = ?", tokenHash).
+		First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *apiTokenRepository) ListPersonalByUserID(userID uint) ([]models.APIToken, error) {
+	var tokens []models.APIToken
+	err := config.DB.
+		Where("kind = ? AND user_id = ? AND revoked_at IS NULL", models.APITokenPersonal, userID).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+func (r *apiTokenRepository) ListServiceByWorkspaceID(workspaceID uint) ([]models.APIToken, error) {
+	var tokens []models.APIToken
+	err := config.DB.
+		Where("kind = ? AND workspace_id = ? AND revoked_at IS NULL", models.APITokenService, workspaceID).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+func (r *apiTokenRepository) Revoke(tokenID uint) error {
+	return config.DB.Model(&models.APIToken{}).
+		Where("id = ? AND revoked_at IS NULL", tokenID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAllByUserID revokes every token the user owns, personal tokens and
+// the service keys they created.
+func (r *apiTokenRepository) RevokeAllByUserID(userID uint) error {
+	return config.DB.Model(&models.APIToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *apiTokenRepository) TouchLastUsed(tokenID uint, usedAt time.Time, ip string) error {
+	return config.DB.Model(&models.APIToken{}).
+		Where("id = ?", tokenID).
+		UpdateColumns(map[string]interface{}{
+			"last_used_at": usedAt,
+			"last_used_ip": ip,
+		}).Error
+}
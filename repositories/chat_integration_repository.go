@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"project-management-backend/config"
+	"project-management-backend/models"
+)
+
+type ChatIntegrationRepository interface {
+	Create(integration *models.WorkspaceChatIntegration) error
+	GetByID(integrationID uint) (*models.WorkspaceChatIntegration, error)
+	GetByWorkspaceID(workspaceID uint) ([]models.WorkspaceChatIntegration, error)
+	GetActiveByWorkspaceID(workspaceID uint) ([]models.WorkspaceChatIntegration, error)
+	Update(integration *models.WorkspaceChatIntegration) error
+	Delete(integrationID uint) error
+}
+
+type chatIntegrationRepository struct{}
+
+func NewChatIntegrationRepository() ChatIntegrationRepository {
+	return &chatIntegrationRepository{}
+}
+
+func (r *chatIntegrationRepository) Create(integration *models.WorkspaceChatIntegration) error {
+	return config.DB.Create(integration).Error
+}
+
+func (r *chatIntegrationRepository) GetByID(integrationID uint) (*models.WorkspaceChatIntegration, error) {
+	var integration models.WorkspaceChatIntegration
+	if err := config.DB.First(&integration, integrationID).Error; err != nil {
+		return nil, err
+	}
+	return &integration, nil
+}
+
+func (r *chatIntegrationRepository) GetByWorkspaceID(workspaceID uint) ([]models.WorkspaceChatIntegration, error) {
+	var integrations []models.WorkspaceChatIntegration
+	err := config.DB.Where("workspace_id = ?", workspaceID).Order("id ASC").Find(&integrations).Error
+	return integrations, err
+}
+
+func (r *chatIntegrationRepository) GetActiveByWorkspaceID(workspaceID uint) ([]models.WorkspaceChatIntegration, error) {
+	var integrations []models.WorkspaceChatIntegration
+	err := config.DB.Where("workspace_id = ? AND is_active = ?", workspaceID, true).Find(&integrations).Error
+	return integrations, err
+}
+
+func (r *chatIntegrationRepository) Update(integration *models.WorkspaceChatIntegration) error {
+	return config.DB.Save(integration).Error
+}
+
+func (r *chatIntegrationRepository) Delete(integrationID uint) error {
+	return config.DB.Delete(&models.WorkspaceChatIntegration{}, integrationID).Error
+}
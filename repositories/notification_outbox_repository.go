@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"project-management-backend/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationOutboxRepository interface {
+	Create(entry *models.NotificationOutbox) error
+	ClaimDue(channel string, now time.Time, limit int) ([]models.NotificationOutbox, error)
+	MarkSent(entryID uint, sentAt time.Time) error
+	MarkFailed(entryID uint, attempts int, nextAttemptAt time.Time, lastError string, dead bool) error
+	ResetProcessing(staleBefore time.Time) error
+	GetByStatus(status string, limit, offset int) ([]models.NotificationOutbox, int64, error)
+	Retry(entryID uint, now time.Time) (bool, error)
+	RetryAllDead(now time.Time) (int64, error)
+
+	// WithTx returns a repository whose queries run inside tx
+	WithTx(tx *gorm.DB) NotificationOutboxRepository
+}
+
+type notificationOutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationOutboxRepository() NotificationOutboxRepository {
+	return &notificationOutboxRepository{}
+}
+
+func (r *notificationOutboxRepository) WithTx(tx *gorm.DB) NotificationOutboxRepository {
+	return &notificationOutboxRepository{db: tx}
+}
+
+func (r *notificationOutboxRepository) Create(entry *models.NotificationOutbox) error {
+	return conn(r.db).Create(entry).Error
+}
+
+// ClaimDue moves up to limit due entries of a channel to processing and
+// returns them with their notification and recipient loaded. Rows locked by
+// another worker are skipped, so concurrent instances never claim the same
+// entry.
+func (r *notificationOutboxRepository) ClaimDue(channel string, now time.Time, limit int) ([]models.NotificationOutbox, error) {
+	var entries []models.NotificationOutbox
+	err := conn(r.db).Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		if err := tx.Model(&models.NotificationOutbox{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND channel = ? AND next_attempt_at <= ?", models.OutboxPending, channel, now).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := tx.Model(&models.NotificationOutbox{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":     models.OutboxProcessing,
+				"claimed_at": now,
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.
+			Preload("Notification.Actor").
+			Preload("User").
+			Order("next_attempt_at ASC, id ASC").
+			Find(&entries, ids).Error
+	})
+	return entries, err
+}
+
+func (r *notificationOutboxRepository) MarkSent(entryID uint, sentAt time.Time) error {
+	return conn(r.db).Model(&models.NotificationOutbox{}).Where("id = ?", entryID).Updates(map[string]interface{}{
+		"status":     models.OutboxSent,
+		"attempts":   gorm.Expr("attempts + 1"),
+		"sent_at":    sentAt,
+		"last_error": "",
+	}).Error
+}
+
+func (r *notificationOutboxRepository) MarkFailed(entryID uint, attempts int, nextAttemptAt time.Time, lastError string, dead bool) error {
+	status := models.OutboxPending
+	if dead {
+		status = models.OutboxDead
+	}
+	return conn(r.db).Model(&models.NotificationOutbox{}).Where("id = ?", entryID).Updates(map[string]interface{}{
+		"status":          status,
+		"attempts":        attempts,
+		"next_attempt_at": nextAttemptAt,
+		"last_error":      lastError,
+		"claimed_at":      nil,
+	}).Error
+}
+
+// ResetProcessing returns entries claimed before staleBefore to the queue.
+// Those were left behind by a worker that stopped; fresher claims still
+// belong to a running worker.
+func (r *notificationOutboxRepository) ResetProcessing(staleBefore time.Time) error {
+	return conn(r.db).Model(&models.NotificationOutbox{}).
+		Where("status = ? AND (claimed_at IS NULL OR claimed_at < ?)", models.OutboxProcessing, staleBefore).
+		Updates(map[string]interface{}{
+			"status":     models.OutboxPending,
+			"claimed_at": nil,
+		}).Error
+}
+
+func (r *notificationOutboxRepository) GetByStatus(status string, limit, offset int) ([]models.NotificationOutbox, int64, error) {
+	var entries []models.NotificationOutbox
+	var total int64
+
+	db := conn(r.db).Model(&models.NotificationOutbox{})
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.
+		Preload("Notification").
+		Preload("User").
+		Order("updated_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&entries).Error
+	return entries, total, err
+}
+
+// Retry puts a dead entry back on the queue with a fresh attempt budget.
+// It returns false when no dead entry with that ID exists.
+func (r *notificationOutboxRepository) Retry(entryID uint, now time.Time) (bool, error) {
+	result := conn(r.db).Model(&models.NotificationOutbox{}).
+		Where("id = ? AND status = ?", entryID, models.OutboxDead).
+		Updates(map[string]interface{}{
+			"status":          models.OutboxPending,
+			"attempts":        0,
+			"next_attempt_at": now,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *notificationOutboxRepository) RetryAllDead(now time.Time) (int64, error) {
+	result := conn(r.db).Model(&models.NotificationOutbox{}).
+		Where("status = ?", models.OutboxDead).
+		Updates(map[string]interface{}{
+			"status":          models.OutboxPending,
+			"attempts":        0,
+			"next_attempt_at": now,
+		})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"errors"
+	"project-management-backend/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	Create(notification *models.Notification) error
+	GetByUserID(userID uint, unreadOnly bool, limit, offset int) ([]models.Notification, int64, error)
+	CountUnread(userID uint) (int64, error)
+	MarkRead(notificationID uint, userID uint, readAt time.Time) (bool, error)
+	MarkAllRead(userID uint, readAt time.Time) (int64, error)
+
+	// WithTx returns a repository whose queries run inside tx
+	WithTx(tx *gorm.DB) NotificationRepository
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository() NotificationRepository {
+	return &notificationRepository{}
+}
+
+func (r *notificationRepository) WithTx(tx *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: tx}
+}
+
+func (r *notificationRepository) Create(notification *models.Notification) error {
+	return conn(r.db).Create(notification).Error
+}
+
+func (r *notificationRepository) GetByUserID(userID uint, unreadOnly bool, limit, offset int) ([]models.Notification, int64, error) {
+	var notifications []models.Notification
+	var total int64
+
+	db := conn(r.db).Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		db = db.Where("read_at IS NULL")
+	}
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.
+		Preload("Actor").
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&notifications).Error
+	return notifications, total, err
+}
+
+func (r *notificationRepository) CountUnread(userID uint) (int64, error) {
+	var count int64
+	err := conn(r.db).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead returns false when the notification does not exist or belongs to
+// another user.
+func (r *notificationRepository) MarkRead(notificationID uint, userID uint, readAt time.Time) (bool, error) {
+	var notification models.Notification
+	err := conn(r.db).Where("id = ? AND user_id = ?", notificationID, userID).First(&notification).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if notification.ReadAt != nil {
+		return true, nil
+	}
+	err = conn(r.db).Model(&notification).Update("read_at", readAt).Error
+	return err == nil, err
+}
+
+func (r *notificationRepository) MarkAllRead(userID uint, readAt time.Time) (int64, error) {
+	result := conn(r.db).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"project-management-backend/config"
+	"project-management-backend/models"
+	"time"
+)
+
+type PasswordResetRepository interface {
+	Create(token *models.PasswordResetToken) error
+	GetValid(tokenHash string, now time.Time) (*models.PasswordResetToken, error)
+	MarkUsed(tokenID uint, usedAt time.Time) (bool, error)
+	DeleteUnusedByUserID(userID uint) error
+}
+
+type passwordResetRepository struct{}
+
+func NewPasswordResetRepository() PasswordResetRepository {
+	return &passwordResetRepository{}
+}
+
+func (r *passwordResetRepository) Create(token *models.PasswordResetToken) error {
+	return config.DB.Create(token).Error
+}
+
+func (r *passwordResetRepository) GetValid(tokenHash string, now time.Time) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	err := config.DB.
+		Preload("User").
+		Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+		First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed marks the token as used. It returns false when the token was
+// already used, so two concurrent resets with the same token cannot both
+// succeed.
+func (r *passwordResetRepository) MarkUsed(tokenID uint, usedAt time.Time) (bool, error) {
+	result := config.DB.Model(&models.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", tokenID).
+		Update("used_at", usedAt)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *passwordResetRepository) DeleteUnusedByUserID(userID uint) error {
+	return config.DB.Where("user_id = ? AND used_at IS NULL", userID).Delete(&models.PasswordResetToken{}).Error
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"project-management-backend/config"
+	"project-management-backend/models"
+	"time"
+)
+
+type ReportJobRepository interface {
+	Create(job *models.ReportJob) error
+	GetByID(jobID uint) (*models.ReportJob, error)
+	GetByUserID(userID uint) ([]models.ReportJob, error)
+	GetByStatuses(statuses []string) ([]models.ReportJob, error)
+	GetExpired(now time.Time) ([]models.ReportJob, error)
+	Update(jobID uint, updates map[string]interface{}) error
+}
+
+type reportJobRepository struct{}
+
+func NewReportJobRepository() ReportJobRepository {
+	return &reportJobRepository{}
+}
+
+func (r *reportJobRepository) Create(job *models.ReportJob) error {
+	return config.DB.Create(job).Error
+}
+
+func (r *reportJobRepository) GetByID(jobID uint) (*models.ReportJob, error) {
+	var job models.ReportJob
+	err := config.DB.Where("id = ?", jobID).First(&job).Error
+	return &job, err
+}
+
+func (r *reportJobRepository) GetByUserID(userID uint) ([]models.ReportJob, error) {
+	var jobs []models.ReportJob
+	err := config.DB.
+		Where("requested_by = ?", userID).
+		Order("created_at DESC").
+		Limit(50).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *reportJobRepository) GetByStatuses(statuses []string) ([]models.ReportJob, error) {
+	var jobs []models.ReportJob
+	err := config.DB.Where("status IN ?", statuses).Order("created_at ASC").Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *reportJobRepository) GetExpired(now time.Time) ([]models.ReportJob, error) {
+	var jobs []models.ReportJob
+	err := config.DB.
+		Where("status = ? AND expires_at IS NOT NULL AND expires_at < ?", models.ReportJobDone, now).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *reportJobRepository) Update(jobID uint, updates map[string]interface{}) error {
+	return config.DB.Model(&models.ReportJob{}).Where("id = ?", jobID).Updates(updates).Error
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"project-management-backend/config"
+	"project-management-backend/models"
+	"time"
+)
+
+type ReportScheduleRepository interface {
+	Create(schedule *models.ReportSchedule) error
+	GetAll() ([]models.ReportSchedule, error)
+	GetByID(scheduleID uint) (*models.ReportSchedule, error)
+	GetDue(now time.Time) ([]models.ReportSchedule, error)
+	Save(schedule *models.ReportSchedule) error
+	Update(scheduleID uint, updates map[string]interface{}) error
+	Delete(scheduleID uint) error
+}
+
+type reportScheduleRepository struct{}
+
+func NewReportScheduleRepository() ReportScheduleRepository {
+	return &reportScheduleRepository{}
+}
+
+func (r *reportScheduleRepository) Create(schedule *models.ReportSchedule) error {
+	return config.DB.Create(schedule).Error
+}
+
+func (r *reportScheduleRepository) GetAll() ([]models.ReportSchedule, error) {
+	var schedules []models.ReportSchedule
+	err := config.DB.Order("created_at DESC").Find(&schedules).Error
+	return schedules, err
+}
+
+func (r *reportScheduleRepository) GetByID(scheduleID uint) (*models.ReportSchedule, error) {
+	var schedule models.ReportSchedule
+	err := config.DB.Where("id = ?", scheduleID).First(&schedule).Error
+	return &schedule, err
+}
+
+func (r *reportScheduleRepository) GetDue(now time.Time) ([]models.ReportSchedule, error) {
+	var schedules []models.ReportSchedule
+	err := config.DB.
+		Where("enabled = ? AND next_run_at IS NOT NULL AND next_run_at <= ?", true, now).
+		Find(&schedules).Error
+	return schedules, err
+}
+
+func (r *reportScheduleRepository) Save(schedule *models.ReportSchedule) error {
+	return config.DB.Save(schedule).Error
+}
+
+func (r *reportScheduleRepository) Update(scheduleID uint, updates map[string]interface{}) error {
+	return config.DB.Model(&models.ReportSchedule{}).Where("id = ?", scheduleID).Updates(updates).Error
+}
+
+func (r *reportScheduleRepository) Delete(scheduleID uint) error {
+	return config.DB.Where("id = ?", scheduleID).Delete(&models.ReportSchedule{}).Error
+}
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"project-management-backend/config"
+	"project-management-backend/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+	Create(session *models.UserSession, token *models.RefreshToken) error
+	GetByID(sessionID uint) (*models.UserSession, error)
+	GetActiveByUserID(userID uint) ([]models.UserSession, error)
+	GetRefreshToken(tokenHash string) (*models.RefreshToken, error)
+	RotateRefreshToken(used *models.RefreshToken, next *models.RefreshToken) (bool, error)
+	Revoke(sessionID uint, reason string) error
+	RevokeAllByUserID(userID uint, reason string) error
+	RevokeOthersByUserID(userID, keepSessionID uint, reason string) error
+}
+
+type sessionRepository struct{}
+
+func NewSessionRepository() SessionRepository {
+	return &sessionRepository{}
+}
+
+// Create stores a new session together with its first refresh token.
+func (r *sessionRepository) Create(session *models.UserSession, token *models.RefreshToken) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		token.SessionID = session.ID
+		return tx.Create(token).Error
+	})
+}
+
+func (r *sessionRepository) GetByID(sessionID uint) (*models.UserSession, error) {
+	var session models.UserSession
+	if err := config.DB.First(&session, sessionID).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *sessionRepository) GetActiveByUserID(userID uint) ([]models.UserSession, error) {
+	var sessions []models.UserSession
+	err := config.DB.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *sessionRepository) GetRefreshToken(tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := config.DB.Preload("Session").Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken marks used as used, stores next and extends the session
+// to the expiry of next. It returns false when used was already used, e.g.
+// by a concurrent request with the same token.
+func (r *sessionRepository) RotateRefreshToken(used *models.RefreshToken, next *models.RefreshToken) (bool, error) {
+	rotated := false
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL", used.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		next.SessionID = used.SessionID
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+
+		// Token lama yang sudah kedaluwarsa tidak perlu disimpan lagi
+		if err := tx.Where("session_id = ? AND expires_at < ?", used.SessionID, now).Delete(&models.RefreshToken{}).Error; err != nil {
+			return err
+		}
+
+		rotated = true
+		return tx.Model(&models.UserSession{}).Where("id = ?", used.SessionID).Updates(map[string]interface{}{
+			"last_used_at": now,
+			"expires_at":   next.ExpiresAt,
+		}).Error
+	})
+	return rotated, err
+}
+
+func (r *sessionRepository) Revoke(sessionID uint, reason string) error {
+	return config.DB.Model(&models.UserSession{}).
+		Where("id = ? AND revoked_at IS NULL", sessionID).
+		Updates(map[string]interface{}{
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		}).Error
+}
+
+func (r *sessionRepository) RevokeAllByUserID(userID uint, reason string) error {
+	return config.DB.Model(&models.UserSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		}).Error
+}
+
+// RevokeOthersByUserID revokes every session of the user except
+// keepSessionID.
+func (r *sessionRepository) RevokeOthersByUserID(userID, keepSessionID uint, reason string) error {
+	return config.DB.Model(&models.UserSession{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, keepSessionID).
+		Updates(map[string]interface{}{
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		}).Error
+}
@@ -1,7 +1,7 @@
 package repositories
 
 import (
-	"project-management-backend/config"
+	"fmt"
 	"project-management-backend/models"
 	"time"
 
@@ -34,21 +34,41 @@ type TaskRepository interface {
 	GetTasksOnBoardSince(projectID uint, since time.Time) ([]models.Task, error)
 	GetOnProgressTasksDueBetween(projectID uint, from, to time.Time) ([]models.Task, error)
 	GetTasksWithStatusOtherThan(projectID uint, statuses []string) ([]models.Task, error)
+
+	// Lintas project, dipakai scheduler deadline
+	GetTasksDueForReminder(now, until time.Time) ([]models.Task, error)
+	GetOpenTasksOverdue(now time.Time) ([]models.Task, error)
+	MarkDeadlineNotified(taskID uint, column string, at time.Time) (bool, error)
+
+	// Task milik satu user lintas project, dipakai digest harian
+	GetUserOpenTasksDueBetween(userID uint, from, to time.Time) ([]models.Task, error)
+	GetUserOpenTasksOverdue(userID uint, now time.Time) ([]models.Task, error)
+	GetTasksAssignedToUserBetween(userID uint, from, to time.Time) ([]models.Task, error)
+	GetUserOpenTasks(userID uint) ([]models.Task, error)
+
+	// WithTx returns a repository whose queries run inside tx
+	WithTx(tx *gorm.DB) TaskRepository
 }
 
-type taskRepository struct{}
+type taskRepository struct {
+	db *gorm.DB
+}
 
 func NewTaskRepository() TaskRepository {
 	return &taskRepository{}
 }
 
+func (r *taskRepository) WithTx(tx *gorm.DB) TaskRepository {
+	return &taskRepository{db: tx}
+}
+
 func (r *taskRepository) CreateTask(task *models.Task) error {
-	return config.DB.Create(task).Error
+	return conn(r.db).Create(task).Error
 }
 
 func (r *taskRepository) GetAllTasks(projectID uint) ([]models.Task, error) {
 	var tasks []models.Task
-	err := config.DB.
+	err := conn(r.db).
 		Joins("JOIN projects ON projects.id = tasks.project_id").
 		Joins("JOIN workspaces ON workspaces.id = projects.workspace_id").
 		Where("tasks.project_id = ? AND tasks.deleted_at IS NULL AND projects.deleted_at IS NULL AND workspaces.deleted_at IS NULL", projectID).
@@ -62,7 +82,7 @@ func (r *taskRepository) GetAllTasks(projectID uint) ([]models.Task, error) {
 
 func (r *taskRepository) GetAllTasksByUserID(userID uint) ([]models.Task, error) {
 	var tasks []models.Task
-	err := config.DB.
+	err := conn(r.db).
 		Joins("JOIN task_users ON task_users.task_id = tasks.id").
 		Joins("JOIN projects ON projects.id = tasks.project_id").
 		Joins("JOIN workspaces ON workspaces.id = projects.workspace_id").
@@ -77,7 +97,7 @@ func (r *taskRepository) GetAllTasksByUserID(userID uint) ([]models.Task, error)
 
 func (r *taskRepository) GetAllTasksForAdmin() ([]models.Task, error) {
 	var tasks []models.Task
-	err := config.DB.
+	err := conn(r.db).
 		Joins("JOIN projects ON projects.id = tasks.project_id").
 		Joins("JOIN workspaces ON workspaces.id = projects.workspace_id").
 		Where("tasks.deleted_at IS NULL AND projects.deleted_at IS NULL AND workspaces.deleted_at IS NULL").
@@ -91,7 +111,7 @@ func (r *taskRepository) GetAllTasksForAdmin() ([]models.Task, error) {
 
 func (r *taskRepository) GetTasksByUserID(projectID uint, userID uint) ([]models.Task, error) {
 	var tasks []models.Task
-	err := config.DB.
+	err := conn(r.db).
 		Joins("JOIN projects ON projects.id = tasks.project_id").
 		Joins("JOIN workspaces ON workspaces.id = projects.workspace_id").
 		Where("tasks.project_id = ? AND tasks.deleted_at IS NULL AND projects.deleted_at IS NULL AND workspaces.deleted_at IS NULL", projectID).
@@ -105,20 +125,20 @@ func (r *taskRepository) GetTasksByUserID(projectID uint, userID uint) ([]models
 }
 
 func (r *taskRepository) SoftDeleteAllTasksInProject(projectID uint) error {
-	return config.DB.Model(&models.Task{}).
+	return conn(r.db).Model(&models.Task{}).
 		Where("project_id = ?", projectID).
 		Update("deleted_at", time.Now()).Error
 }
 
 func (r *taskRepository) GetProjectByID(projectID uint) (*models.Project, error) {
 	var project models.Project
-	err := config.DB.First(&project, projectID).Error
+	err := conn(r.db).First(&project, projectID).Error
 	return &project, err
 }
 
 func (r *taskRepository) GetByID(taskID uint) (*models.Task, error) {
 	var task models.Task
-	err := config.DB.
+	err := conn(r.db).
 		Joins("JOIN projects ON projects.id = tasks.project_id").
 		Joins("JOIN workspaces ON workspaces.id = projects.workspace_id").
 		Where("tasks.id = ? AND tasks.deleted_at IS NULL AND projects.deleted_at IS NULL AND workspaces.deleted_at IS NULL", taskID).
@@ -131,19 +151,39 @@ func (r *taskRepository) GetByID(taskID uint) (*models.Task, error) {
 	return &task, err
 }
 
+// Kolom penanda yang boleh dipakai MarkDeadlineNotified
+var deadlineMarkerColumns = map[string]bool{
+	"reminder_sent_at":    true,
+	"overdue_notified_at": true,
+	"escalated_at":        true,
+}
+
+// MarkDeadlineNotified sets a deadline marker column unless it is already
+// set. It returns false when another scheduler run set it first, so only one
+// instance sends the notification.
+func (r *taskRepository) MarkDeadlineNotified(taskID uint, column string, at time.Time) (bool, error) {
+	if !deadlineMarkerColumns[column] {
+		return false, fmt.Errorf("unknown deadline marker %q", column)
+	}
+	result := conn(r.db).Model(&models.Task{}).
+		Where("id = ? AND deleted_at IS NULL AND "+column+" IS NULL", taskID).
+		Update(column, at)
+	return result.RowsAffected > 0, result.Error
+}
+
 func (r *taskRepository) UpdateTask(taskID uint, updates map[string]interface{}) error {
-	return config.DB.Model(&models.Task{}).
+	return conn(r.db).Model(&models.Task{}).
 		Where("id = ? AND deleted_at IS NULL", taskID).
 		Updates(updates).Error
 }
 func (r *taskRepository) SoftDeleteTask(taskID uint) error {
-	return config.DB.Model(&models.Task{}).
+	return conn(r.db).Model(&models.Task{}).
 		Where("id = ?", taskID).
 		Update("deleted_at", time.Now()).Error
 }
 
 func (r *taskRepository) DeleteTask(taskID uint) error {
-	return config.DB.Transaction(func(tx *gorm.DB) error {
+	return conn(r.db).Transaction(func(tx *gorm.DB) error {
 		// 1. Delete task_users (members)
 		if err := tx.Where("task_id = ?", taskID).Delete(&models.TaskUser{}).Error; err != nil {
 			return err
@@ -164,12 +204,12 @@ func (r *taskRepository) DeleteTask(taskID uint) error {
 }
 
 func (r *taskRepository) AddMember(tu *models.TaskUser) error {
-	return config.DB.Create(tu).Error
+	return conn(r.db).Create(tu).Error
 }
 
 func (r *taskRepository) GetMembers(taskID uint) ([]models.TaskUser, error) {
 	var members []models.TaskUser
-	err := config.DB.
+	err := conn(r.db).
 		Preload("User").
 		Where("task_id = ?", taskID).
 		Find(&members).Error
@@ -177,18 +217,18 @@ func (r *taskRepository) GetMembers(taskID uint) ([]models.TaskUser, error) {
 }
 
 func (r *taskRepository) DeleteMember(taskID uint, userID uint) error {
-	return config.DB.Where("task_id = ? AND user_id = ?", taskID, userID).Delete(&models.TaskUser{}).Error
+	return conn(r.db).Where("task_id = ? AND user_id = ?", taskID, userID).Delete(&models.TaskUser{}).Error
 }
 
 func (r *taskRepository) GetUserByID(userID uint) (*models.User, error) {
 	var user models.User
-	err := config.DB.First(&user, userID).Error
+	err := conn(r.db).First(&user, userID).Error
 	return &user, err
 }
 
 func (r *taskRepository) IsProjectInWorkspace(projectID uint, workspaceID uint) (bool, error) {
 	var count int64
-	err := config.DB.Model(&models.Project{}).
+	err := conn(r.db).Model(&models.Project{}).
 		Where("id = ? AND workspace_id = ?", projectID, workspaceID).
 		Count(&count).Error
 	return count > 0, err
@@ -196,7 +236,7 @@ func (r *taskRepository) IsProjectInWorkspace(projectID uint, workspaceID uint)
 
 func (r *taskRepository) IsUserMember(taskID uint, userID uint) (bool, error) {
 	var count int64
-	err := config.DB.Model(&models.TaskUser{}).
+	err := conn(r.db).Model(&models.TaskUser{}).
 		Where("task_id = ? AND user_id = ?", taskID, userID).
 		Count(&count).Error
 	return count > 0, err
@@ -204,14 +244,14 @@ func (r *taskRepository) IsUserMember(taskID uint, userID uint) (bool, error) {
 
 func (r *taskRepository) IsUserInProject(projectID uint, userID uint) (bool, error) {
 	var count int64
-	err := config.DB.Model(&models.ProjectUser{}).
+	err := conn(r.db).Model(&models.ProjectUser{}).
 		Where("project_id = ? AND user_id = ?", projectID, userID).
 		Count(&count).Error
 	return count > 0, err
 }
 
-func newBaseTaskQuery(projectID uint) *gorm.DB {
-	return config.DB.
+func newBaseTaskQuery(db *gorm.DB, projectID uint) *gorm.DB {
+	return db.
 		Joins("JOIN projects ON projects.id = tasks.project_id").
 		Joins("JOIN workspaces ON workspaces.id = projects.workspace_id").
 		Where("tasks.project_id = ? AND tasks.deleted_at IS NULL AND projects.deleted_at IS NULL AND workspaces.deleted_at IS NULL", projectID).
@@ -222,42 +262,108 @@ func newBaseTaskQuery(projectID uint) *gorm.DB {
 
 func (r *taskRepository) GetTasksInProgressSince(projectID uint, since time.Time) ([]models.Task, error) {
 	var tasks []models.Task
-	db := newBaseTaskQuery(projectID)
+	db := newBaseTaskQuery(conn(r.db), projectID)
 	err := db.Where("tasks.status = 'on_progress' AND tasks.updated_at >= ?", since).Find(&tasks).Error
 	return tasks, err
 }
 
 func (r *taskRepository) GetTasksDoneSince(projectID uint, since time.Time) ([]models.Task, error) {
 	var tasks []models.Task
-	db := newBaseTaskQuery(projectID)
+	db := newBaseTaskQuery(conn(r.db), projectID)
 	err := db.Where("tasks.status = 'done' AND tasks.finished_at >= ?", since).Find(&tasks).Error
 	return tasks, err
 }
 
 func (r *taskRepository) GetTasksOnBoardSince(projectID uint, since time.Time) ([]models.Task, error) {
 	var tasks []models.Task
-	db := newBaseTaskQuery(projectID)
+	db := newBaseTaskQuery(conn(r.db), projectID)
 	err := db.Where("tasks.status = 'on_board' AND tasks.updated_at >= ?", since).Find(&tasks).Error
 	return tasks, err
 }
 
 func (r *taskRepository) GetTasksStartingBetween(projectID uint, from, to time.Time) ([]models.Task, error) {
 	var tasks []models.Task
-	db := newBaseTaskQuery(projectID)
+	db := newBaseTaskQuery(conn(r.db), projectID)
 	err := db.Where("tasks.start_date BETWEEN ? AND ?", from, to).Find(&tasks).Error
 	return tasks, err
 }
 
 func (r *taskRepository) GetOnProgressTasksDueBetween(projectID uint, from, to time.Time) ([]models.Task, error) {
 	var tasks []models.Task
-	db := newBaseTaskQuery(projectID)
+	db := newBaseTaskQuery(conn(r.db), projectID)
 	err := db.Where("tasks.status = 'on_progress' AND tasks.due_date BETWEEN ? AND ?", from, to).Find(&tasks).Error
 	return tasks, err
 }
 
 func (r *taskRepository) GetTasksWithStatusOtherThan(projectID uint, statuses []string) ([]models.Task, error) {
 	var tasks []models.Task
-	db := newBaseTaskQuery(projectID)
+	db := newBaseTaskQuery(conn(r.db), projectID)
 	err := db.Where("tasks.status NOT IN ?", statuses).Find(&tasks).Error
 	return tasks, err
 }
+
+func newOpenTaskQuery(db *gorm.DB) *gorm.DB {
+	return db.
+		Joins("JOIN projects ON projects.id = tasks.project_id").
+		Joins("JOIN workspaces ON workspaces.id = projects.workspace_id").
+		Where("tasks.deleted_at IS NULL AND projects.deleted_at IS NULL AND workspaces.deleted_at IS NULL").
+		Where("LOWER(tasks.status) <> 'done'").
+		Preload("Members.User").
+		Preload("Project")
+}
+
+func (r *taskRepository) GetTasksDueForReminder(now, until time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+	err := newOpenTaskQuery(conn(r.db)).
+		Where("tasks.reminder_sent_at IS NULL AND tasks.due_date > ? AND tasks.due_date <= ?", now, until).
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskRepository) GetOpenTasksOverdue(now time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+	err := newOpenTaskQuery(conn(r.db)).Where("tasks.due_date < ?", now).Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskRepository) GetUserOpenTasksDueBetween(userID uint, from, to time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+	err := newOpenTaskQuery(conn(r.db)).
+		Where("tasks.id IN (SELECT task_id FROM task_users WHERE user_id = ?)", userID).
+		Where("tasks.due_date >= ? AND tasks.due_date < ?", from, to).
+		Order("tasks.due_date ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskRepository) GetUserOpenTasksOverdue(userID uint, now time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+	err := newOpenTaskQuery(conn(r.db)).
+		Where("tasks.id IN (SELECT task_id FROM task_users WHERE user_id = ?)", userID).
+		Where("tasks.due_date < ?", now).
+		Order("tasks.due_date ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskRepository) GetTasksAssignedToUserBetween(userID uint, from, to time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+	err := conn(r.db).
+		Joins("JOIN projects ON projects.id = tasks.project_id").
+		Joins("JOIN workspaces ON workspaces.id = projects.workspace_id").
+		Where("tasks.deleted_at IS NULL AND projects.deleted_at IS NULL AND workspaces.deleted_at IS NULL").
+		Where("tasks.id IN (SELECT task_id FROM task_users WHERE user_id = ? AND created_at >= ? AND created_at < ?)", userID, from, to).
+		Preload("Project").
+		Order("tasks.due_date ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskRepository) GetUserOpenTasks(userID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := newOpenTaskQuery(conn(r.db)).
+		Where("tasks.id IN (SELECT task_id FROM task_users WHERE user_id = ?)", userID).
+		Order("tasks.due_date ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
@@ -1,7 +1,6 @@
 package repositories
 
 import (
-	"project-management-backend/config"
 	"project-management-backend/models"
 	"time"
 
@@ -13,21 +12,31 @@ type TaskStatusLogRepository interface {
 	FindLastLog(taskID uint) (*models.TaskStatusLog, error)
 	UpdateClockOut(logID uint, clockOut time.Time) error
 	GetLogsByTaskID(taskID uint) ([]models.TaskStatusLog, error)
+	GetStatusChangesBetween(projectID uint, start, end time.Time) ([]models.TaskStatusLog, error)
+
+	// WithTx returns a repository whose queries run inside tx
+	WithTx(tx *gorm.DB) TaskStatusLogRepository
 }
 
-type taskStatusLogRepository struct{}
+type taskStatusLogRepository struct {
+	db *gorm.DB
+}
 
 func NewTaskStatusLogRepository() TaskStatusLogRepository {
 	return &taskStatusLogRepository{}
 }
 
+func (r *taskStatusLogRepository) WithTx(tx *gorm.DB) TaskStatusLogRepository {
+	return &taskStatusLogRepository{db: tx}
+}
+
 func (r *taskStatusLogRepository) Create(log *models.TaskStatusLog) error {
-	return config.DB.Create(log).Error
+	return conn(r.db).Create(log).Error
 }
 
 func (r *taskStatusLogRepository) FindLastLog(taskID uint) (*models.TaskStatusLog, error) {
 	var log models.TaskStatusLog
-	err := config.DB.Where("task_id = ?", taskID).Order("created_at desc").First(&log).Error
+	err := conn(r.db).Where("task_id = ?", taskID).Order("created_at desc").First(&log).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -39,14 +48,14 @@ func (r *taskStatusLogRepository) FindLastLog(taskID uint) (*models.TaskStatusLo
 
 func (r *taskStatusLogRepository) UpdateClockOut(logID uint, clockOut time.Time) error {
 	var log models.TaskStatusLog
-	if err := config.DB.First(&log, logID).Error; err != nil {
+	if err := conn(r.db).First(&log, logID).Error; err != nil {
 		return err
 	}
 	if log.ClockIn.IsZero() {
-		return config.DB.Model(&models.TaskStatusLog{}).Where("id = ?", logID).Update("clock_out", clockOut).Error
+		return conn(r.db).Model(&models.TaskStatusLog{}).Where("id = ?", logID).Update("clock_out", clockOut).Error
 	}
 	duration := clockOut.Sub(log.ClockIn).Milliseconds()
-	return config.DB.Model(&models.TaskStatusLog{}).Where("id = ?", logID).Updates(map[string]interface{}{
+	return conn(r.db).Model(&models.TaskStatusLog{}).Where("id = ?", logID).Updates(map[string]interface{}{
 		"clock_out": clockOut,
 		"duration":  duration,
 	}).Error
@@ -54,6 +63,22 @@ func (r *taskStatusLogRepository) UpdateClockOut(logID uint, clockOut time.Time)
 
 func (r *taskStatusLogRepository) GetLogsByTaskID(taskID uint) ([]models.TaskStatusLog, error) {
 	var logs []models.TaskStatusLog
-	err := config.DB.Where("task_id = ?", taskID).Order("created_at asc").Find(&logs).Error
+	err := conn(r.db).Where("task_id = ?", taskID).Order("created_at asc").Find(&logs).Error
+	return logs, err
+}
+
+// GetStatusChangesBetween returns status transitions (not the initial log
+// written on task creation) for tasks in a project, oldest first.
+func (r *taskStatusLogRepository) GetStatusChangesBetween(projectID uint, start, end time.Time) ([]models.TaskStatusLog, error) {
+	var logs []models.TaskStatusLog
+	err := conn(r.db).
+		Joins("JOIN tasks ON tasks.id = task_status_logs.task_id").
+		Where("tasks.project_id = ?", projectID).
+		Where("task_status_logs.previous_status IS NOT NULL").
+		Where("task_status_logs.created_at >= ? AND task_status_logs.created_at < ?", start, end).
+		Preload("Task").
+		Preload("Actor").
+		Order("task_status_logs.created_at asc").
+		Find(&logs).Error
 	return logs, err
 }
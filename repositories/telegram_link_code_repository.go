@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"project-management-backend/config"
+	"project-management-backend/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TelegramLinkCodeRepository interface {
+	Create(code *models.TelegramLinkCode) error
+	GetValid(code string, now time.Time) (*models.TelegramLinkCode, error)
+	MarkUsed(tx *gorm.DB, codeID uint, usedAt time.Time) (bool, error)
+	DeleteUnusedByUserID(userID uint) error
+}
+
+type telegramLinkCodeRepository struct{}
+
+func NewTelegramLinkCodeRepository() TelegramLinkCodeRepository {
+	return &telegramLinkCodeRepository{}
+}
+
+func (r *telegramLinkCodeRepository) Create(code *models.TelegramLinkCode) error {
+	return config.DB.Create(code).Error
+}
+
+func (r *telegramLinkCodeRepository) GetValid(code string, now time.Time) (*models.TelegramLinkCode, error) {
+	var linkCode models.TelegramLinkCode
+	err := config.DB.
+		Preload("User").
+		Where("code = ? AND used_at IS NULL AND expires_at > ?", code, now).
+		First(&linkCode).Error
+	return &linkCode, err
+}
+
+// MarkUsed marks the code as used. It returns false when the code was
+// already used, so a code cannot link two chats. tx may be nil.
+func (r *telegramLinkCodeRepository) MarkUsed(tx *gorm.DB, codeID uint, usedAt time.Time) (bool, error) {
+	result := conn(tx).Model(&models.TelegramLinkCode{}).
+		Where("id = ? AND used_at IS NULL", codeID).
+		Update("used_at", usedAt)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *telegramLinkCodeRepository) DeleteUnusedByUserID(userID uint) error {
+	return config.DB.Where("user_id = ? AND used_at IS NULL", userID).Delete(&models.TelegramLinkCode{}).Error
+}
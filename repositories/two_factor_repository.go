@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"project-management-backend/config"
+	"project-management-backend/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TwoFactorRepository interface {
+	ConsumeCounter(userID uint, counter int64) (bool, error)
+	ReplaceRecoveryCodes(userID uint, codeHashes []string) error
+	UseRecoveryCode(userID uint, codeHash string) (bool, error)
+	CountUnusedRecoveryCodes(userID uint) (int64, error)
+	Disable(userID uint) error
+}
+
+type twoFactorRepository struct{}
+
+func NewTwoFactorRepository() TwoFactorRepository {
+	return &twoFactorRepository{}
+}
+
+// ConsumeCounter records counter as the last used TOTP time step. It returns
+// false when the same or a later step was already used, so a code cannot be
+// replayed.
+func (r *twoFactorRepository) ConsumeCounter(userID uint, counter int64) (bool, error) {
+	result := config.DB.Model(&models.User{}).
+		Where("id = ? AND two_factor_last_counter < ?", userID, counter).
+		Update("two_factor_last_counter", counter)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ReplaceRecoveryCodes deletes the user's existing recovery codes and stores
+// codeHashes in their place.
+func (r *twoFactorRepository) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserRecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]models.UserRecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, models.UserRecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+func (r *twoFactorRepository) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	result := config.DB.Model(&models.UserRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *twoFactorRepository) CountUnusedRecoveryCodes(userID uint) (int64, error) {
+	var count int64
+	err := config.DB.Model(&models.UserRecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// Disable turns two-factor authentication off and removes the secret and
+// recovery codes.
+func (r *twoFactorRepository) Disable(userID uint) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserRecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"two_factor_enabled":      false,
+			"two_factor_secret":       nil,
+			"two_factor_last_counter": 0,
+		}).Error
+	})
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"project-management-backend/config"
+
+	"gorm.io/gorm"
+)
+
+// conn returns the transaction a repository was bound to with WithTx, or the
+// global connection when it is not bound.
+func conn(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return config.DB
+}
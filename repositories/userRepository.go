@@ -24,6 +24,12 @@ type UserRepository interface {
 	GetOnlineWorkspaceMembers(workspaceID uint) ([]models.User, error)
 	IsUserMemberOfWorkspace(userID uint, workspaceID uint) (bool, error)
 	DeleteUser(userID uint) error
+	GetDigestSubscribers() ([]models.User, error)
+	UpdateFields(userID uint, updates map[string]interface{}) error
+	GetByTelegramChatID(chatID string) (*models.User, error)
+	LinkTelegramChat(tx *gorm.DB, userID uint, chatID string) error
+	IsMemberOfTwoFactorWorkspace(userID uint) (bool, error)
+	MarkDigestSent(tx *gorm.DB, userID uint, scheduledAt, sentAt time.Time) (bool, error)
 }
 
 type userRepository struct{}
@@ -234,7 +240,14 @@ func (r *userRepository) DeleteUser(userID uint) error {
 			return err
 		}
 
-		// 4. Finally, hard delete the user itself.
+		// 4. Revoke API tokens so a leaked token stops working with the account
+		if err := tx.Model(&models.APIToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", time.Now()).Error; err != nil {
+			return err
+		}
+
+		// 5. Finally, hard delete the user itself.
 		if err := tx.Unscoped().Where("id = ?", userID).Delete(&models.User{}).Error; err != nil {
 			return err
 		}
@@ -242,3 +255,55 @@ func (r *userRepository) DeleteUser(userID uint) error {
 		return nil
 	})
 }
+
+func (r *userRepository) GetDigestSubscribers() ([]models.User, error) {
+	var users []models.User
+	err := config.DB.
+		Where("digest_time IS NOT NULL AND deleted_at IS NULL").
+		Find(&users).Error
+	return users, err
+}
+
+func (r *userRepository) UpdateFields(userID uint, updates map[string]interface{}) error {
+	return config.DB.Model(&models.User{}).Where("id = ?", userID).Updates(updates).Error
+}
+
+func (r *userRepository) GetByTelegramChatID(chatID string) (*models.User, error) {
+	var user models.User
+	err := config.DB.Where("telegram_chat_id = ? AND deleted_at IS NULL", chatID).First(&user).Error
+	return &user, err
+}
+
+// LinkTelegramChat connects chatID to userID, detaching it from any other
+// account first so a chat always maps to one user. tx may be nil.
+func (r *userRepository) LinkTelegramChat(tx *gorm.DB, userID uint, chatID string) error {
+	return conn(tx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).
+			Where("telegram_chat_id = ? AND id <> ?", chatID, userID).
+			Update("telegram_chat_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.User{}).Where("id = ?", userID).Update("telegram_chat_id", chatID).Error
+	})
+}
+
+// IsMemberOfTwoFactorWorkspace reports whether the user belongs to a
+// workspace that requires two-factor authentication for admins.
+func (r *userRepository) IsMemberOfTwoFactorWorkspace(userID uint) (bool, error) {
+	var count int64
+	err := config.DB.Table("workspace_users").
+		Joins("JOIN workspaces ON workspaces.id = workspace_users.workspace_id").
+		Where("workspace_users.user_id = ? AND workspaces.require_admin_two_factor = ? AND workspaces.deleted_at IS NULL", userID, true).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// MarkDigestSent records that the digest scheduled at scheduledAt was sent.
+// It returns false when it was already marked, e.g. by another instance.
+// tx may be nil.
+func (r *userRepository) MarkDigestSent(tx *gorm.DB, userID uint, scheduledAt, sentAt time.Time) (bool, error) {
+	result := conn(tx).Model(&models.User{}).
+		Where("id = ? AND (last_digest_sent_at IS NULL OR last_digest_sent_at < ?)", userID, scheduledAt).
+		Update("last_digest_sent_at", sentAt)
+	return result.RowsAffected > 0, result.Error
+}
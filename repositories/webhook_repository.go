@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"project-management-backend/config"
+	"project-management-backend/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository interface {
+	Create(webhook *models.WorkspaceWebhook) error
+	GetByID(webhookID uint) (*models.WorkspaceWebhook, error)
+	GetByWorkspaceID(workspaceID uint) ([]models.WorkspaceWebhook, error)
+	GetActiveByWorkspaceID(workspaceID uint) ([]models.WorkspaceWebhook, error)
+	Update(webhook *models.WorkspaceWebhook) error
+	Delete(webhookID uint) error
+
+	CreateDelivery(delivery *models.WebhookDelivery) error
+	GetDeliveryByID(deliveryID uint) (*models.WebhookDelivery, error)
+	GetDeliveries(webhookID uint, limit, offset int) ([]models.WebhookDelivery, int64, error)
+	ClaimDueDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error)
+	UpdateDelivery(deliveryID uint, updates map[string]interface{}) error
+	ResetProcessingDeliveries(staleBefore time.Time) error
+}
+
+type webhookRepository struct{}
+
+func NewWebhookRepository() WebhookRepository {
+	return &webhookRepository{}
+}
+
+func (r *webhookRepository) Create(webhook *models.WorkspaceWebhook) error {
+	return config.DB.Create(webhook).Error
+}
+
+func (r *webhookRepository) GetByID(webhookID uint) (*models.WorkspaceWebhook, error) {
+	var webhook models.WorkspaceWebhook
+	if err := config.DB.First(&webhook, webhookID).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepository) GetByWorkspaceID(workspaceID uint) ([]models.WorkspaceWebhook, error) {
+	var webhooks []models.WorkspaceWebhook
+	err := config.DB.Where("workspace_id = ?", workspaceID).Order("id ASC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) GetActiveByWorkspaceID(workspaceID uint) ([]models.WorkspaceWebhook, error) {
+	var webhooks []models.WorkspaceWebhook
+	err := config.DB.Where("workspace_id = ? AND is_active = ?", workspaceID, true).Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) Update(webhook *models.WorkspaceWebhook) error {
+	return config.DB.Save(webhook).Error
+}
+
+func (r *webhookRepository) Delete(webhookID uint) error {
+	return config.DB.Delete(&models.WorkspaceWebhook{}, webhookID).Error
+}
+
+func (r *webhookRepository) CreateDelivery(delivery *models.WebhookDelivery) error {
+	return config.DB.Create(delivery).Error
+}
+
+func (r *webhookRepository) GetDeliveryByID(deliveryID uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := config.DB.Preload("Webhook").First(&delivery, deliveryID).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookRepository) GetDeliveries(webhookID uint, limit, offset int) ([]models.WebhookDelivery, int64, error) {
+	var deliveries []models.WebhookDelivery
+	var total int64
+
+	db := config.DB.Model(&models.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Order("id DESC").Limit(limit).Offset(offset).Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+// ClaimDueDeliveries moves up to limit due deliveries to processing and
+// returns them with their webhook loaded. Rows locked by another worker are
+// skipped, so concurrent instances never claim the same delivery.
+func (r *webhookRepository) ClaimDueDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		if err := tx.Model(&models.WebhookDelivery{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := tx.Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":     models.WebhookDeliveryProcessing,
+				"claimed_at": now,
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.
+			Preload("Webhook").
+			Order("next_attempt_at ASC, id ASC").
+			Find(&deliveries, ids).Error
+	})
+	return deliveries, err
+}
+
+func (r *webhookRepository) UpdateDelivery(deliveryID uint, updates map[string]interface{}) error {
+	return config.DB.Model(&models.WebhookDelivery{}).Where("id = ?", deliveryID).Updates(updates).Error
+}
+
+// ResetProcessingDeliveries returns deliveries claimed before staleBefore to
+// the queue. Fresher claims still belong to a running worker.
+func (r *webhookRepository) ResetProcessingDeliveries(staleBefore time.Time) error {
+	return config.DB.Model(&models.WebhookDelivery{}).
+		Where("status = ? AND (claimed_at IS NULL OR claimed_at < ?)", models.WebhookDeliveryProcessing, staleBefore).
+		Updates(map[string]interface{}{
+			"status":     models.WebhookDeliveryPending,
+			"claimed_at": nil,
+		}).Error
+}
@@ -23,6 +23,7 @@ type WorkspaceRepository interface {
 	GetWorkspacesByUserID(userID uint) ([]models.Workspace, error)
 	RemoveMembers(workspaceID uint, userIDs []uint) error
 	RemoveMember(workspaceID uint, userID uint) error
+	SetRequireAdminTwoFactor(workspaceID uint, required bool) error
 }
 
 type workspaceRepository struct{}
@@ -69,6 +70,15 @@ func (r *workspaceRepository) GetByID(workspaceID uint) (*models.Workspace, erro
 	return &workspace, err
 }
 
+func (r *workspaceRepository) SetRequireAdminTwoFactor(workspaceID uint, required bool) error {
+	return config.DB.Model(&models.Workspace{}).
+		Where("id = ? AND deleted_at IS NULL", workspaceID).
+		Updates(map[string]interface{}{
+			"require_admin_two_factor": required,
+			"updated_at":               time.Now(),
+		}).Error
+}
+
 func (r *workspaceRepository) UpdateWorkspace(workspace *models.Workspace) error {
 	return config.DB.Model(&models.Workspace{}).
 		Where("id = ? AND deleted_at IS NULL", workspace.ID).
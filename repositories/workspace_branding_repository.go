@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"project-management-backend/config"
+	"project-management-backend/models"
+)
+
+type WorkspaceBrandingRepository interface {
+	GetByWorkspaceID(workspaceID uint) (*models.WorkspaceBranding, error)
+	Save(branding *models.WorkspaceBranding) error
+}
+
+type workspaceBrandingRepository struct{}
+
+func NewWorkspaceBrandingRepository() WorkspaceBrandingRepository {
+	return &workspaceBrandingRepository{}
+}
+
+func (r *workspaceBrandingRepository) GetByWorkspaceID(workspaceID uint) (*models.WorkspaceBranding, error) {
+	var branding models.WorkspaceBranding
+	err := config.DB.Where("workspace_id = ?", workspaceID).First(&branding).Error
+	if err != nil {
+		return nil, err
+	}
+	return &branding, nil
+}
+
+func (r *workspaceBrandingRepository) Save(branding *models.WorkspaceBranding) error {
+	return config.DB.Save(branding).Error
+}
@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"log"
 	"os"
 	"project-management-backend/config"
 	"project-management-backend/controllers"
@@ -16,8 +17,10 @@ func SetupRoutes(r *gin.Engine) {
 
 	//Inisiasi Layer
 	userRepo := repositories.NewUserRepository()
-	authService := services.NewAuthService(userRepo)
-	authController := controllers.NewAuthController(authService)
+	sessionRepo := repositories.NewSessionRepository()
+	passwordResetRepo := repositories.NewPasswordResetRepository()
+	twoFactorRepo := repositories.NewTwoFactorRepository()
+	apiTokenRepo := repositories.NewAPITokenRepository()
 
 	//repositories
 	attendanceRepo := repositories.NewAttendanceRepository(config.DB)
@@ -29,28 +32,62 @@ func SetupRoutes(r *gin.Engine) {
 	projectRepo := repositories.NewProjectRepository()
 	projectImageRepo := repositories.NewProjectImageRepository()
 	workspaceRepo := repositories.NewWorkspaceRepository()
+	reportJobRepo := repositories.NewReportJobRepository()
+	reportScheduleRepo := repositories.NewReportScheduleRepository()
+	workspaceBrandingRepo := repositories.NewWorkspaceBrandingRepository()
+	notificationRepo := repositories.NewNotificationRepository()
+	telegramLinkCodeRepo := repositories.NewTelegramLinkCodeRepository()
+	notificationOutboxRepo := repositories.NewNotificationOutboxRepository()
+	webhookRepo := repositories.NewWebhookRepository()
+	chatIntegrationRepo := repositories.NewChatIntegrationRepository()
 
 	// Initialize Activity Logger
 	activityLogger := utils.NewActivityLogger(config.DB)
 
 	// Initialize Telegram Service
 	telegramBotToken := os.Getenv("TELEGRAM_BOT_TOKEN")
-	telegramService := services.NewTelegramService(telegramBotToken)
+	telegramService := services.NewTelegramService(telegramBotToken, os.Getenv("TELEGRAM_API_BASE_URL"))
+
+	// Initialize chat (Slack/Discord/Mattermost) webhook client
+	chatService := services.NewChatService()
+
+	// Initialize Mailer
+	mailer := services.NewMailerFromEnv()
+
+	authService := services.NewAuthService(userRepo, sessionRepo, passwordResetRepo, services.NewMailPasswordResetSender(mailer), twoFactorRepo, apiTokenRepo)
+	authController := controllers.NewAuthController(authService)
+
+	// Initialize realtime backend (memory atau redis untuk banyak instance)
+	realtimeBackend, err := services.NewRealtimeBackendFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize realtime backend: %v", err)
+	}
 
 	//services
-	pdfService := services.NewPDFService()
+	webhookService := services.NewWebhookService(webhookRepo)
+	chatIntegrationService := services.NewChatIntegrationService(chatIntegrationRepo, chatService)
+	pdfService := services.NewPDFService(workspaceBrandingRepo)
 	attendanceImageService := services.NewAttendanceImageService(attendanceImageRepo)
-	attendanceService := services.NewAttendanceService(*attendanceRepo, *attendanceImageRepo, userRepo, workspaceRepo)
-	projectService := services.NewProjectService(projectRepo, userRepo, workspaceRepo, taskRepo, taskStatusLog, pdfService, activityLogger) // Tambahkan userRepo dan pdfService
-	taskImageService := services.NewTaskImageService(taskImageRepo, taskRepo, projectRepo, workspaceRepo)
+	webSocketService := services.NewWebSocketService(realtimeBackend, userRepo, workspaceRepo, projectRepo, taskRepo)
+	attendanceService := services.NewAttendanceService(*attendanceRepo, *attendanceImageRepo, userRepo, workspaceRepo, webhookService)
+	projectService := services.NewProjectService(projectRepo, userRepo, workspaceRepo, taskRepo, taskStatusLog, pdfService, activityLogger, webhookService, webSocketService) // Tambahkan userRepo dan pdfService
+	taskImageService := services.NewTaskImageService(taskImageRepo, taskRepo, projectRepo, workspaceRepo, webSocketService)
 	taskFileService := services.NewTaskFileService(taskFileRepo, taskRepo, projectRepo)
-	taskService := services.NewTaskService(taskRepo, userRepo, taskStatusLog, activityLogger, telegramService)
-	projectImageService := services.NewProjectImageService(projectImageRepo, projectRepo, workspaceRepo, userRepo)
-	workspaceService := services.NewWorkspaceService(workspaceRepo, projectRepo, taskRepo)
+	notificationDispatcher := services.NewNotificationDispatcher(notificationRepo, notificationOutboxRepo, webSocketService, telegramService, mailer)
+	notificationService := services.NewNotificationService(notificationRepo, projectRepo, userRepo, notificationDispatcher)
+	notificationOutboxService := services.NewNotificationOutboxService(notificationOutboxRepo)
+	digestService := services.NewDigestService(taskRepo, attendanceRepo, workspaceRepo, userRepo, notificationDispatcher)
+	deadlineScheduler := services.NewDeadlineScheduler(taskRepo, projectRepo, notificationService, chatIntegrationService)
+	taskService := services.NewTaskService(taskRepo, userRepo, taskStatusLog, activityLogger, notificationService, webhookService, chatIntegrationService, webSocketService)
+	projectImageService := services.NewProjectImageService(projectImageRepo, projectRepo, workspaceRepo, userRepo, webSocketService)
+	workspaceService := services.NewWorkspaceService(workspaceRepo, projectRepo, taskRepo, mailer, webSocketService)
 	userService := services.NewUserService(userRepo)
-	webSocketService := services.NewWebSocketService(userRepo, workspaceRepo, projectRepo, taskRepo)
 	dashboardService := services.NewDashboardService(taskRepo)
 	profileService := services.NewProfileService(userRepo)
+	reportJobService := services.NewReportJobService(reportJobRepo, projectRepo, projectService, webSocketService, notificationService)
+	workspaceBrandingService := services.NewWorkspaceBrandingService(workspaceBrandingRepo, workspaceRepo)
+	telegramBotService := services.NewTelegramBotService(telegramLinkCodeRepo, userRepo, taskRepo, workspaceRepo, taskService, attendanceService, telegramService)
+	reportScheduleService := services.NewReportScheduleService(reportScheduleRepo, projectRepo, workspaceRepo, userRepo, projectService, attendanceService, pdfService, telegramService, mailer)
 
 	//controllers
 	attendanceController := controllers.NewAttendanceController(*attendanceService, *attendanceImageService, pdfService, workspaceService)
@@ -60,32 +97,73 @@ func SetupRoutes(r *gin.Engine) {
 	projectController := controllers.NewProjectController(projectService)
 	projectImageController := controllers.NewProjectImageController(projectImageService)
 	workspaceController := controllers.NewWorkspaceController(workspaceService)
+	workspaceBrandingController := controllers.NewWorkspaceBrandingController(workspaceBrandingService)
 	userController := controllers.NewUserController(userService)
 	webSocketController := controllers.NewWebSocketController(authService, webSocketService, userService)
 	dashboardController := controllers.NewDashboardController(dashboardService)
 	profileController := controllers.NewProfileController(profileService)
 	exportController := controllers.NewExportController(projectService)
-
-	authMiddleware := middleware.AuthMiddleware(authService)
+	reportJobController := controllers.NewReportJobController(reportJobService)
+	reportScheduleController := controllers.NewReportScheduleController(reportScheduleService)
+	notificationController := controllers.NewNotificationController(notificationService)
+	notificationOutboxController := controllers.NewNotificationOutboxController(notificationOutboxService)
+	webhookController := controllers.NewWebhookController(webhookService)
+	chatIntegrationController := controllers.NewChatIntegrationController(chatIntegrationService)
+	telegramController := controllers.NewTelegramController(telegramBotService)
+	apiTokenService := services.NewAPITokenService(apiTokenRepo, workspaceRepo)
+	apiTokenController := controllers.NewAPITokenController(apiTokenService)
+
+	authMiddleware := middleware.AuthMiddleware(authService, apiTokenService)
 	adminMiddleware := middleware.AdminMiddleware()
+	adminTwoFactorMiddleware := middleware.AdminTwoFactorMiddleware(workspaceService)
+	scheduleTwoFactorMiddleware := middleware.ReportScheduleTwoFactorMiddleware(reportScheduleService, workspaceService)
+	sessionOnlyMiddleware := middleware.SessionOnlyMiddleware()
 
 	// Jalankan WebSocket Hub
 	go webSocketService.RunHub()
 
+	// Jalankan worker report
+	reportJobService.Start()
+	reportScheduleService.Start()
+	deadlineScheduler.Start()
+	digestService.Start()
+	notificationDispatcher.Start()
+	webhookService.Start()
+
 	//public routes
 	auth := r.Group("/auth")
 	{
 		auth.POST("/register", authController.Register)
 		auth.POST("/login", authController.Login)
+		auth.POST("/login/2fa", authController.LoginTwoFactor)
+		auth.POST("/refresh", authController.Refresh)
+		auth.POST("/forgot-password", authController.ForgotPassword)
+		auth.POST("/reset-password", authController.ResetPassword)
+		auth.POST("/change-password", authMiddleware, sessionOnlyMiddleware, authController.ChangePassword)
 		auth.GET("/profile", authMiddleware, authController.GetProfile) // Ini butuh auth
+		auth.POST("/logout", authMiddleware, sessionOnlyMiddleware, authController.Logout)
+		auth.POST("/logout-all", authMiddleware, sessionOnlyMiddleware, authController.LogoutAll)
+		auth.GET("/sessions", authMiddleware, sessionOnlyMiddleware, authController.ListSessions)
+		auth.DELETE("/sessions/:session_id", authMiddleware, sessionOnlyMiddleware, authController.RevokeSession)
+		auth.GET("/2fa", authMiddleware, sessionOnlyMiddleware, authController.TwoFactorStatus)
+		auth.POST("/2fa/setup", authMiddleware, sessionOnlyMiddleware, authController.SetupTwoFactor)
+		auth.POST("/2fa/enable", authMiddleware, sessionOnlyMiddleware, authController.EnableTwoFactor)
+		auth.POST("/2fa/disable", authMiddleware, sessionOnlyMiddleware, authController.DisableTwoFactor)
+		auth.POST("/2fa/recovery-codes", authMiddleware, sessionOnlyMiddleware, authController.RegenerateRecoveryCodes)
 	}
 
+	// Telegram bot webhook
+	r.POST("/telegram/webhook", telegramController.Webhook)
+
 	// web socket
 	ws := r.Group("/ws")
 	{
 		ws.GET("", webSocketController.ServeWs)
 	}
 
+	// Server-Sent Events, alternatif jika upgrade WebSocket diblokir
+	r.GET("/sse", webSocketController.ServeSSE)
+
 	//Protected Routes
 	api := r.Group("/api")
 	api.Use(authMiddleware)
@@ -94,11 +172,38 @@ func SetupRoutes(r *gin.Engine) {
 		api.GET("/profile", profileController.ListProfile)
 		api.PUT("/profile", profileController.UpdateProfile)
 		api.DELETE("/profile/image", profileController.DeleteProfileImage)
+		api.POST("/profile/telegram/link-code", telegramController.CreateLinkCode)
+		api.DELETE("/profile/telegram", telegramController.Unlink)
+
+		// Personal access token untuk script dan CI
+		tokens := api.Group("/tokens", sessionOnlyMiddleware)
+		{
+			tokens.GET("", apiTokenController.ListPersonalTokens)
+			tokens.POST("", apiTokenController.CreatePersonalToken)
+			tokens.DELETE("/:token_id", apiTokenController.RevokePersonalToken)
+		}
 
 		// Dashboard
 		api.GET("/dashboard", dashboardController.GetUserDashboard)
 		api.GET("/dashboard/admin", dashboardController.GetAdminDashboard)
 
+		// Notifications
+		notifications := api.Group("/notifications")
+		{
+			notifications.GET("", notificationController.ListNotifications)
+			notifications.GET("/unread-count", notificationController.UnreadCount)
+			notifications.POST("/read-all", notificationController.MarkAllRead)
+			notifications.PATCH("/:notification_id/read", notificationController.MarkRead)
+		}
+
+		// Antrean pengiriman notifikasi (dead letter)
+		outbox := api.Group("/admin/notification-outbox", adminMiddleware)
+		{
+			outbox.GET("", notificationOutboxController.ListOutbox)
+			outbox.POST("/retry-dead", notificationOutboxController.RetryAllDead)
+			outbox.POST("/:outbox_id/retry", notificationOutboxController.Retry)
+		}
+
 		// Online Users
 		api.GET("/online-users", adminMiddleware, userController.GetOnlineUsers)
 
@@ -111,22 +216,60 @@ func SetupRoutes(r *gin.Engine) {
 			workspace := workspaces.Group("/:workspace_id")
 			{
 				workspace.GET("", workspaceController.DetailWorkspace)
-				workspace.PUT("", adminMiddleware, workspaceController.UpdateWorkspace)
-				workspace.DELETE("", adminMiddleware, workspaceController.SoftDeleteWorkspace)
-				workspace.DELETE("/permanent", adminMiddleware, workspaceController.DeleteWorkspace)
+				workspace.PUT("", adminMiddleware, adminTwoFactorMiddleware, workspaceController.UpdateWorkspace)
+				workspace.DELETE("", adminMiddleware, adminTwoFactorMiddleware, workspaceController.SoftDeleteWorkspace)
+				workspace.DELETE("/permanent", adminMiddleware, adminTwoFactorMiddleware, workspaceController.DeleteWorkspace)
+				workspace.PUT("/two-factor", adminMiddleware, adminTwoFactorMiddleware, sessionOnlyMiddleware, workspaceController.UpdateTwoFactorPolicy)
 
-				workspace.GET("/members", adminMiddleware, workspaceController.GetMembers)
-				workspace.POST("/members", adminMiddleware, workspaceController.AddMembers)
-				workspace.DELETE("/members/:user_id", adminMiddleware, workspaceController.RemoveSingleMember)
-				workspace.DELETE("/members/", adminMiddleware, workspaceController.RemoveMember)
+				// Service key workspace
+				serviceKeys := workspace.Group("/service-keys", adminMiddleware, adminTwoFactorMiddleware, sessionOnlyMiddleware)
+				{
+					serviceKeys.GET("", apiTokenController.ListServiceKeys)
+					serviceKeys.POST("", apiTokenController.CreateServiceKey)
+					serviceKeys.DELETE("/:token_id", apiTokenController.RevokeServiceKey)
+				}
+
+				workspace.GET("/members", adminMiddleware, adminTwoFactorMiddleware, workspaceController.GetMembers)
+				workspace.POST("/members", adminMiddleware, adminTwoFactorMiddleware, workspaceController.AddMembers)
+				workspace.DELETE("/members/:user_id", adminMiddleware, adminTwoFactorMiddleware, workspaceController.RemoveSingleMember)
+				workspace.DELETE("/members/", adminMiddleware, adminTwoFactorMiddleware, workspaceController.RemoveMember)
 
 				workspace.GET("/online-members", userController.GetOnlineWorkspaceMembers)
 
+				// Branding laporan PDF
+				workspace.GET("/branding", workspaceBrandingController.GetBranding)
+				workspace.PUT("/branding", adminMiddleware, adminTwoFactorMiddleware, workspaceBrandingController.UpdateBranding)
+				workspace.POST("/branding/logo", adminMiddleware, adminTwoFactorMiddleware, workspaceBrandingController.UploadLogo)
+				workspace.DELETE("/branding/logo", adminMiddleware, adminTwoFactorMiddleware, workspaceBrandingController.DeleteLogo)
+				workspace.GET("/branding/preview", workspaceBrandingController.PreviewBranding)
+
+				// Outgoing webhook
+				webhooks := workspace.Group("/webhooks", adminMiddleware, adminTwoFactorMiddleware)
+				{
+					webhooks.GET("", webhookController.ListWebhooks)
+					webhooks.POST("", webhookController.CreateWebhook)
+					webhooks.PUT("/:webhook_id", webhookController.UpdateWebhook)
+					webhooks.DELETE("/:webhook_id", webhookController.DeleteWebhook)
+					webhooks.GET("/:webhook_id/deliveries", webhookController.ListDeliveries)
+					webhooks.POST("/:webhook_id/deliveries/:delivery_id/redeliver", webhookController.Redeliver)
+					webhooks.POST("/:webhook_id/test", webhookController.SendTest)
+				}
+
+				// Integrasi chat (Slack/Discord/Mattermost)
+				chatIntegrations := workspace.Group("/chat-integrations", adminMiddleware, adminTwoFactorMiddleware)
+				{
+					chatIntegrations.GET("", chatIntegrationController.ListIntegrations)
+					chatIntegrations.POST("", chatIntegrationController.CreateIntegration)
+					chatIntegrations.PUT("/:integration_id", chatIntegrationController.UpdateIntegration)
+					chatIntegrations.DELETE("/:integration_id", chatIntegrationController.DeleteIntegration)
+					chatIntegrations.POST("/:integration_id/test", chatIntegrationController.SendTest)
+				}
+
 				// Attendance
 				attendances := workspace.Group("/attendances")
 				{
 					attendances.POST("", attendanceController.SubmitAttendance)
-					attendances.GET("/export", adminMiddleware, attendanceController.ExportAttendances)
+					attendances.GET("/export", adminMiddleware, adminTwoFactorMiddleware, attendanceController.ExportAttendances)
 				}
 			}
 		}
@@ -142,54 +285,83 @@ func SetupRoutes(r *gin.Engine) {
 		projects := api.Group("/projects")
 		{
 			projects.GET("", projectController.ListProjects)
-			projects.POST("", adminMiddleware, projectController.CreateProject)
+			projects.POST("", adminMiddleware, adminTwoFactorMiddleware, projectController.CreateProject)
 			exportGroup := projects.Group("/:project_id/export")
 			{
 				// New specific routes
-				exportGroup.GET("/daily", adminMiddleware, exportController.ExportDaily)
-				exportGroup.GET("/weekly-backward", adminMiddleware, exportController.ExportWeeklyBackward)
-				exportGroup.GET("/weekly-forward", adminMiddleware, exportController.ExportWeeklyForward)
-				exportGroup.GET("/monitoring", adminMiddleware, exportController.ExportMonitoring)
+				exportGroup.GET("/daily", adminMiddleware, adminTwoFactorMiddleware, exportController.ExportDaily)
+				exportGroup.GET("/weekly-backward", adminMiddleware, adminTwoFactorMiddleware, exportController.ExportWeeklyBackward)
+				exportGroup.GET("/weekly-forward", adminMiddleware, adminTwoFactorMiddleware, exportController.ExportWeeklyForward)
+				exportGroup.GET("/monitoring", adminMiddleware, adminTwoFactorMiddleware, exportController.ExportMonitoring)
+			}
+			// Data JSON yang sama dengan isi export PDF
+			reportDataGroup := projects.Group("/:project_id/report-data")
+			{
+				reportDataGroup.GET("/daily", adminMiddleware, adminTwoFactorMiddleware, exportController.DailyData)
+				reportDataGroup.GET("/weekly-backward", adminMiddleware, adminTwoFactorMiddleware, exportController.WeeklyBackwardData)
+				reportDataGroup.GET("/weekly-forward", adminMiddleware, adminTwoFactorMiddleware, exportController.WeeklyForwardData)
+				reportDataGroup.GET("/monitoring", adminMiddleware, adminTwoFactorMiddleware, exportController.MonitoringData)
 			}
+			projects.POST("/:project_id/reports", adminMiddleware, adminTwoFactorMiddleware, reportJobController.CreateJob)
 
 			project := projects.Group("/:project_id")
 			{
 				project.GET("", projectController.DetailProject)
-				project.PUT("", adminMiddleware, projectController.UpdateProject)
-				project.DELETE("", adminMiddleware, projectController.SoftDeleteProject)
-				project.DELETE("/permanent", adminMiddleware, projectController.DeleteProject)
+				project.PUT("", adminMiddleware, adminTwoFactorMiddleware, projectController.UpdateProject)
+				project.DELETE("", adminMiddleware, adminTwoFactorMiddleware, projectController.SoftDeleteProject)
+				project.DELETE("/permanent", adminMiddleware, adminTwoFactorMiddleware, projectController.DeleteProject)
 
 				project.GET("/members", projectController.GetMembers)
-				project.POST("/members", adminMiddleware, projectController.AddMember)
-				project.DELETE("/members/:user_id", adminMiddleware, projectController.RemoveSingleMember)
-				project.DELETE("/members", adminMiddleware, projectController.RemoveMember)
+				project.POST("/members", adminMiddleware, adminTwoFactorMiddleware, projectController.AddMember)
+				project.DELETE("/members/:user_id", adminMiddleware, adminTwoFactorMiddleware, projectController.RemoveSingleMember)
+				project.DELETE("/members", adminMiddleware, adminTwoFactorMiddleware, projectController.RemoveMember)
 
 				// Project Images
 				images := project.Group("/images")
 				{
 					images.GET("", projectImageController.GetProjectImages)
-					images.POST("", adminMiddleware, projectImageController.UploadProjectImage)
-					images.DELETE("/:image_id", adminMiddleware, projectImageController.DeleteProjectImage)
+					images.POST("", adminMiddleware, adminTwoFactorMiddleware, projectImageController.UploadProjectImage)
+					images.DELETE("/:image_id", adminMiddleware, adminTwoFactorMiddleware, projectImageController.DeleteProjectImage)
 				}
 			}
 		}
 
+		// Report Jobs
+		reports := api.Group("/reports")
+		{
+			reports.GET("", reportJobController.ListJobs)
+			reports.GET("/:job_id", reportJobController.GetJob)
+			reports.GET("/:job_id/download", reportJobController.Download)
+		}
+
+		// Report Schedules: 2FA dicek untuk workspace schedule lama dan
+		// workspace tujuan di body
+		schedules := api.Group("/report-schedules", adminMiddleware, adminTwoFactorMiddleware, scheduleTwoFactorMiddleware)
+		{
+			schedules.GET("", reportScheduleController.ListSchedules)
+			schedules.POST("", reportScheduleController.CreateSchedule)
+			schedules.GET("/:schedule_id", reportScheduleController.DetailSchedule)
+			schedules.PUT("/:schedule_id", reportScheduleController.UpdateSchedule)
+			schedules.DELETE("/:schedule_id", reportScheduleController.DeleteSchedule)
+			schedules.POST("/:schedule_id/run", reportScheduleController.RunSchedule)
+		}
+
 		// Task
 		tasks := api.Group("/workspaces/:workspace_id/projects/:project_id/tasks")
 		{
 			tasks.GET("", taskController.ListTasks)
-			tasks.POST("", adminMiddleware, taskController.CreateTask)
+			tasks.POST("", adminMiddleware, adminTwoFactorMiddleware, taskController.CreateTask)
 
 			task := tasks.Group("/:task_id")
 			{
 				task.GET("", taskController.DetailTask)
 				task.PUT("", taskController.UpdateTask)
-				task.DELETE("", adminMiddleware, taskController.SoftDeleteTask)
-				task.DELETE("/permanent", adminMiddleware, taskController.DeleteTask)
+				task.DELETE("", adminMiddleware, adminTwoFactorMiddleware, taskController.SoftDeleteTask)
+				task.DELETE("/permanent", adminMiddleware, adminTwoFactorMiddleware, taskController.DeleteTask)
 
 				task.GET("/members", taskController.GetMembers)
-				task.POST("/members", adminMiddleware, taskController.AddMember)
-				task.DELETE("/members/:user_id", adminMiddleware, taskController.DeleteMember)
+				task.POST("/members", adminMiddleware, adminTwoFactorMiddleware, taskController.AddMember)
+				task.DELETE("/members/:user_id", adminMiddleware, adminTwoFactorMiddleware, taskController.DeleteMember)
 
 				// Task Images
 				images := task.Group("/images")
@@ -203,11 +375,11 @@ func SetupRoutes(r *gin.Engine) {
 				// Task Files
 				files := task.Group("/files")
 				{
-					files.GET("", adminMiddleware, taskFileController.ListFiles)
-					files.POST("", adminMiddleware, taskFileController.UploadFile)
-					files.GET("/:fileId/view", adminMiddleware, taskFileController.ViewFile)
-					files.GET("/:fileId/download", adminMiddleware, taskFileController.DownloadFile)
-					files.DELETE("/:fileId", adminMiddleware, taskFileController.DeleteFile)
+					files.GET("", adminMiddleware, adminTwoFactorMiddleware, taskFileController.ListFiles)
+					files.POST("", adminMiddleware, adminTwoFactorMiddleware, taskFileController.UploadFile)
+					files.GET("/:fileId/view", adminMiddleware, adminTwoFactorMiddleware, taskFileController.ViewFile)
+					files.GET("/:fileId/download", adminMiddleware, adminTwoFactorMiddleware, taskFileController.DownloadFile)
+					files.DELETE("/:fileId", adminMiddleware, adminTwoFactorMiddleware, taskFileController.DeleteFile)
 				}
 			}
 		}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"log"
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"project-management-backend/repositories"
+	"strings"
+	"time"
+)
+
+const (
+	personalTokenPrefix = "pmp_"
+	serviceKeyPrefix    = "pms_"
+
+	defaultAPITokenExpiryDays = 90
+	maxAPITokenExpiryDays     = 365
+
+	// last_used_at cukup diperbarui sekali per menit
+	apiTokenTouchInterval = time.Minute
+)
+
+// CreateAPITokenInput is the request to create a personal token or service
+// key. ExpiresInDays defaults to 90.
+type CreateAPITokenInput struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Scopes        []string `json:"scopes" binding:"required"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+// CreatedAPIToken is returned once on creation. The plain token cannot be
+// retrieved again.
+type CreatedAPIToken struct {
+	Token    string           `json:"token"`
+	APIToken *models.APIToken `json:"api_token"`
+}
+
+type APITokenService interface {
+	IsAPIToken(token string) bool
+	Authenticate(token, ip string) (*models.User, *models.APIToken, error)
+	CreatePersonalToken(input CreateAPITokenInput, user *models.User) (*CreatedAPIToken, error)
+	ListPersonalTokens(user *models.User) ([]models.APIToken, error)
+	RevokePersonalToken(tokenID uint, user *models.User) error
+	CreateServiceKey(workspaceID uint, input CreateAPITokenInput, user *models.User) (*CreatedAPIToken, error)
+	ListServiceKeys(workspaceID uint, user *models.User) ([]models.APIToken, error)
+	RevokeServiceKey(workspaceID, tokenID uint, user *models.User) error
+}
+
+type apiTokenService struct {
+	repo          repositories.APITokenRepository
+	workspaceRepo repositories.WorkspaceRepository
+}
+
+func NewAPITokenService(repo repositories.APITokenRepository, workspaceRepo repositories.WorkspaceRepository) APITokenService {
+	return &apiTokenService{repo: repo, workspaceRepo: workspaceRepo}
+}
+
+// IsAPIToken reports whether a bearer token is an API token rather than a
+// JWT access token.
+func (s *apiTokenService) IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, personalTokenPrefix) || strings.HasPrefix(token, serviceKeyPrefix)
+}
+
+// Authenticate resolves an API token to the user it acts as and records
+// when and from where it was last used.
+func (s *apiTokenService) Authenticate(token, ip string) (*models.User, *models.APIToken, error) {
+	apiToken, err := s.repo.GetByHash(hashToken(token))
+	if err != nil {
+		return nil, nil, i18n.NewError(i18n.CodeAPITokenInvalid)
+	}
+
+	// Pemilik yang sudah dihapus tidak boleh terautentikasi sebagai user kosong
+	now := time.Now()
+	if apiToken.User.ID == 0 || !apiToken.IsActive(now) {
+		return nil, nil, i18n.NewError(i18n.CodeAPITokenInvalid)
+	}
+
+	if apiToken.LastUsedAt == nil || now.Sub(*apiToken.LastUsedAt) >= apiTokenTouchInterval || apiToken.LastUsedIP != ip {
+		if err := s.repo.TouchLastUsed(apiToken.ID, now, ip); err != nil {
+			log.Printf("[APIToken] Failed to record usage of token %d: %v", apiToken.ID, err)
+		}
+	}
+
+	user := apiToken.User
+	return &user, apiToken, nil
+}
+
+func (s *apiTokenService) CreatePersonalToken(input CreateAPITokenInput, user *models.User) (*CreatedAPIToken, error) {
+	return s.create(models.APITokenPersonal, nil, input, user)
+}
+
+func (s *apiTokenService) ListPersonalTokens(user *models.User) ([]models.APIToken, error) {
+	return s.repo.ListPersonalByUserID(user.ID)
+}
+
+func (s *apiTokenService) RevokePersonalToken(tokenID uint, user *models.User) error {
+	token, err := s.repo.GetByID(tokenID)
+	if err != nil || token.Kind != models.APITokenPersonal || token.UserID != user.ID {
+		return i18n.NewError(i18n.CodeAPITokenNotFound)
+	}
+	return s.repo.Revoke(tokenID)
+}
+
+func (s *apiTokenService) CreateServiceKey(workspaceID uint, input CreateAPITokenInput, user *models.User) (*CreatedAPIToken, error) {
+	if err := s.checkWorkspaceAdmin(workspaceID, user); err != nil {
+		return nil, err
+	}
+	return s.create(models.APITokenService, &workspaceID, input, user)
+}
+
+func (s *apiTokenService) ListServiceKeys(workspaceID uint, user *models.User) ([]models.APIToken, error) {
+	if err := s.checkWorkspaceAdmin(workspaceID, user); err != nil {
+		return nil, err
+	}
+	return s.repo.ListServiceByWorkspaceID(workspaceID)
+}
+
+func (s *apiTokenService) RevokeServiceKey(workspaceID, tokenID uint, user *models.User) error {
+	if err := s.checkWorkspaceAdmin(workspaceID, user); err != nil {
+		return err
+	}
+
+	token, err := s.repo.GetByID(tokenID)
+	if err != nil || token.Kind != models.APITokenService || token.WorkspaceID == nil || *token.WorkspaceID != workspaceID {
+		return i18n.NewError(i18n.CodeAPITokenNotFound)
+	}
+	return s.repo.Revoke(tokenID)
+}
+
+// checkWorkspaceAdmin allows service keys to be managed by the same users
+// who can update the workspace.
+func (s *apiTokenService) checkWorkspaceAdmin(workspaceID uint, user *models.User) error {
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return i18n.NewError(i18n.CodeWorkspaceNotFound)
+	}
+	if user.Role != "admin" && workspace.CreatedBy != user.ID {
+		return i18n.NewError(i18n.CodeWorkspaceCreatorOnlyUpdate)
+	}
+	return nil
+}
+
+func (s *apiTokenService) create(kind string, workspaceID *uint, input CreateAPITokenInput, user *models.User) (*CreatedAPIToken, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, i18n.NewError(i18n.CodeAPITokenNameRequired)
+	}
+
+	scopes, err := normalizeAPITokenScopes(input.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	days := input.ExpiresInDays
+	if days == 0 {
+		days = defaultAPITokenExpiryDays
+	}
+	if days < 1 || days > maxAPITokenExpiryDays {
+		return nil, i18n.NewError(i18n.CodeAPITokenExpiryInvalid, maxAPITokenExpiryDays)
+	}
+
+	prefix := personalTokenPrefix
+	if kind == models.APITokenService {
+		prefix = serviceKeyPrefix
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, i18n.NewError(i18n.CodeTokenGenerationFailed)
+	}
+	plain := prefix + base64.RawURLEncoding.EncodeToString(buf)
+
+	token := &models.APIToken{
+		Kind:        kind,
+		Name:        name,
+		Prefix:      plain[:len(prefix)+6],
+		TokenHash:   hashToken(plain),
+		Scopes:      scopes,
+		UserID:      user.ID,
+		WorkspaceID: workspaceID,
+		ExpiresAt:   time.Now().AddDate(0, 0, days),
+	}
+	if err := s.repo.Create(token); err != nil {
+		return nil, ErrSystemError
+	}
+
+	return &CreatedAPIToken{Token: plain, APIToken: token}, nil
+}
+
+func normalizeAPITokenScopes(scopes []string) ([]string, error) {
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if scope != models.APITokenScopeRead && scope != models.APITokenScopeWrite {
+			return nil, i18n.NewError(i18n.CodeAPITokenScopesInvalid)
+		}
+		if !containsString(normalized, scope) {
+			normalized = append(normalized, scope)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, i18n.NewError(i18n.CodeAPITokenScopesInvalid)
+	}
+	return normalized, nil
+}
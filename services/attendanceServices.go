@@ -2,30 +2,36 @@ package services
 
 import (
 	"errors"
-	"fmt"
+	"log"
 	"time"
 
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/repositories"
 
 	"github.com/go-sql-driver/mysql"
 )
 
-var ErrAttendanceAlreadyExists = errors.New("attendance for this day already submitted")
+var (
+	ErrAttendanceAlreadyExists = i18n.NewError(i18n.CodeAttendanceAlreadySubmitted)
+	ErrAttendanceDateInvalid   = i18n.NewError(i18n.CodeDateFormatInvalid)
+)
 
 type AttendanceService struct {
 	repo          repositories.AttendanceRepository
 	imageRepo     repositories.AttendanceImageRepository
 	userRepo      repositories.UserRepository
 	workspaceRepo repositories.WorkspaceRepository
+	webhooks      WebhookService
 }
 
-func NewAttendanceService(repo repositories.AttendanceRepository, imageRepo repositories.AttendanceImageRepository, userRepo repositories.UserRepository, workspaceRepo repositories.WorkspaceRepository) *AttendanceService {
+func NewAttendanceService(repo repositories.AttendanceRepository, imageRepo repositories.AttendanceImageRepository, userRepo repositories.UserRepository, workspaceRepo repositories.WorkspaceRepository, webhooks WebhookService) *AttendanceService {
 	return &AttendanceService{
 		repo:          repo,
 		imageRepo:     imageRepo,
 		userRepo:      userRepo,
 		workspaceRepo: workspaceRepo,
+		webhooks:      webhooks,
 	}
 }
 
@@ -36,10 +42,11 @@ func (s *AttendanceService) GetAttendanceByID(attendanceID uint) (*models.Attend
 func (s *AttendanceService) SubmitAttendance(attendance *models.Attendance) error {
 	hasAccess, err := s.workspaceRepo.IsUserMember(attendance.WorkspaceID, attendance.UserID)
 	if err != nil {
-		return fmt.Errorf("tidak dapat memeriksa apakah pengguna memiliki akses ke workspace: %w", err)
+		log.Printf("[Attendance] Failed to check workspace access of user %d: %v", attendance.UserID, err)
+		return i18n.NewError(i18n.CodeWorkspaceAccessCheckFailed)
 	}
 	if !hasAccess {
-		return errors.New("user bukan anggota workspace")
+		return i18n.NewError(i18n.CodeUserNotWorkspaceMember)
 	}
 
 	err = s.repo.Create(attendance)
@@ -50,13 +57,24 @@ func (s *AttendanceService) SubmitAttendance(attendance *models.Attendance) erro
 		}
 		return err
 	}
+
+	s.webhooks.Publish(attendance.WorkspaceID, models.WebhookEventAttendanceSubmitted, map[string]interface{}{
+		"attendance": map[string]interface{}{
+			"id":        attendance.ID,
+			"user_id":   attendance.UserID,
+			"activity":  attendance.Activity,
+			"obstacle":  attendance.Obstacle,
+			"clock_in":  attendance.ClockIn,
+			"clock_out": attendance.ClockOut,
+		},
+	})
 	return nil
 }
 
 func (s *AttendanceService) GetAttendancesForExport(workspaceID uint, date string) ([]models.AttendanceExportResponse, error) {
 	parsedDate, err := time.Parse("2006-01-02", date)
 	if err != nil {
-		return nil, fmt.Errorf("invalid date format: %w", err)
+		return nil, ErrAttendanceDateInvalid
 	}
 	startOfDay := time.Date(parsedDate.Year(), parsedDate.Month(), parsedDate.Day(), 0, 0, 0, 0, parsedDate.Location())
 	endOfDay := startOfDay.Add(24 * time.Hour)
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/repositories"
 )
@@ -28,12 +29,12 @@ func NewAttendanceImageService(repo *repositories.AttendanceImageRepository) *At
 
 func (s *AttendanceImageService) UploadImage(attendanceID uint, file *multipart.FileHeader) (*models.AttendanceImage, error) {
 	if file.Size > maxSize {
-		return nil, fmt.Errorf("file size exceeds the limit of 2MB")
+		return nil, i18n.NewError(i18n.CodeFileTooLarge, "2MB")
 	}
 
 	ext := strings.ToLower(filepath.Ext(file.Filename))
 	if ext != ".jpg" && ext != ".jpeg" && ext != ".png" {
-		return nil, fmt.Errorf("unsupported file type: %s", ext)
+		return nil, i18n.NewError(i18n.CodeFileTypeNotAllowed)
 	}
 
 	fileName := fmt.Sprintf("%d_%d%s", attendanceID, time.Now().UnixNano(), ext)
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"log"
+	"os"
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"strings"
+	"time"
+)
+
+const defaultPasswordResetTTL = 30 * time.Minute
+
+func passwordResetTTL() time.Duration {
+	if ttl, err := time.ParseDuration(os.Getenv("PASSWORD_RESET_TTL")); err == nil && ttl > 0 {
+		return ttl
+	}
+	return defaultPasswordResetTTL
+}
+
+// ChangePassword sets a new password after checking the current one. Other
+// sessions and all API tokens of the user are revoked; the session making
+// the change stays logged in.
+func (s *authService) ChangePassword(user *models.User, sessionID uint, currentPassword, newPassword string) error {
+	if !s.CheckPassword(currentPassword, user.Password) {
+		return i18n.NewError(i18n.CodeCurrentPasswordWrong)
+	}
+	if currentPassword == newPassword {
+		return i18n.NewError(i18n.CodePasswordUnchanged)
+	}
+
+	if err := s.updatePassword(user.ID, newPassword); err != nil {
+		return err
+	}
+
+	if err := s.sessionRepo.RevokeOthersByUserID(user.ID, sessionID, models.SessionRevokedPasswordChange); err != nil {
+		log.Printf("[Auth] Failed to revoke sessions of user %d after password change: %v", user.ID, err)
+	}
+	if err := s.apiTokenRepo.RevokeAllByUserID(user.ID); err != nil {
+		log.Printf("[Auth] Failed to revoke API tokens of user %d after password change: %v", user.ID, err)
+	}
+	return nil
+}
+
+// ForgotPassword issues a reset token for the account with email and hands
+// it to the reset sender. Unknown emails are ignored silently so the
+// endpoint cannot be used to find out which accounts exist.
+func (s *authService) ForgotPassword(email, requestedIP string) error {
+	user, err := s.userRepo.GetByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return nil
+	}
+
+	token, tokenHash, err := newRefreshToken()
+	if err != nil {
+		return i18n.NewError(i18n.CodeTokenGenerationFailed)
+	}
+
+	// Hanya token terakhir yang berlaku
+	if err := s.resetRepo.DeleteUnusedByUserID(user.ID); err != nil {
+		return ErrSystemError
+	}
+
+	ttl := passwordResetTTL()
+	resetToken := &models.PasswordResetToken{
+		UserID:      user.ID,
+		TokenHash:   tokenHash,
+		ExpiresAt:   time.Now().Add(ttl),
+		RequestedIP: requestedIP,
+	}
+	if err := s.resetRepo.Create(resetToken); err != nil {
+		return ErrSystemError
+	}
+
+	go func() {
+		if err := s.resetSender.SendPasswordReset(user, token, ttl); err != nil {
+			log.Printf("[Auth] Failed to send password reset to user %d: %v", user.ID, err)
+		}
+	}()
+	return nil
+}
+
+// ResetPassword sets a new password using a reset token and revokes every
+// session and API token of the user.
+func (s *authService) ResetPassword(token, newPassword string) error {
+	now := time.Now()
+	resetToken, err := s.resetRepo.GetValid(hashToken(token), now)
+	if err != nil {
+		return i18n.NewError(i18n.CodeResetTokenInvalid)
+	}
+
+	used, err := s.resetRepo.MarkUsed(resetToken.ID, now)
+	if err != nil {
+		return ErrSystemError
+	}
+	if !used {
+		return i18n.NewError(i18n.CodeResetTokenInvalid)
+	}
+
+	if err := s.updatePassword(resetToken.UserID, newPassword); err != nil {
+		return err
+	}
+
+	if err := s.sessionRepo.RevokeAllByUserID(resetToken.UserID, models.SessionRevokedPasswordReset); err != nil {
+		log.Printf("[Auth] Failed to revoke sessions of user %d after password reset: %v", resetToken.UserID, err)
+	}
+	if err := s.apiTokenRepo.RevokeAllByUserID(resetToken.UserID); err != nil {
+		log.Printf("[Auth] Failed to revoke API tokens of user %d after password reset: %v", resetToken.UserID, err)
+	}
+	return nil
+}
+
+func (s *authService) updatePassword(userID uint, password string) error {
+	hashedPassword, err := s.HashPassword(password)
+	if err != nil {
+		return ErrHashPassword
+	}
+	if err := s.userRepo.UpdateFields(userID, map[string]interface{}{"password": hashedPassword}); err != nil {
+		return ErrSystemError
+	}
+	return nil
+}
@@ -1,11 +1,17 @@
 package services
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"os"
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/repositories"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -15,32 +21,104 @@ import (
 
 type AuthService interface {
 	Register(user *models.User) error
-	Login(email, password string) (string, *models.User, error)
+	Login(email, password string, client SessionClient) (*LoginResult, error)
+	LoginTwoFactor(challengeToken, code string, client SessionClient) (*AuthTokens, *models.User, error)
+	Refresh(refreshToken string) (*AuthTokens, error)
+	Logout(sessionID uint) error
+	LogoutAll(userID uint) error
+	ListSessions(userID uint) ([]models.UserSession, error)
+	RevokeSession(userID, sessionID uint) error
+	ChangePassword(user *models.User, sessionID uint, currentPassword, newPassword string) error
+	ForgotPassword(email, requestedIP string) error
+	ResetPassword(token, newPassword string) error
+	SetupTwoFactor(user *models.User) (*TwoFactorSetup, error)
+	EnableTwoFactor(user *models.User, code string) ([]string, error)
+	DisableTwoFactor(user *models.User, password, code string) error
+	RegenerateRecoveryCodes(user *models.User, code string) ([]string, error)
+	RemainingRecoveryCodes(userID uint) (int64, error)
 	ValidateToken(tokenString string) (*jwt.Token, error)
+	Authenticate(tokenString string) (*models.User, uint, error)
 	GetUserFromToken(tokenString string) (*models.User, error)
 	HashPassword(password string) (string, error)
 	CheckPassword(password, hash string) bool
 }
 
+const (
+	defaultAccessTokenTTL  = time.Hour
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
 type authService struct {
-	userRepo repositories.UserRepository
+	userRepo        repositories.UserRepository
+	sessionRepo     repositories.SessionRepository
+	resetRepo       repositories.PasswordResetRepository
+	resetSender     PasswordResetSender
+	twoFactorRepo   repositories.TwoFactorRepository
+	apiTokenRepo    repositories.APITokenRepository
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+
+	twoFactorMu       sync.Mutex
+	twoFactorFailures map[uint]twoFactorFailure
 }
 
-func NewAuthService(userRepo repositories.UserRepository) AuthService {
-	return &authService{userRepo: userRepo}
+func NewAuthService(
+	userRepo repositories.UserRepository,
+	sessionRepo repositories.SessionRepository,
+	resetRepo repositories.PasswordResetRepository,
+	resetSender PasswordResetSender,
+	twoFactorRepo repositories.TwoFactorRepository,
+	apiTokenRepo repositories.APITokenRepository,
+) AuthService {
+	accessTokenTTL := defaultAccessTokenTTL
+	if ttl, err := time.ParseDuration(os.Getenv("ACCESS_TOKEN_TTL")); err == nil && ttl > 0 {
+		accessTokenTTL = ttl
+	}
+	refreshTokenTTL := defaultRefreshTokenTTL
+	if ttl, err := time.ParseDuration(os.Getenv("REFRESH_TOKEN_TTL")); err == nil && ttl > 0 {
+		refreshTokenTTL = ttl
+	}
+
+	return &authService{
+		userRepo:          userRepo,
+		sessionRepo:       sessionRepo,
+		resetRepo:         resetRepo,
+		resetSender:       resetSender,
+		twoFactorRepo:     twoFactorRepo,
+		apiTokenRepo:      apiTokenRepo,
+		accessTokenTTL:    accessTokenTTL,
+		refreshTokenTTL:   refreshTokenTTL,
+		twoFactorFailures: make(map[uint]twoFactorFailure),
+	}
 }
 
 type Claims struct {
-	UserID uint   `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID    uint   `json:"user_id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	SessionID uint   `json:"sid"`
 	jwt.RegisteredClaims
 }
 
+// SessionClient describes the device a session is created from.
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
+}
+
+// AuthTokens is the token pair returned by Login and Refresh. The refresh
+// token can be used once; every refresh returns a new one.
+type AuthTokens struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"` // umur access token dalam detik
+	SessionID    uint   `json:"session_id"`
+}
+
 var (
-	ErrEmailExists  = errors.New("email sudah terdaftar")
-	ErrHashPassword = errors.New("gagal mengenkripsi password")
-	ErrSystemError  = errors.New("terjadi kesalahan sistem")
+	ErrEmailExists  = i18n.NewError(i18n.CodeEmailAlreadyRegistered)
+	ErrHashPassword = i18n.NewError(i18n.CodePasswordHashFailed)
+	ErrSystemError  = i18n.NewError(i18n.CodeInternalError)
 )
 
 func (s *authService) Register(user *models.User) error {
@@ -73,31 +151,116 @@ func (s *authService) Register(user *models.User) error {
 	return nil
 }
 
-func (s *authService) Login(email, password string) (string, *models.User, error) {
-	user, err := s.userRepo.GetByEmail(email)
+// createSession starts a new session for user and issues its first token
+// pair.
+func (s *authService) createSession(user *models.User, client SessionClient) (*AuthTokens, error) {
+	refreshToken, refreshHash, err := newRefreshToken()
 	if err != nil {
-		return "", nil, errors.New("email atau password salah")
+		return nil, err
 	}
 
-	if !s.CheckPassword(password, user.Password) {
-		return "", nil, errors.New("email atau password salah")
+	now := time.Now()
+	session := &models.UserSession{
+		UserID:     user.ID,
+		UserAgent:  truncateRunes(client.UserAgent, 512),
+		IPAddress:  client.IPAddress,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(s.refreshTokenTTL),
+	}
+	token := &models.RefreshToken{TokenHash: refreshHash, ExpiresAt: session.ExpiresAt}
+	if err := s.sessionRepo.Create(session, token); err != nil {
+		return nil, err
 	}
 
-	token, err := s.generateToken(user)
+	return s.issueTokens(user, session.ID, refreshToken)
+}
+
+// Refresh exchanges a refresh token for a new token pair. A refresh token
+// that was already used means it leaked, so its session is revoked.
+func (s *authService) Refresh(refreshToken string) (*AuthTokens, error) {
+	token, err := s.sessionRepo.GetRefreshToken(hashToken(refreshToken))
 	if err != nil {
-		return "", nil, errors.New("gagal generate token")
+		return nil, i18n.NewError(i18n.CodeRefreshTokenInvalid)
 	}
 
-	return token, user, nil
+	if token.UsedAt != nil {
+		s.sessionRepo.Revoke(token.SessionID, models.SessionRevokedTokenReuse)
+		return nil, i18n.NewError(i18n.CodeRefreshTokenReused)
+	}
+
+	now := time.Now()
+	if !token.Session.IsActive(now) || now.After(token.ExpiresAt) {
+		return nil, i18n.NewError(i18n.CodeRefreshTokenInvalid)
+	}
+
+	user, err := s.userRepo.GetByID(token.Session.UserID)
+	if err != nil {
+		return nil, i18n.NewError(i18n.CodeRefreshTokenInvalid)
+	}
+
+	nextToken, nextHash, err := newRefreshToken()
+	if err != nil {
+		return nil, i18n.NewError(i18n.CodeTokenGenerationFailed)
+	}
+	next := &models.RefreshToken{TokenHash: nextHash, ExpiresAt: now.Add(s.refreshTokenTTL)}
+
+	rotated, err := s.sessionRepo.RotateRefreshToken(token, next)
+	if err != nil {
+		return nil, i18n.NewError(i18n.CodeTokenGenerationFailed)
+	}
+	if !rotated {
+		s.sessionRepo.Revoke(token.SessionID, models.SessionRevokedTokenReuse)
+		return nil, i18n.NewError(i18n.CodeRefreshTokenReused)
+	}
+
+	tokens, err := s.issueTokens(user, token.SessionID, nextToken)
+	if err != nil {
+		return nil, i18n.NewError(i18n.CodeTokenGenerationFailed)
+	}
+	return tokens, nil
+}
+
+func (s *authService) Logout(sessionID uint) error {
+	return s.sessionRepo.Revoke(sessionID, models.SessionRevokedLogout)
+}
+
+func (s *authService) LogoutAll(userID uint) error {
+	return s.sessionRepo.RevokeAllByUserID(userID, models.SessionRevokedLogoutAll)
+}
+
+func (s *authService) ListSessions(userID uint) ([]models.UserSession, error) {
+	return s.sessionRepo.GetActiveByUserID(userID)
+}
+
+func (s *authService) RevokeSession(userID, sessionID uint) error {
+	session, err := s.sessionRepo.GetByID(sessionID)
+	if err != nil || session.UserID != userID {
+		return i18n.NewError(i18n.CodeSessionNotFound)
+	}
+	return s.sessionRepo.Revoke(sessionID, models.SessionRevokedByUser)
+}
+
+func (s *authService) issueTokens(user *models.User, sessionID uint, refreshToken string) (*AuthTokens, error) {
+	accessToken, err := s.generateToken(user, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.accessTokenTTL.Seconds()),
+		SessionID:    sessionID,
+	}, nil
 }
 
-func (s *authService) generateToken(user *models.User) (string, error) {
-	expirationTime := time.Now().Add(8 * time.Hour)
+func (s *authService) generateToken(user *models.User, sessionID uint) (string, error) {
+	expirationTime := time.Now().Add(s.accessTokenTTL)
 
 	claims := &Claims{
-		UserID: user.ID,
-		Email:  user.Email,
-		Role:   user.Role,
+		UserID:    user.ID,
+		Email:     user.Email,
+		Role:      user.Role,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -120,21 +283,35 @@ func (s *authService) ValidateToken(tokenString string) (*jwt.Token, error) {
 	})
 }
 
-func (s *authService) GetUserFromToken(tokenString string) (*models.User, error) {
+// Authenticate validates an access token and returns its user and session.
+// Tokens of revoked or expired sessions are rejected even before the JWT
+// itself expires.
+func (s *authService) Authenticate(tokenString string) (*models.User, uint, error) {
 	token, err := s.ValidateToken(tokenString)
 	if err != nil {
-		return nil, errors.New("token tidak valid")
+		return nil, 0, i18n.NewError(i18n.CodeInvalidToken)
 	}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		user, err := s.userRepo.GetByID(claims.UserID)
-		if err != nil {
-			return nil, errors.New("user tidak ditemukan")
-		}
-		return user, nil
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid || claims.SessionID == 0 {
+		return nil, 0, i18n.NewError(i18n.CodeInvalidToken)
+	}
+
+	session, err := s.sessionRepo.GetByID(claims.SessionID)
+	if err != nil || session.UserID != claims.UserID || !session.IsActive(time.Now()) {
+		return nil, 0, i18n.NewError(i18n.CodeSessionRevoked)
+	}
+
+	user, err := s.userRepo.GetByID(claims.UserID)
+	if err != nil {
+		return nil, 0, i18n.NewError(i18n.CodeUserNotFound)
 	}
+	return user, session.ID, nil
+}
 
-	return nil, errors.New("token tidak valid")
+func (s *authService) GetUserFromToken(tokenString string) (*models.User, error) {
+	user, _, err := s.Authenticate(tokenString)
+	return user, err
 }
 
 func (s *authService) HashPassword(password string) (string, error) {
@@ -146,3 +323,18 @@ func (s *authService) CheckPassword(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
+
+// newRefreshToken returns a random refresh token and the hash to store.
+func newRefreshToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
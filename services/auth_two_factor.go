@@ -0,0 +1,372 @@
+package services
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"image/png"
+	"log"
+	"os"
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	totpPeriod             = 30
+	recoveryCodeCount      = 10
+	twoFactorChallengeTTL  = 5 * time.Minute
+	twoFactorMaxFailures   = 5
+	twoFactorLockout       = 5 * time.Minute
+	twoFactorChallengeType = "2fa_login"
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// LoginResult is the outcome of the password step of a login. When the user
+// has two-factor authentication enabled Tokens is nil and ChallengeToken has
+// to be exchanged through LoginTwoFactor together with a TOTP or recovery
+// code.
+type LoginResult struct {
+	User           *models.User
+	Tokens         *AuthTokens
+	ChallengeToken string
+}
+
+// TwoFactorSetup is returned when enrollment starts. The user scans QRCode
+// (or enters Secret) in an authenticator app and confirms with a code.
+type TwoFactorSetup struct {
+	Secret     string `json:"secret"`
+	OTPAuthURL string `json:"otpauth_url"`
+	QRCode     string `json:"qr_code"` // data URI PNG
+}
+
+type twoFactorChallengeClaims struct {
+	UserID  uint   `json:"user_id"`
+	Purpose string `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+type twoFactorFailure struct {
+	count       int
+	lockedUntil time.Time
+}
+
+func (s *authService) Login(email, password string, client SessionClient) (*LoginResult, error) {
+	user, err := s.userRepo.GetByEmail(email)
+	if err != nil {
+		return nil, i18n.NewError(i18n.CodeInvalidCredentials)
+	}
+
+	if !s.CheckPassword(password, user.Password) {
+		return nil, i18n.NewError(i18n.CodeInvalidCredentials)
+	}
+
+	if user.TwoFactorEnabled {
+		challenge, err := s.generateTwoFactorChallenge(user)
+		if err != nil {
+			return nil, i18n.NewError(i18n.CodeTokenGenerationFailed)
+		}
+		return &LoginResult{User: user, ChallengeToken: challenge}, nil
+	}
+
+	tokens, err := s.createSession(user, client)
+	if err != nil {
+		return nil, i18n.NewError(i18n.CodeTokenGenerationFailed)
+	}
+
+	return &LoginResult{User: user, Tokens: tokens}, nil
+}
+
+// LoginTwoFactor completes a login started by Login for a user with
+// two-factor authentication enabled.
+func (s *authService) LoginTwoFactor(challengeToken, code string, client SessionClient) (*AuthTokens, *models.User, error) {
+	userID, err := s.parseTwoFactorChallenge(challengeToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil || !user.TwoFactorEnabled {
+		return nil, nil, i18n.NewError(i18n.CodeTwoFactorChallengeInvalid)
+	}
+
+	if err := s.verifySecondFactor(user, code, true); err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.createSession(user, client)
+	if err != nil {
+		return nil, nil, i18n.NewError(i18n.CodeTokenGenerationFailed)
+	}
+	return tokens, user, nil
+}
+
+// SetupTwoFactor starts enrollment by generating a new secret. The secret
+// is stored but only takes effect after EnableTwoFactor.
+func (s *authService) SetupTwoFactor(user *models.User) (*TwoFactorSetup, error) {
+	if user.TwoFactorEnabled {
+		return nil, i18n.NewError(i18n.CodeTwoFactorAlreadyEnabled)
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      mailAppName(),
+		AccountName: user.Email,
+		Period:      totpPeriod,
+	})
+	if err != nil {
+		return nil, ErrSystemError
+	}
+
+	qrCode, err := totpQRCode(key)
+	if err != nil {
+		return nil, ErrSystemError
+	}
+
+	secret := key.Secret()
+	if err := s.userRepo.UpdateFields(user.ID, map[string]interface{}{
+		"two_factor_secret":       secret,
+		"two_factor_last_counter": 0,
+	}); err != nil {
+		return nil, ErrSystemError
+	}
+
+	return &TwoFactorSetup{Secret: secret, OTPAuthURL: key.URL(), QRCode: qrCode}, nil
+}
+
+// EnableTwoFactor confirms enrollment with a code from the authenticator app
+// and returns the recovery codes. They are shown only once.
+func (s *authService) EnableTwoFactor(user *models.User, code string) ([]string, error) {
+	if user.TwoFactorEnabled {
+		return nil, i18n.NewError(i18n.CodeTwoFactorAlreadyEnabled)
+	}
+	if user.TwoFactorSecret == nil {
+		return nil, i18n.NewError(i18n.CodeTwoFactorSetupRequired)
+	}
+
+	if err := s.verifyTOTP(user, code); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.replaceRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.UpdateFields(user.ID, map[string]interface{}{"two_factor_enabled": true}); err != nil {
+		return nil, ErrSystemError
+	}
+	return codes, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off. Both the password
+// and a current TOTP or recovery code are required.
+func (s *authService) DisableTwoFactor(user *models.User, password, code string) error {
+	if !user.TwoFactorEnabled {
+		return i18n.NewError(i18n.CodeTwoFactorNotEnabled)
+	}
+	if !s.CheckPassword(password, user.Password) {
+		return i18n.NewError(i18n.CodeCurrentPasswordWrong)
+	}
+
+	if err := s.verifySecondFactor(user, code, true); err != nil {
+		return err
+	}
+
+	if err := s.twoFactorRepo.Disable(user.ID); err != nil {
+		return ErrSystemError
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of the user. A TOTP
+// code is required so a lost recovery code cannot be used to mint new ones.
+func (s *authService) RegenerateRecoveryCodes(user *models.User, code string) ([]string, error) {
+	if !user.TwoFactorEnabled {
+		return nil, i18n.NewError(i18n.CodeTwoFactorNotEnabled)
+	}
+
+	if err := s.verifySecondFactor(user, code, false); err != nil {
+		return nil, err
+	}
+
+	return s.replaceRecoveryCodes(user.ID)
+}
+
+func (s *authService) RemainingRecoveryCodes(userID uint) (int64, error) {
+	return s.twoFactorRepo.CountUnusedRecoveryCodes(userID)
+}
+
+// verifySecondFactor checks a TOTP code or, if allowRecovery, a recovery
+// code. Repeated failures lock the user out of the second step for a while.
+func (s *authService) verifySecondFactor(user *models.User, code string, allowRecovery bool) error {
+	if s.twoFactorLocked(user.ID) {
+		return i18n.NewError(i18n.CodeTwoFactorTooManyAttempts)
+	}
+
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	var err error
+	if allowRecovery && len(code) != 6 {
+		err = s.useRecoveryCode(user, code)
+	} else {
+		err = s.verifyTOTP(user, code)
+	}
+
+	if err != nil {
+		s.recordTwoFactorFailure(user.ID)
+		return err
+	}
+	s.resetTwoFactorFailures(user.ID)
+	return nil
+}
+
+// verifyTOTP accepts a code of the current time step or one step either
+// side to allow for clock drift. Each step can be used once.
+func (s *authService) verifyTOTP(user *models.User, code string) error {
+	if user.TwoFactorSecret == nil {
+		return i18n.NewError(i18n.CodeTwoFactorCodeInvalid)
+	}
+
+	current := time.Now().Unix() / totpPeriod
+	for _, counter := range []int64{current - 1, current, current + 1} {
+		if counter <= user.TwoFactorLastCounter {
+			continue
+		}
+
+		expected, err := totp.GenerateCodeCustom(*user.TwoFactorSecret, time.Unix(counter*totpPeriod, 0), totp.ValidateOpts{
+			Period:    totpPeriod,
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err != nil || subtle.ConstantTimeCompare([]byte(expected), []byte(code)) != 1 {
+			continue
+		}
+
+		consumed, err := s.twoFactorRepo.ConsumeCounter(user.ID, counter)
+		if err != nil {
+			return ErrSystemError
+		}
+		if !consumed {
+			break
+		}
+		user.TwoFactorLastCounter = counter
+		return nil
+	}
+	return i18n.NewError(i18n.CodeTwoFactorCodeInvalid)
+}
+
+func (s *authService) useRecoveryCode(user *models.User, code string) error {
+	used, err := s.twoFactorRepo.UseRecoveryCode(user.ID, hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return ErrSystemError
+	}
+	if !used {
+		return i18n.NewError(i18n.CodeTwoFactorCodeInvalid)
+	}
+
+	log.Printf("[Auth] User %d signed in with a recovery code", user.ID)
+	return nil
+}
+
+func (s *authService) replaceRecoveryCodes(userID uint) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		buf := make([]byte, 10)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, ErrSystemError
+		}
+		raw := strings.ToLower(recoveryCodeEncoding.EncodeToString(buf))[:10]
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, hashToken(raw))
+	}
+
+	if err := s.twoFactorRepo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, ErrSystemError
+	}
+	return codes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(code, "-", ""))
+}
+
+func (s *authService) generateTwoFactorChallenge(user *models.User) (string, error) {
+	now := time.Now()
+	claims := &twoFactorChallengeClaims{
+		UserID:  user.ID,
+		Purpose: twoFactorChallengeType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(twoFactorChallengeTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Subject:   user.Email,
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+}
+
+func (s *authService) parseTwoFactorChallenge(challengeToken string) (uint, error) {
+	claims := &twoFactorChallengeClaims{}
+	token, err := jwt.ParseWithClaims(challengeToken, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(os.Getenv("JWT_SECRET")), nil
+	})
+	if err != nil || !token.Valid || claims.Purpose != twoFactorChallengeType {
+		return 0, i18n.NewError(i18n.CodeTwoFactorChallengeInvalid)
+	}
+	return claims.UserID, nil
+}
+
+func (s *authService) twoFactorLocked(userID uint) bool {
+	s.twoFactorMu.Lock()
+	defer s.twoFactorMu.Unlock()
+	failure, ok := s.twoFactorFailures[userID]
+	return ok && time.Now().Before(failure.lockedUntil)
+}
+
+func (s *authService) recordTwoFactorFailure(userID uint) {
+	s.twoFactorMu.Lock()
+	defer s.twoFactorMu.Unlock()
+
+	failure := s.twoFactorFailures[userID]
+	if !failure.lockedUntil.IsZero() && time.Now().After(failure.lockedUntil) {
+		failure = twoFactorFailure{}
+	}
+	failure.count++
+	if failure.count >= twoFactorMaxFailures {
+		failure.lockedUntil = time.Now().Add(twoFactorLockout)
+		failure.count = 0
+		log.Printf("[Auth] Too many two-factor failures for user %d, locked for %s", userID, twoFactorLockout)
+	}
+	s.twoFactorFailures[userID] = failure
+}
+
+func (s *authService) resetTwoFactorFailures(userID uint) {
+	s.twoFactorMu.Lock()
+	defer s.twoFactorMu.Unlock()
+	delete(s.twoFactorFailures, userID)
+}
+
+func totpQRCode(key *otp.Key) (string, error) {
+	img, err := key.Image(256, 256)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
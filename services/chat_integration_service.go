@@ -0,0 +1,210 @@
+package services
+
+import (
+	"log"
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"project-management-backend/repositories"
+	"strings"
+)
+
+// Warna pesan chat per event
+const (
+	chatColorAssigned = "#1f4e79"
+	chatColorStatus   = "#2e7d32"
+	chatColorOverdue  = "#c62828"
+)
+
+type ChatIntegrationInput struct {
+	Name       *string  `json:"name"`
+	Provider   *string  `json:"provider"`
+	WebhookURL *string  `json:"webhook_url"`
+	Events     []string `json:"events"`
+	IsActive   *bool    `json:"is_active"`
+}
+
+// ChatIntegrationService manages the chat integrations of a workspace and
+// posts task events to them. Notify methods send in the background and only
+// log failures, so they can be called after the triggering change commits.
+type ChatIntegrationService interface {
+	NotifyTaskAssigned(task *models.Task, assignee *models.User, actor *models.User)
+	NotifyStatusChanged(task *models.Task, oldStatus, newStatus string, actor *models.User)
+	NotifyOverdue(task *models.Task)
+	List(workspaceID uint) ([]models.WorkspaceChatIntegration, error)
+	Create(workspaceID uint, input ChatIntegrationInput, user *models.User) (*models.WorkspaceChatIntegration, error)
+	Update(workspaceID, integrationID uint, input ChatIntegrationInput) (*models.WorkspaceChatIntegration, error)
+	Delete(workspaceID, integrationID uint) error
+	SendTest(workspaceID, integrationID uint) error
+}
+
+type chatIntegrationService struct {
+	repo        repositories.ChatIntegrationRepository
+	chatService ChatService
+}
+
+func NewChatIntegrationService(repo repositories.ChatIntegrationRepository, chatService ChatService) ChatIntegrationService {
+	return &chatIntegrationService{
+		repo:        repo,
+		chatService: chatService,
+	}
+}
+
+func (s *chatIntegrationService) NotifyTaskAssigned(task *models.Task, assignee *models.User, actor *models.User) {
+	lang := i18n.DefaultLang
+	s.publish(task.Project.WorkspaceID, models.ChatEventTaskAssigned, ChatMessage{
+		Title:  i18n.T(lang, i18n.LabelNotifAssignedTitle),
+		Text:   i18n.T(lang, i18n.LabelChatAssigned, actorName(actor), assignee.Name, task.Title, i18n.FormatDateTime(lang, task.DueDate)),
+		Footer: task.Project.Name,
+		Color:  chatColorAssigned,
+	})
+}
+
+func (s *chatIntegrationService) NotifyStatusChanged(task *models.Task, oldStatus, newStatus string, actor *models.User) {
+	lang := i18n.DefaultLang
+	s.publish(task.Project.WorkspaceID, models.ChatEventTaskStatusChanged, ChatMessage{
+		Title:  i18n.T(lang, i18n.LabelNotifStatusTitle),
+		Text:   i18n.T(lang, i18n.LabelChatStatusChanged, actorName(actor), task.Title, oldStatus, newStatus),
+		Footer: task.Project.Name,
+		Color:  chatColorStatus,
+	})
+}
+
+func (s *chatIntegrationService) NotifyOverdue(task *models.Task) {
+	lang := i18n.DefaultLang
+	s.publish(task.Project.WorkspaceID, models.ChatEventTaskOverdue, ChatMessage{
+		Title:  i18n.T(lang, i18n.LabelNotifOverdueTitle),
+		Text:   i18n.T(lang, i18n.LabelChatOverdue, task.Title, i18n.FormatDateTime(lang, task.DueDate), taskAssigneeNames(task)),
+		Footer: task.Project.Name,
+		Color:  chatColorOverdue,
+	})
+}
+
+func (s *chatIntegrationService) publish(workspaceID uint, event string, message ChatMessage) {
+	integrations, err := s.repo.GetActiveByWorkspaceID(workspaceID)
+	if err != nil {
+		log.Printf("[Chat] Failed to load integrations of workspace %d: %v", workspaceID, err)
+		return
+	}
+
+	for _, integration := range integrations {
+		if !integration.Subscribes(event) {
+			continue
+		}
+		go func(integration models.WorkspaceChatIntegration) {
+			if err := s.chatService.Send(integration.Provider, integration.WebhookURL, message); err != nil {
+				log.Printf("[Chat] Failed to post %s to integration %d: %v", event, integration.ID, err)
+			}
+		}(integration)
+	}
+}
+
+func (s *chatIntegrationService) List(workspaceID uint) ([]models.WorkspaceChatIntegration, error) {
+	return s.repo.GetByWorkspaceID(workspaceID)
+}
+
+func (s *chatIntegrationService) Create(workspaceID uint, input ChatIntegrationInput, user *models.User) (*models.WorkspaceChatIntegration, error) {
+	integration := &models.WorkspaceChatIntegration{
+		WorkspaceID: workspaceID,
+		Events:      append([]string{}, models.ChatEvents...),
+		IsActive:    true,
+		CreatedBy:   user.ID,
+	}
+	if input.Provider == nil || input.WebhookURL == nil {
+		return nil, i18n.NewError(i18n.CodeChatIntegrationRequired)
+	}
+	if err := applyChatIntegrationInput(integration, input); err != nil {
+		return nil, err
+	}
+	if integration.Name == "" {
+		integration.Name = integration.Provider
+	}
+
+	if err := s.repo.Create(integration); err != nil {
+		return nil, err
+	}
+	return integration, nil
+}
+
+func (s *chatIntegrationService) Update(workspaceID, integrationID uint, input ChatIntegrationInput) (*models.WorkspaceChatIntegration, error) {
+	integration, err := s.get(workspaceID, integrationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyChatIntegrationInput(integration, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(integration); err != nil {
+		return nil, err
+	}
+	return integration, nil
+}
+
+func (s *chatIntegrationService) Delete(workspaceID, integrationID uint) error {
+	if _, err := s.get(workspaceID, integrationID); err != nil {
+		return err
+	}
+	return s.repo.Delete(integrationID)
+}
+
+// SendTest posts a test message synchronously so the caller sees whether
+// the webhook URL works.
+func (s *chatIntegrationService) SendTest(workspaceID, integrationID uint) error {
+	integration, err := s.get(workspaceID, integrationID)
+	if err != nil {
+		return err
+	}
+
+	lang := i18n.DefaultLang
+	err = s.chatService.Send(integration.Provider, integration.WebhookURL, ChatMessage{
+		Title: i18n.T(lang, i18n.LabelChatTestTitle),
+		Text:  i18n.T(lang, i18n.LabelChatTestMessage, integration.Name),
+	})
+	if err != nil {
+		return i18n.NewError(i18n.CodeChatTestFailed, err.Error())
+	}
+	return nil
+}
+
+func (s *chatIntegrationService) get(workspaceID, integrationID uint) (*models.WorkspaceChatIntegration, error) {
+	integration, err := s.repo.GetByID(integrationID)
+	if err != nil || integration.WorkspaceID != workspaceID {
+		return nil, i18n.NewError(i18n.CodeChatIntegrationNotFound)
+	}
+	return integration, nil
+}
+
+func applyChatIntegrationInput(integration *models.WorkspaceChatIntegration, input ChatIntegrationInput) error {
+	if input.Name != nil {
+		integration.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Provider != nil {
+		provider := strings.ToLower(strings.TrimSpace(*input.Provider))
+		if !containsString(models.ChatProviders, provider) {
+			return i18n.NewError(i18n.CodeChatProviderUnknown, *input.Provider)
+		}
+		integration.Provider = provider
+	}
+	if input.WebhookURL != nil {
+		if err := ValidateWebhookURL(*input.WebhookURL); err != nil {
+			return err
+		}
+		integration.WebhookURL = *input.WebhookURL
+	}
+	if input.Events != nil {
+		events := make([]string, 0, len(input.Events))
+		for _, event := range input.Events {
+			if !containsString(models.ChatEvents, event) {
+				return i18n.NewError(i18n.CodeChatEventUnknown, event)
+			}
+			if !containsString(events, event) {
+				events = append(events, event)
+			}
+		}
+		integration.Events = events
+	}
+	if input.IsActive != nil {
+		integration.IsActive = *input.IsActive
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"project-management-backend/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	chatTimeout             = 10 * time.Second
+	discordTitleLimit       = 256
+	discordDescriptionLimit = 4096
+	defaultChatColor        = "#607d8b"
+)
+
+type ChatMessage struct {
+	Title  string
+	Text   string
+	Footer string
+	Color  string // format #RRGGBB
+}
+
+// ChatService posts messages to team chat incoming webhooks. Slack and
+// Mattermost share the attachment format; Discord uses embeds.
+type ChatService interface {
+	Send(provider string, webhookURL string, message ChatMessage) error
+}
+
+type chatService struct {
+	client *http.Client
+}
+
+func NewChatService() ChatService {
+	return &chatService{client: newOutboundHTTPClient(chatTimeout)}
+}
+
+func (s *chatService) Send(provider string, webhookURL string, message ChatMessage) error {
+	payload, err := buildChatPayload(provider, message)
+	if err != nil {
+		return err
+	}
+
+	requestBody, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(webhookURL, "application/json", bytes.NewReader(requestBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to send chat message, status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func buildChatPayload(provider string, message ChatMessage) (interface{}, error) {
+	color := message.Color
+	if color == "" {
+		color = defaultChatColor
+	}
+
+	switch provider {
+	case models.ChatProviderSlack, models.ChatProviderMattermost:
+		return map[string]interface{}{
+			"attachments": []map[string]interface{}{{
+				"fallback": message.Title + ": " + message.Text,
+				"color":    color,
+				"title":    message.Title,
+				"text":     message.Text,
+				"footer":   message.Footer,
+			}},
+		}, nil
+	case models.ChatProviderDiscord:
+		embed := map[string]interface{}{
+			"title":       truncateRunes(message.Title, discordTitleLimit),
+			"description": truncateRunes(message.Text, discordDescriptionLimit),
+			"color":       hexColorToInt(color),
+		}
+		if message.Footer != "" {
+			embed["footer"] = map[string]string{"text": message.Footer}
+		}
+		return map[string]interface{}{
+			"embeds": []map[string]interface{}{embed},
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown chat provider %s", provider)
+}
+
+func hexColorToInt(color string) int64 {
+	value, err := strconv.ParseInt(strings.TrimPrefix(color, "#"), 16, 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+func truncateRunes(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	return string(runes[:limit-1]) + "…"
+}
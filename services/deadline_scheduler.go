@@ -0,0 +1,176 @@
+package services
+
+import (
+	"log"
+	"os"
+	"project-management-backend/config"
+	"project-management-backend/models"
+	"project-management-backend/repositories"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultReminderHoursBefore    = 24
+	defaultOverdueEscalationHours = 24
+	defaultDeadlineScanMinutes    = 15
+)
+
+// DeadlineScheduler sends deadline reminders, overdue notifications and
+// escalations, and keeps overdue_duration of open tasks current.
+type DeadlineScheduler interface {
+	Start()
+}
+
+type deadlineScheduler struct {
+	taskRepo        repositories.TaskRepository
+	projectRepo     repositories.ProjectRepository
+	notifications   NotificationService
+	chat            ChatIntegrationService
+	reminderBefore  time.Duration
+	escalationDelay time.Duration
+	interval        time.Duration
+}
+
+func NewDeadlineScheduler(taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, notifications NotificationService, chat ChatIntegrationService) DeadlineScheduler {
+	return &deadlineScheduler{
+		taskRepo:        taskRepo,
+		projectRepo:     projectRepo,
+		notifications:   notifications,
+		chat:            chat,
+		reminderBefore:  envDuration("REMINDER_HOURS_BEFORE", defaultReminderHoursBefore, time.Hour),
+		escalationDelay: envDuration("OVERDUE_ESCALATION_HOURS", defaultOverdueEscalationHours, time.Hour),
+		interval:        envDuration("DEADLINE_SCAN_MINUTES", defaultDeadlineScanMinutes, time.Minute),
+	}
+}
+
+func (s *deadlineScheduler) Start() {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.tick(time.Now())
+			<-ticker.C
+		}
+	}()
+}
+
+func (s *deadlineScheduler) tick(now time.Time) {
+	s.sendReminders(now)
+	s.processOverdue(now)
+}
+
+func (s *deadlineScheduler) sendReminders(now time.Time) {
+	tasks, err := s.taskRepo.GetTasksDueForReminder(now, now.Add(s.reminderBefore))
+	if err != nil {
+		log.Printf("[Deadline] Failed to load tasks due soon: %v", err)
+		return
+	}
+
+	for i := range tasks {
+		task := &tasks[i]
+		err := config.DB.Transaction(func(tx *gorm.DB) error {
+			// Hanya instance yang berhasil menandai yang mengirim pengingat
+			marked, err := s.taskRepo.WithTx(tx).MarkDeadlineNotified(task.ID, "reminder_sent_at", now)
+			if err != nil || !marked {
+				return err
+			}
+			return s.notifications.WithTx(tx).NotifyDueSoon(task)
+		})
+		if err != nil {
+			log.Printf("[Deadline] Failed to send reminder for task %d: %v", task.ID, err)
+		}
+	}
+}
+
+func (s *deadlineScheduler) processOverdue(now time.Time) {
+	tasks, err := s.taskRepo.GetOpenTasksOverdue(now)
+	if err != nil {
+		log.Printf("[Deadline] Failed to load overdue tasks: %v", err)
+		return
+	}
+
+	admins := make(map[uint][]models.User)
+	for i := range tasks {
+		task := &tasks[i]
+		notifyOverdue := task.OverdueNotifiedAt == nil
+		escalate := task.EscalatedAt == nil && now.Sub(task.DueDate) >= s.escalationDelay
+
+		var projectAdmins []models.User
+		if notifyOverdue || escalate {
+			var ok bool
+			projectAdmins, ok = admins[task.ProjectID]
+			if !ok {
+				projectAdmins = s.projectAdmins(task.ProjectID)
+				admins[task.ProjectID] = projectAdmins
+			}
+		}
+
+		// Penanda dan notifikasi disimpan bersama agar tidak ada notifikasi
+		// yang hilang. Penanda hanya diset jika masih kosong, sehingga
+		// instance lain yang memproses task yang sama tidak mengirim ulang
+		err := config.DB.Transaction(func(tx *gorm.DB) error {
+			taskRepo := s.taskRepo.WithTx(tx)
+			if err := taskRepo.UpdateTask(task.ID, map[string]interface{}{"overdue_duration": now.Sub(task.DueDate)}); err != nil {
+				return err
+			}
+			var err error
+			if notifyOverdue {
+				if notifyOverdue, err = taskRepo.MarkDeadlineNotified(task.ID, "overdue_notified_at", now); err != nil {
+					return err
+				}
+			}
+			if escalate {
+				if escalate, err = taskRepo.MarkDeadlineNotified(task.ID, "escalated_at", now); err != nil {
+					return err
+				}
+			}
+
+			notifications := s.notifications.WithTx(tx)
+			if notifyOverdue {
+				if err := notifications.NotifyOverdue(task, projectAdmins); err != nil {
+					return err
+				}
+			}
+			if escalate {
+				return notifications.NotifyOverdueEscalation(task, projectAdmins)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("[Deadline] Failed to update overdue task %d: %v", task.ID, err)
+			continue
+		}
+		if notifyOverdue {
+			s.chat.NotifyOverdue(task)
+		}
+	}
+}
+
+func (s *deadlineScheduler) projectAdmins(projectID uint) []models.User {
+	members, err := s.projectRepo.GetMembers(projectID)
+	if err != nil {
+		log.Printf("[Deadline] Failed to load members of project %d: %v", projectID, err)
+		return nil
+	}
+
+	var admins []models.User
+	for _, member := range members {
+		if member.RoleInProject == "admin" {
+			admins = append(admins, member.User)
+		}
+	}
+	return admins
+}
+
+// envDuration reads a positive integer from the environment and multiplies
+// it by unit, falling back to def.
+func envDuration(key string, def int, unit time.Duration) time.Duration {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n > 0 {
+		return time.Duration(n) * unit
+	}
+	return time.Duration(def) * unit
+}
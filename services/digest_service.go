@@ -0,0 +1,216 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"project-management-backend/config"
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"project-management-backend/repositories"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const digestCheckInterval = time.Minute
+
+// DailyDigest is the morning summary for one user. Dates are in the user's
+// timezone.
+type DailyDigest struct {
+	Date              time.Time
+	DueToday          []models.Task
+	Overdue           []models.Task
+	AssignedYesterday []models.Task
+	AttendanceMissing bool
+}
+
+func (d *DailyDigest) IsEmpty() bool {
+	return len(d.DueToday) == 0 && len(d.Overdue) == 0 && len(d.AssignedYesterday) == 0 && !d.AttendanceMissing
+}
+
+type DigestService interface {
+	Start()
+	BuildDigest(user *models.User, now time.Time) (*DailyDigest, error)
+}
+
+type digestService struct {
+	taskRepo       repositories.TaskRepository
+	attendanceRepo *repositories.AttendanceRepository
+	workspaceRepo  repositories.WorkspaceRepository
+	userRepo       repositories.UserRepository
+	dispatcher     NotificationDispatcher
+}
+
+func NewDigestService(taskRepo repositories.TaskRepository, attendanceRepo *repositories.AttendanceRepository, workspaceRepo repositories.WorkspaceRepository, userRepo repositories.UserRepository, dispatcher NotificationDispatcher) DigestService {
+	return &digestService{
+		taskRepo:       taskRepo,
+		attendanceRepo: attendanceRepo,
+		workspaceRepo:  workspaceRepo,
+		userRepo:       userRepo,
+		dispatcher:     dispatcher,
+	}
+}
+
+// Start checks every minute for users whose digest time has passed today in
+// their own timezone and who have not received today's digest yet.
+func (s *digestService) Start() {
+	go func() {
+		ticker := time.NewTicker(digestCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			s.sendDue(time.Now())
+			<-ticker.C
+		}
+	}()
+}
+
+func (s *digestService) sendDue(now time.Time) {
+	users, err := s.userRepo.GetDigestSubscribers()
+	if err != nil {
+		log.Printf("[Digest] Failed to load subscribers: %v", err)
+		return
+	}
+
+	for i := range users {
+		user := &users[i]
+		scheduledAt, ok := digestScheduledAt(user, now)
+		if !ok || now.Before(scheduledAt) {
+			continue
+		}
+		if user.LastDigestSentAt != nil && !user.LastDigestSentAt.Before(scheduledAt) {
+			continue
+		}
+
+		digest, err := s.BuildDigest(user, now)
+		if err != nil {
+			// Belum ditandai, dicoba lagi pada pengecekan berikutnya
+			log.Printf("[Digest] Failed to build digest for user %d: %v", user.ID, err)
+			continue
+		}
+
+		// Penanda dan antrean notifikasi dalam satu transaksi: digest tidak
+		// hilang jika dispatch gagal, dan tidak terkirim dua kali jika
+		// beberapa instance berjalan bersamaan
+		err = config.DB.Transaction(func(tx *gorm.DB) error {
+			marked, err := s.userRepo.MarkDigestSent(tx, user.ID, scheduledAt, now)
+			if err != nil || !marked || digest.IsEmpty() {
+				return err
+			}
+
+			lang := userLanguage(user)
+			return s.dispatcher.WithTx(tx).Dispatch(user, &models.Notification{
+				Type:    models.NotificationDailyDigest,
+				Title:   i18n.T(lang, i18n.LabelDigestTitle, i18n.FormatLongDate(lang, digest.Date)),
+				Message: renderDigest(digest, lang),
+			})
+		})
+		if err != nil {
+			log.Printf("[Digest] Failed to queue digest for user %d: %v", user.ID, err)
+		}
+	}
+}
+
+func (s *digestService) BuildDigest(user *models.User, now time.Time) (*DailyDigest, error) {
+	loc := UserLocation(user)
+	local := now.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	tomorrow := today.AddDate(0, 0, 1)
+	yesterday := today.AddDate(0, 0, -1)
+
+	digest := &DailyDigest{Date: today}
+
+	var err error
+	if digest.DueToday, err = s.taskRepo.GetUserOpenTasksDueBetween(user.ID, today, tomorrow); err != nil {
+		return nil, err
+	}
+	if digest.Overdue, err = s.taskRepo.GetUserOpenTasksOverdue(user.ID, today); err != nil {
+		return nil, err
+	}
+	if digest.AssignedYesterday, err = s.taskRepo.GetTasksAssignedToUserBetween(user.ID, yesterday, today); err != nil {
+		return nil, err
+	}
+
+	workspaces, err := s.workspaceRepo.GetWorkspacesByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(workspaces) > 0 {
+		_, err := s.attendanceRepo.GetAttendanceByUserIDAndDateRange(user.ID, today, tomorrow)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			digest.AttendanceMissing = true
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	return digest, nil
+}
+
+func renderDigest(digest *DailyDigest, lang string) string {
+	loc := digest.Date.Location()
+	var sections []string
+
+	addTasks := func(titleKey string, tasks []models.Task) {
+		if len(tasks) == 0 {
+			return
+		}
+		lines := []string{fmt.Sprintf("%s (%d)", i18n.T(lang, titleKey), len(tasks))}
+		for _, task := range tasks {
+			lines = append(lines, i18n.T(lang, i18n.LabelDigestTaskLine, task.Title, task.Project.Name, i18n.FormatDateTime(lang, task.DueDate.In(loc))))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	addTasks(i18n.LabelDigestDueToday, digest.DueToday)
+	addTasks(i18n.LabelDigestOverdue, digest.Overdue)
+	addTasks(i18n.LabelDigestAssignedYesterday, digest.AssignedYesterday)
+	if digest.AttendanceMissing {
+		sections = append(sections, i18n.T(lang, i18n.LabelDigestAttendanceMissing))
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+// digestScheduledAt returns today's digest moment for the user, or false
+// when the digest is off or misconfigured.
+func digestScheduledAt(user *models.User, now time.Time) (time.Time, bool) {
+	if user.DigestTime == nil {
+		return time.Time{}, false
+	}
+	clock, err := ParseDigestTime(*user.DigestTime)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	local := now.In(UserLocation(user))
+	return time.Date(local.Year(), local.Month(), local.Day(), clock.Hour(), clock.Minute(), 0, 0, local.Location()), true
+}
+
+// ParseDigestTime parses an HH:MM clock time.
+func ParseDigestTime(value string) (time.Time, error) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return time.Time{}, i18n.NewError(i18n.CodeDigestTimeInvalid)
+	}
+	return clock, nil
+}
+
+func ValidateTimezone(name string) error {
+	if _, err := time.LoadLocation(name); err != nil || name == "" {
+		return i18n.NewError(i18n.CodeTimezoneInvalid, name)
+	}
+	return nil
+}
+
+// UserLocation returns the user's timezone, falling back to the server's.
+func UserLocation(user *models.User) *time.Location {
+	if user.Timezone != nil {
+		if loc, err := time.LoadLocation(*user.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"encoding/json"
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// EventStream is a one-way subscription to the realtime hub, used by the
+// Server-Sent Events endpoint. It receives the same messages as a WebSocket
+// client subscribed to the same topics.
+type EventStream struct {
+	client *models.Client
+	cursor map[uint]string // workspace -> ID event terakhir
+}
+
+// Messages returns the channel of encoded messages. It is closed when the
+// hub drops the stream.
+func (st *EventStream) Messages() <-chan []byte {
+	return st.client.Send
+}
+
+// Track records a delivered message and returns the SSE id to send with it,
+// or "" when the message is not a sequenced event. Sequences are per
+// workspace, so the id lists the last event of every workspace, e.g.
+// "3:1760000000-42,5:1760000000-7".
+func (st *EventStream) Track(message []byte) string {
+	var event struct {
+		ID          string `json:"id"`
+		Seq         uint64 `json:"seq"`
+		WorkspaceID uint   `json:"workspace_id"`
+	}
+	if err := json.Unmarshal(message, &event); err != nil || event.ID == "" || event.Seq == 0 {
+		return ""
+	}
+	st.cursor[event.WorkspaceID] = event.ID
+	return formatStreamCursor(st.cursor)
+}
+
+func formatStreamCursor(cursor map[uint]string) string {
+	workspaceIDs := make([]uint, 0, len(cursor))
+	for id := range cursor {
+		workspaceIDs = append(workspaceIDs, id)
+	}
+	sort.Slice(workspaceIDs, func(i, j int) bool { return workspaceIDs[i] < workspaceIDs[j] })
+
+	parts := make([]string, 0, len(workspaceIDs))
+	for _, id := range workspaceIDs {
+		parts = append(parts, strconv.FormatUint(uint64(id), 10)+":"+cursor[id])
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseStreamCursor reads a cursor written by Track. A plain event ID (as
+// sent over WebSocket) is returned as fallback for every workspace.
+func parseStreamCursor(value string) (cursor map[uint]string, fallback string) {
+	cursor = make(map[uint]string)
+	if !strings.Contains(value, ":") {
+		return cursor, value
+	}
+	for _, part := range strings.Split(value, ",") {
+		rawID, eventID, found := strings.Cut(part, ":")
+		id, err := strconv.ParseUint(rawID, 10, 64)
+		if found && err == nil {
+			cursor[uint(id)] = eventID
+		}
+	}
+	return cursor, ""
+}
+
+// OpenStream checks access to topics and registers an event stream with the
+// hub. Events missed since lastEventID (an SSE cursor or a plain event ID)
+// are replayed first. The stream always follows user:me.
+func (s *webSocketService) OpenStream(user *models.User, topics []string, lastEventID string) (*EventStream, error) {
+	// Satu slot dipakai topic user:me
+	if len(topics) > maxSubscriptions-1 {
+		return nil, i18n.NewError(i18n.CodeWsTooManyTopics, maxSubscriptions-1)
+	}
+
+	client := &models.Client{
+		ID:         uuid.New().String(),
+		Hub:        s.hub,
+		Send:       make(chan []byte, 256),
+		UserID:     user.ID,
+		UserName:   user.Name,
+		Role:       user.Role,
+		Lang:       userLanguage(user),
+		Topics:     make(map[string]bool),
+		Allowed:    make(map[string]bool),
+		Indicators: make(map[string]string),
+	}
+
+	// Semua topic dicek dulu agar stream tidak dibuka setengah jalan
+	requests := make([]*models.SubscriptionRequest, 0, len(topics))
+	cursor, fallback := parseStreamCursor(lastEventID)
+	for _, raw := range topics {
+		topic, err := s.resolveTopic(client, raw)
+		if err != nil {
+			return nil, err
+		}
+		workspaceID, err := s.authorizeTopic(client, topic)
+		if err != nil {
+			return nil, err
+		}
+
+		resumeFrom, ok := cursor[workspaceID]
+		if !ok {
+			resumeFrom = fallback
+		}
+		requests = append(requests, &models.SubscriptionRequest{
+			Client:      client,
+			Topic:       topic,
+			Subscribe:   true,
+			WorkspaceID: workspaceID,
+			LastEventID: resumeFrom,
+		})
+	}
+
+	s.hub.Register <- client
+	s.connectionOpened(client)
+	for _, request := range requests {
+		s.hub.Subscriptions <- request
+		if request.WorkspaceID != 0 && strings.HasPrefix(request.Topic, models.TopicWorkspace+":") {
+			s.addPresenceWorkspace(client, request.WorkspaceID)
+		}
+	}
+
+	stream := &EventStream{client: client, cursor: cursor}
+	if fallback != "" {
+		// ID lama tanpa workspace tidak bisa dipetakan ke cursor baru
+		stream.cursor = make(map[uint]string)
+	}
+	return stream, nil
+}
+
+// CloseStream unregisters a stream opened with OpenStream.
+func (s *webSocketService) CloseStream(stream *EventStream) {
+	s.hub.Unregister <- stream.client
+	s.connectionClosed(stream.client)
+}
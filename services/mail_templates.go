@@ -0,0 +1,104 @@
+package services
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"project-management-backend/i18n"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Nama template email di services/mail_templates. Setiap template terdiri
+// dari <nama>.txt (mendefinisikan "subject" dan "text") dan <nama>.html
+// (mendefinisikan "content" yang dibungkus layout.html).
+const (
+	MailTemplateNotification    = "notification"
+	MailTemplateScheduledReport = "scheduled_report"
+	MailTemplateInvitation      = "invitation"
+	MailTemplatePasswordReset   = "password_reset"
+)
+
+//go:embed mail_templates/*
+var mailTemplateFS embed.FS
+
+type mailTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+var mailTemplates = loadMailTemplates(
+	MailTemplateNotification,
+	MailTemplateScheduledReport,
+	MailTemplateInvitation,
+	MailTemplatePasswordReset,
+)
+
+// MailData is the data passed to a mail template. RenderMail fills in Lang,
+// AppName and AppURL.
+type MailData map[string]interface{}
+
+func loadMailTemplates(names ...string) map[string]mailTemplate {
+	funcs := map[string]interface{}{
+		"t": func(lang, key string, args ...interface{}) string {
+			return i18n.T(lang, key, args...)
+		},
+	}
+
+	loaded := make(map[string]mailTemplate, len(names))
+	for _, name := range names {
+		loaded[name] = mailTemplate{
+			text: texttemplate.Must(texttemplate.New(name+".txt").Funcs(funcs).
+				ParseFS(mailTemplateFS, "mail_templates/"+name+".txt")),
+			html: htmltemplate.Must(htmltemplate.New("layout.html").Funcs(funcs).
+				ParseFS(mailTemplateFS, "mail_templates/layout.html", "mail_templates/"+name+".html")),
+		}
+	}
+	return loaded
+}
+
+// RenderMail renders the subject, plain text and HTML body of a template.
+// Recipients and attachments are left for the caller to set.
+func RenderMail(name, lang string, data MailData) (MailMessage, error) {
+	tmpl, ok := mailTemplates[name]
+	if !ok {
+		return MailMessage{}, fmt.Errorf("unknown mail template %q", name)
+	}
+
+	values := MailData{
+		"Lang":    lang,
+		"AppName": mailAppName(),
+		"AppURL":  os.Getenv("APP_URL"),
+	}
+	for key, value := range data {
+		values[key] = value
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", values); err != nil {
+		return MailMessage{}, err
+	}
+	if err := tmpl.text.ExecuteTemplate(&text, "text", values); err != nil {
+		return MailMessage{}, err
+	}
+
+	values["Subject"] = strings.TrimSpace(subject.String())
+	if err := tmpl.html.Execute(&html, values); err != nil {
+		return MailMessage{}, err
+	}
+
+	return MailMessage{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
+
+func mailAppName() string {
+	if name := os.Getenv("APP_NAME"); name != "" {
+		return name
+	}
+	return "Project Management"
+}
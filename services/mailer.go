@@ -0,0 +1,265 @@
+package services
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultMailFileDir = "./storage/mails"
+
+type MailAttachment struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
+
+type MailMessage struct {
+	To          []string
+	Subject     string
+	Text        string
+	HTML        string
+	Attachments []MailAttachment
+}
+
+// Mailer sends email messages. The SMTP implementation is used in production;
+// the log and file implementations are meant for development and tests.
+type Mailer interface {
+	Send(msg MailMessage) error
+}
+
+type smtpMailer struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+func NewSMTPMailer(host, port, username, password, from string) Mailer {
+	return &smtpMailer{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+// NewMailerFromEnv builds the mailer selected by MAILER_DRIVER: "smtp"
+// (default, configured by SMTP_* variables), "log" or "file" (writes .eml
+// files to MAIL_FILE_DIR).
+func NewMailerFromEnv() Mailer {
+	switch strings.ToLower(os.Getenv("MAILER_DRIVER")) {
+	case "log":
+		return NewLogMailer(mailFrom())
+	case "file":
+		dir := os.Getenv("MAIL_FILE_DIR")
+		if dir == "" {
+			dir = defaultMailFileDir
+		}
+		return NewFileMailer(dir, mailFrom())
+	}
+
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	return NewSMTPMailer(
+		os.Getenv("SMTP_HOST"),
+		port,
+		os.Getenv("SMTP_USERNAME"),
+		os.Getenv("SMTP_PASSWORD"),
+		os.Getenv("SMTP_FROM"),
+	)
+}
+
+func mailFrom() string {
+	if from := os.Getenv("SMTP_FROM"); from != "" {
+		return from
+	}
+	return "no-reply@localhost"
+}
+
+func (m *smtpMailer) Send(msg MailMessage) error {
+	if m.host == "" {
+		return errors.New("SMTP_HOST is not configured")
+	}
+	if len(msg.To) == 0 {
+		return errors.New("email has no recipients")
+	}
+
+	body, err := buildMIMEMessage(m.from, msg)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	return smtp.SendMail(m.host+":"+m.port, auth, m.from, msg.To, body)
+}
+
+// logMailer only writes a summary and the plain text body to the log.
+type logMailer struct {
+	from string
+}
+
+func NewLogMailer(from string) Mailer {
+	return &logMailer{from: from}
+}
+
+func (m *logMailer) Send(msg MailMessage) error {
+	if len(msg.To) == 0 {
+		return errors.New("email has no recipients")
+	}
+
+	attachments := make([]string, 0, len(msg.Attachments))
+	for _, attachment := range msg.Attachments {
+		attachments = append(attachments, fmt.Sprintf("%s (%d bytes)", attachment.FileName, len(attachment.Data)))
+	}
+
+	log.Printf("[Mailer] From: %s To: %s Subject: %q Attachments: [%s]\n%s",
+		m.from, strings.Join(msg.To, ", "), msg.Subject, strings.Join(attachments, ", "), msg.Text)
+	return nil
+}
+
+// fileMailer writes every message as a complete .eml file, which can be
+// opened in a mail client to check the HTML rendering.
+type fileMailer struct {
+	dir  string
+	from string
+	mu   sync.Mutex
+	seq  int
+}
+
+func NewFileMailer(dir, from string) Mailer {
+	return &fileMailer{dir: dir, from: from}
+}
+
+func (m *fileMailer) Send(msg MailMessage) error {
+	if len(msg.To) == 0 {
+		return errors.New("email has no recipients")
+	}
+
+	body, err := buildMIMEMessage(m.from, msg)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.dir, 0755); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.seq++
+	name := fmt.Sprintf("%s_%04d.eml", time.Now().Format("20060102_150405"), m.seq)
+	m.mu.Unlock()
+
+	return os.WriteFile(filepath.Join(m.dir, name), body, 0644)
+}
+
+func buildMIMEMessage(from string, msg MailMessage) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	mixed := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", mixed.Boundary())
+
+	alternative := &bytes.Buffer{}
+	altWriter := multipart.NewWriter(alternative)
+
+	text := msg.Text
+	if text == "" && msg.HTML == "" {
+		text = " "
+	}
+	if text != "" {
+		if err := writeMIMEPart(altWriter, "text/plain; charset=utf-8", []byte(text)); err != nil {
+			return nil, err
+		}
+	}
+	if msg.HTML != "" {
+		if err := writeMIMEPart(altWriter, "text/html; charset=utf-8", []byte(msg.HTML)); err != nil {
+			return nil, err
+		}
+	}
+	if err := altWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	altPart, err := mixed.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"multipart/alternative; boundary=" + altWriter.Boundary()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := altPart.Write(alternative.Bytes()); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range msg.Attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		part, err := mixed.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeBase64Lines(part, attachment.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mixed.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeMIMEPart(w *multipart.Writer, contentType string, data []byte) error {
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return err
+	}
+	return writeBase64Lines(part, data)
+}
+
+func writeBase64Lines(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		if _, err := w.Write([]byte(encoded[:76] + "\r\n")); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err := w.Write([]byte(encoded + "\r\n"))
+	return err
+}
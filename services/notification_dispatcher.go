@@ -0,0 +1,419 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"project-management-backend/repositories"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	notificationWebhookTimeout = 10 * time.Second
+	outboxPollInterval         = 2 * time.Second
+	outboxBatchSize            = 20
+	outboxClaimTimeout         = 15 * time.Minute
+	retryBaseBackoff           = 30 * time.Second
+	retryMaxBackoff            = time.Hour
+	defaultOutboxMaxAttempts   = 8
+)
+
+// Default pengiriman per detik tiap channel, bisa diubah lewat
+// NOTIFICATION_RATE_<CHANNEL>. Telegram membatasi bot sekitar 30 pesan/detik.
+var defaultOutboxRates = map[string]int{
+	models.NotificationChannelInApp:    0,
+	models.NotificationChannelTelegram: 25,
+	models.NotificationChannelEmail:    5,
+	models.NotificationChannelWebhook:  10,
+}
+
+var errNoDeliveryTarget = errors.New("recipient has no target for this channel")
+
+// NotificationDispatcher stores a notification and queues one outbox entry
+// per channel the recipient enabled for its event type. The outbox worker
+// started by Start delivers the entries with retries.
+type NotificationDispatcher interface {
+	Start()
+	Dispatch(recipient *models.User, notification *models.Notification) error
+
+	// WithTx returns a dispatcher that writes inside tx, so notifications
+	// are only queued when the triggering change commits.
+	WithTx(tx *gorm.DB) NotificationDispatcher
+}
+
+type notificationDispatcher struct {
+	repo             repositories.NotificationRepository
+	outboxRepo       repositories.NotificationOutboxRepository
+	webSocketService WebSocketService
+	telegramService  TelegramService
+	mailer           Mailer
+	client           *http.Client
+	maxAttempts      int
+	wake             map[string]chan struct{}
+	inTx             bool
+}
+
+func NewNotificationDispatcher(repo repositories.NotificationRepository, outboxRepo repositories.NotificationOutboxRepository, webSocketService WebSocketService, telegramService TelegramService, mailer Mailer) NotificationDispatcher {
+	maxAttempts := defaultOutboxMaxAttempts
+	if n, err := strconv.Atoi(os.Getenv("NOTIFICATION_MAX_ATTEMPTS")); err == nil && n > 0 {
+		maxAttempts = n
+	}
+
+	wake := make(map[string]chan struct{}, len(models.NotificationChannels))
+	for _, channel := range models.NotificationChannels {
+		wake[channel] = make(chan struct{}, 1)
+	}
+
+	return &notificationDispatcher{
+		repo:             repo,
+		outboxRepo:       outboxRepo,
+		webSocketService: webSocketService,
+		telegramService:  telegramService,
+		mailer:           mailer,
+		client:           newOutboundHTTPClient(notificationWebhookTimeout),
+		maxAttempts:      maxAttempts,
+		wake:             wake,
+	}
+}
+
+func (d *notificationDispatcher) WithTx(tx *gorm.DB) NotificationDispatcher {
+	bound := *d
+	bound.repo = d.repo.WithTx(tx)
+	bound.outboxRepo = d.outboxRepo.WithTx(tx)
+	bound.inTx = true
+	return &bound
+}
+
+func (d *notificationDispatcher) Dispatch(recipient *models.User, notification *models.Notification) error {
+	event := models.NotificationEvent(notification.Type)
+	prefs := recipient.NotificationPreferences
+	notification.UserID = recipient.ID
+
+	// Notifikasi selalu disimpan sebagai riwayat. Jika in-app dimatikan,
+	// langsung ditandai sudah dibaca.
+	if !prefs.Enabled(event, models.NotificationChannelInApp) {
+		now := time.Now()
+		notification.ReadAt = &now
+	}
+
+	actor := notification.Actor
+	notification.Actor = nil
+	if err := d.repo.Create(notification); err != nil {
+		return err
+	}
+	notification.Actor = actor
+
+	now := time.Now()
+	for _, channel := range prefs.Channels(event) {
+		if !hasDeliveryTarget(recipient, channel) {
+			continue
+		}
+		entry := &models.NotificationOutbox{
+			NotificationID: notification.ID,
+			UserID:         recipient.ID,
+			Channel:        channel,
+			Status:         models.OutboxPending,
+			NextAttemptAt:  now,
+		}
+		if err := d.outboxRepo.Create(entry); err != nil {
+			return err
+		}
+		// Di dalam transaksi entry belum terlihat worker; poll berikutnya
+		// yang akan mengambilnya setelah commit
+		if !d.inTx {
+			d.signal(channel)
+		}
+	}
+	return nil
+}
+
+// Start launches one delivery worker per channel so a slow channel does not
+// hold up the others.
+func (d *notificationDispatcher) Start() {
+	for _, channel := range models.NotificationChannels {
+		go d.worker(channel, newRateLimiter(outboxRate(channel)))
+	}
+	go d.requeueStale()
+}
+
+// requeueStale periodically returns entries whose worker stopped mid-delivery
+// to the queue. Claims younger than outboxClaimTimeout are left alone because
+// another instance may still be delivering them.
+func (d *notificationDispatcher) requeueStale() {
+	ticker := time.NewTicker(outboxClaimTimeout / 3)
+	defer ticker.Stop()
+
+	for {
+		if err := d.outboxRepo.ResetProcessing(time.Now().Add(-outboxClaimTimeout)); err != nil {
+			log.Printf("[Outbox] Failed to requeue stale entries: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
+func (d *notificationDispatcher) signal(channel string) {
+	select {
+	case d.wake[channel] <- struct{}{}:
+	default:
+	}
+}
+
+func (d *notificationDispatcher) worker(channel string, limiter *rateLimiter) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+		case <-d.wake[channel]:
+		}
+
+		for {
+			entries, err := d.outboxRepo.ClaimDue(channel, time.Now(), outboxBatchSize)
+			if err != nil {
+				log.Printf("[Outbox] Failed to claim %s entries: %v", channel, err)
+				break
+			}
+			if len(entries) == 0 {
+				break
+			}
+			for i := range entries {
+				limiter.Wait()
+				d.process(&entries[i])
+			}
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(outboxPollInterval)
+	}
+}
+
+func (d *notificationDispatcher) process(entry *models.NotificationOutbox) {
+	err := d.deliver(entry)
+	now := time.Now()
+	if err == nil {
+		if err := d.outboxRepo.MarkSent(entry.ID, now); err != nil {
+			log.Printf("[Outbox] Failed to mark entry %d as sent: %v", entry.ID, err)
+		}
+		return
+	}
+
+	attempts := entry.Attempts + 1
+	dead := attempts >= d.maxAttempts || errors.Is(err, errNoDeliveryTarget)
+	if dead {
+		log.Printf("[Outbox] %s delivery %d moved to dead letter after %d attempts: %v", entry.Channel, entry.ID, attempts, err)
+	}
+	if err := d.outboxRepo.MarkFailed(entry.ID, attempts, now.Add(retryBackoff(attempts)), err.Error(), dead); err != nil {
+		log.Printf("[Outbox] Failed to record failure of entry %d: %v", entry.ID, err)
+	}
+}
+
+func (d *notificationDispatcher) deliver(entry *models.NotificationOutbox) error {
+	recipient := &entry.User
+	notification := &entry.Notification
+	if !hasDeliveryTarget(recipient, entry.Channel) {
+		return errNoDeliveryTarget
+	}
+
+	switch entry.Channel {
+	case models.NotificationChannelInApp:
+		return d.sendInApp(recipient, notification)
+	case models.NotificationChannelTelegram:
+		return d.sendTelegram(recipient, notification)
+	case models.NotificationChannelEmail:
+		return d.sendEmail(recipient, notification)
+	case models.NotificationChannelWebhook:
+		return d.sendWebhook(recipient, notification)
+	}
+	return fmt.Errorf("unknown channel %s", entry.Channel)
+}
+
+func (d *notificationDispatcher) sendInApp(recipient *models.User, notification *models.Notification) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"type":         "notification",
+		"notification": notification,
+	})
+	if err != nil {
+		return err
+	}
+	d.webSocketService.SendToUser(recipient.ID, payload)
+	return nil
+}
+
+func (d *notificationDispatcher) sendTelegram(recipient *models.User, notification *models.Notification) error {
+	message := notification.Title + "\n" + notification.Message
+	return d.telegramService.SendNotification(*recipient.TelegramChatID, message)
+}
+
+func (d *notificationDispatcher) sendEmail(recipient *models.User, notification *models.Notification) error {
+	msg, err := RenderMail(MailTemplateNotification, userLanguage(recipient), MailData{
+		"Name":    recipient.Name,
+		"Title":   notification.Title,
+		"Message": notification.Message,
+	})
+	if err != nil {
+		return err
+	}
+	msg.To = []string{recipient.Email}
+	return d.mailer.Send(msg)
+}
+
+func (d *notificationDispatcher) sendWebhook(recipient *models.User, notification *models.Notification) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"event":        models.NotificationEvent(notification.Type),
+		"notification": notification,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := d.client.Post(*recipient.NotificationWebhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func hasDeliveryTarget(recipient *models.User, channel string) bool {
+	switch channel {
+	case models.NotificationChannelInApp:
+		return true
+	case models.NotificationChannelTelegram:
+		return recipient.TelegramChatID != nil && *recipient.TelegramChatID != ""
+	case models.NotificationChannelEmail:
+		return recipient.Email != ""
+	case models.NotificationChannelWebhook:
+		return recipient.NotificationWebhookURL != nil && *recipient.NotificationWebhookURL != ""
+	}
+	return false
+}
+
+// retryBackoff doubles the delay after every failed attempt, starting at
+// retryBaseBackoff and capped at retryMaxBackoff, with up to 10% jitter.
+func retryBackoff(attempts int) time.Duration {
+	delay := retryMaxBackoff
+	if attempts < 20 {
+		if d := retryBaseBackoff << (attempts - 1); d < retryMaxBackoff {
+			delay = d
+		}
+	}
+	return delay + time.Duration(rand.Int63n(int64(delay)/10+1))
+}
+
+func outboxRate(channel string) int {
+	if n, err := strconv.Atoi(os.Getenv("NOTIFICATION_RATE_" + strings.ToUpper(channel))); err == nil && n >= 0 {
+		return n
+	}
+	return defaultOutboxRates[channel]
+}
+
+// rateLimiter spaces calls to Wait so at most perSecond pass each second.
+// Zero means unlimited.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(perSecond int) *rateLimiter {
+	limiter := &rateLimiter{}
+	if perSecond > 0 {
+		limiter.interval = time.Second / time.Duration(perSecond)
+	}
+	return limiter
+}
+
+func (l *rateLimiter) Wait() {
+	if l.interval == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	time.Sleep(wait)
+}
+
+// ParseNotificationPreferences decodes and validates the JSON sent by the
+// profile form, e.g. {"assigned": ["in_app", "email"], "overdue": []}.
+func ParseNotificationPreferences(raw string) (models.NotificationPreferences, error) {
+	var prefs models.NotificationPreferences
+	if err := json.Unmarshal([]byte(raw), &prefs); err != nil {
+		return nil, i18n.NewError(i18n.CodeNotificationPrefsInvalid)
+	}
+
+	for event, channels := range prefs {
+		if !containsString(models.NotificationEvents, event) {
+			return nil, i18n.NewError(i18n.CodeNotificationEventUnknown, event)
+		}
+
+		unique := make([]string, 0, len(channels))
+		for _, channel := range channels {
+			if !containsString(models.NotificationChannels, channel) {
+				return nil, i18n.NewError(i18n.CodeNotificationChannelUnknown, channel)
+			}
+			if !containsString(unique, channel) {
+				unique = append(unique, channel)
+			}
+		}
+		prefs[event] = unique
+	}
+
+	return prefs, nil
+}
+
+// ValidateWebhookURL checks a user-supplied webhook URL. Hosts resolving to
+// internal addresses are rejected; the HTTP client checks again when it
+// connects.
+func ValidateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return i18n.NewError(i18n.CodeWebhookURLInvalid)
+	}
+
+	if err := resolveOutboundHost(u.Hostname()); err != nil {
+		if errors.Is(err, errOutboundAddressBlocked) {
+			return i18n.NewError(i18n.CodeWebhookURLBlocked)
+		}
+		return i18n.NewError(i18n.CodeWebhookURLUnresolvable, u.Hostname())
+	}
+	return nil
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"project-management-backend/repositories"
+	"time"
+)
+
+// NotificationOutboxService lets admins inspect queued deliveries and put
+// dead-lettered ones back on the queue.
+type NotificationOutboxService interface {
+	List(status string, limit, offset int) ([]models.NotificationOutbox, int64, error)
+	Retry(entryID uint) error
+	RetryAllDead() (int64, error)
+}
+
+type notificationOutboxService struct {
+	repo repositories.NotificationOutboxRepository
+}
+
+func NewNotificationOutboxService(repo repositories.NotificationOutboxRepository) NotificationOutboxService {
+	return &notificationOutboxService{repo: repo}
+}
+
+func (s *notificationOutboxService) List(status string, limit, offset int) ([]models.NotificationOutbox, int64, error) {
+	switch status {
+	case "", models.OutboxPending, models.OutboxProcessing, models.OutboxSent, models.OutboxDead:
+	default:
+		return nil, 0, i18n.NewError(i18n.CodeOutboxStatusInvalid, status)
+	}
+	return s.repo.GetByStatus(status, limit, offset)
+}
+
+func (s *notificationOutboxService) Retry(entryID uint) error {
+	found, err := s.repo.Retry(entryID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return i18n.NewError(i18n.CodeOutboxEntryNotFound)
+	}
+	return nil
+}
+
+func (s *notificationOutboxService) RetryAllDead() (int64, error) {
+	return s.repo.RetryAllDead(time.Now())
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"project-management-backend/repositories"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultNotificationLimit = 20
+	maxNotificationLimit     = 100
+)
+
+type NotificationService interface {
+	NotifyTaskAssigned(task *models.Task, assignee *models.User, actor *models.User) error
+	NotifyStatusChanged(task *models.Task, oldStatus, newStatus string, actor *models.User) error
+	NotifyMentions(task *models.Task, oldText, newText string, actor *models.User) error
+	NotifyDueSoon(task *models.Task) error
+	NotifyOverdue(task *models.Task, projectAdmins []models.User) error
+	NotifyOverdueEscalation(task *models.Task, projectAdmins []models.User) error
+	NotifyReportReady(job *models.ReportJob) error
+	List(user *models.User, unreadOnly bool, limit, offset int) ([]models.Notification, int64, error)
+	UnreadCount(user *models.User) (int64, error)
+	MarkRead(notificationID uint, user *models.User) error
+	MarkAllRead(user *models.User) (int64, error)
+
+	// WithTx returns a service whose notifications are queued inside tx.
+	WithTx(tx *gorm.DB) NotificationService
+}
+
+type notificationService struct {
+	repo        repositories.NotificationRepository
+	projectRepo repositories.ProjectRepository
+	userRepo    repositories.UserRepository
+	dispatcher  NotificationDispatcher
+}
+
+func NewNotificationService(repo repositories.NotificationRepository, projectRepo repositories.ProjectRepository, userRepo repositories.UserRepository, dispatcher NotificationDispatcher) NotificationService {
+	return &notificationService{
+		repo:        repo,
+		projectRepo: projectRepo,
+		userRepo:    userRepo,
+		dispatcher:  dispatcher,
+	}
+}
+
+func (s *notificationService) WithTx(tx *gorm.DB) NotificationService {
+	return &notificationService{
+		repo:        s.repo.WithTx(tx),
+		projectRepo: s.projectRepo,
+		userRepo:    s.userRepo,
+		dispatcher:  s.dispatcher.WithTx(tx),
+	}
+}
+
+func (s *notificationService) NotifyTaskAssigned(task *models.Task, assignee *models.User, actor *models.User) error {
+	if assignee == nil || (actor != nil && assignee.ID == actor.ID) {
+		return nil
+	}
+
+	lang := userLanguage(assignee)
+	return s.notify(assignee, models.NotificationTaskAssigned, task, actor,
+		i18n.T(lang, i18n.LabelNotifAssignedTitle),
+		i18n.T(lang, i18n.LabelNotifAssignedMessage, actorName(actor), task.Title, i18n.FormatDateTime(lang, task.DueDate)))
+}
+
+func (s *notificationService) NotifyStatusChanged(task *models.Task, oldStatus, newStatus string, actor *models.User) error {
+	for _, member := range task.Members {
+		if actor != nil && member.UserID == actor.ID {
+			continue
+		}
+
+		lang := userLanguage(&member.User)
+		if err := s.notify(&member.User, models.NotificationTaskStatusChanged, task, actor,
+			i18n.T(lang, i18n.LabelNotifStatusTitle),
+			i18n.T(lang, i18n.LabelNotifStatusMessage, actorName(actor), task.Title, oldStatus, newStatus)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NotifyMentions notifies project members mentioned as "@Full Name" in
+// newText. Mentions already present in oldText are not notified again.
+func (s *notificationService) NotifyMentions(task *models.Task, oldText, newText string, actor *models.User) error {
+	if !strings.Contains(newText, "@") {
+		return nil
+	}
+
+	members, err := s.projectRepo.GetMembers(task.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	oldLower := strings.ToLower(oldText)
+	newLower := strings.ToLower(newText)
+	for _, member := range members {
+		if member.User.Name == "" || (actor != nil && member.UserID == actor.ID) {
+			continue
+		}
+
+		mention := "@" + strings.ToLower(member.User.Name)
+		if !strings.Contains(newLower, mention) || strings.Contains(oldLower, mention) {
+			continue
+		}
+
+		lang := userLanguage(&member.User)
+		if err := s.notify(&member.User, models.NotificationMention, task, actor,
+			i18n.T(lang, i18n.LabelNotifMentionTitle),
+			i18n.T(lang, i18n.LabelNotifMentionMessage, actorName(actor), task.Title)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *notificationService) NotifyDueSoon(task *models.Task) error {
+	for _, member := range task.Members {
+		lang := userLanguage(&member.User)
+		if err := s.notify(&member.User, models.NotificationTaskDueSoon, task, nil,
+			i18n.T(lang, i18n.LabelNotifDueSoonTitle),
+			i18n.T(lang, i18n.LabelNotifDueSoonMessage, task.Title, i18n.FormatDateTime(lang, task.DueDate))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NotifyOverdue notifies the task members and the project admins once a
+// task passes its due date.
+func (s *notificationService) NotifyOverdue(task *models.Task, projectAdmins []models.User) error {
+	recipients := make([]models.User, 0, len(task.Members)+len(projectAdmins))
+	for _, member := range task.Members {
+		recipients = append(recipients, member.User)
+	}
+	recipients = append(recipients, projectAdmins...)
+
+	seen := make(map[uint]bool)
+	for i := range recipients {
+		recipient := &recipients[i]
+		if seen[recipient.ID] {
+			continue
+		}
+		seen[recipient.ID] = true
+
+		lang := userLanguage(recipient)
+		if err := s.notify(recipient, models.NotificationTaskOverdue, task, nil,
+			i18n.T(lang, i18n.LabelNotifOverdueTitle),
+			i18n.T(lang, i18n.LabelNotifOverdueMessage, task.Title, i18n.FormatDateTime(lang, task.DueDate))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *notificationService) NotifyOverdueEscalation(task *models.Task, projectAdmins []models.User) error {
+	for i := range projectAdmins {
+		admin := &projectAdmins[i]
+		lang := userLanguage(admin)
+		if err := s.notify(admin, models.NotificationTaskEscalated, task, nil,
+			i18n.T(lang, i18n.LabelNotifEscalatedTitle),
+			i18n.T(lang, i18n.LabelNotifEscalatedMessage, task.Title, i18n.FormatDateTime(lang, task.DueDate), taskAssigneeNames(task))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *notificationService) NotifyReportReady(job *models.ReportJob) error {
+	recipient, err := s.userRepo.GetByID(job.RequestedBy)
+	if err != nil {
+		return err
+	}
+
+	lang := userLanguage(recipient)
+	return s.dispatcher.Dispatch(recipient, &models.Notification{
+		Type:      models.NotificationReportReady,
+		Title:     i18n.T(lang, i18n.LabelNotifReportReadyTitle),
+		Message:   i18n.T(lang, i18n.LabelNotifReportReadyMessage, job.FileName),
+		ProjectID: &job.ProjectID,
+	})
+}
+
+func (s *notificationService) List(user *models.User, unreadOnly bool, limit, offset int) ([]models.Notification, int64, error) {
+	if limit <= 0 {
+		limit = defaultNotificationLimit
+	}
+	if limit > maxNotificationLimit {
+		limit = maxNotificationLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.repo.GetByUserID(user.ID, unreadOnly, limit, offset)
+}
+
+func (s *notificationService) UnreadCount(user *models.User) (int64, error) {
+	return s.repo.CountUnread(user.ID)
+}
+
+func (s *notificationService) MarkRead(notificationID uint, user *models.User) error {
+	found, err := s.repo.MarkRead(notificationID, user.ID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return i18n.NewError(i18n.CodeNotificationNotFound)
+	}
+	return nil
+}
+
+func (s *notificationService) MarkAllRead(user *models.User) (int64, error) {
+	return s.repo.MarkAllRead(user.ID, time.Now())
+}
+
+func (s *notificationService) notify(recipient *models.User, notificationType string, task *models.Task, actor *models.User, title, message string) error {
+	notification := &models.Notification{
+		Type:      notificationType,
+		Title:     title,
+		Message:   message,
+		TaskID:    &task.ID,
+		ProjectID: &task.ProjectID,
+	}
+	if actor != nil {
+		notification.ActorID = &actor.ID
+		notification.Actor = actor
+	}
+
+	return s.dispatcher.Dispatch(recipient, notification)
+}
+
+func userLanguage(user *models.User) string {
+	if user != nil && user.Language != nil {
+		if lang := i18n.Normalize(*user.Language); lang != "" {
+			return lang
+		}
+	}
+	return i18n.DefaultLang
+}
+
+func taskAssigneeNames(task *models.Task) string {
+	if len(task.Members) == 0 {
+		return "-"
+	}
+	names := make([]string, 0, len(task.Members))
+	for _, member := range task.Members {
+		names = append(names, member.User.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func actorName(actor *models.User) string {
+	if actor == nil {
+		return "System"
+	}
+	return actor.Name
+}
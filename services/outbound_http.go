@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const outboundDialTimeout = 10 * time.Second
+
+var errOutboundAddressBlocked = errors.New("destination address is not allowed")
+
+// newOutboundHTTPClient returns the client used for user-configured URLs
+// (notification webhooks, outgoing webhooks and chat integrations). The
+// address is checked when the connection is made, after DNS resolution, so
+// a hostname that later resolves to an internal address is still refused.
+func newOutboundHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: outboundDialTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isBlockedOutboundIP(ip) {
+				return errOutboundAddressBlocked
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// Proxy akan membuat pengecekan alamat di atas tidak berarti
+	transport.Proxy = nil
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// isBlockedOutboundIP reports whether ip is loopback, private, link-local
+// (including cloud metadata endpoints) or unspecified.
+// WEBHOOK_ALLOW_PRIVATE_NETWORKS=true allows private ranges for self-hosted
+// chat servers; loopback, link-local and unspecified stay blocked.
+func isBlockedOutboundIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return true
+	}
+	if ip.IsPrivate() || sharedAddressSpace.Contains(ip) {
+		return !allowPrivateOutbound()
+	}
+	return false
+}
+
+// 100.64.0.0/10 (carrier-grade NAT) tidak termasuk IsPrivate
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func allowPrivateOutbound() bool {
+	return strings.EqualFold(os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS"), "true")
+}
+
+// resolveOutboundHost checks every address host resolves to.
+func resolveOutboundHost(host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if isBlockedOutboundIP(ip) {
+			return errOutboundAddressBlocked
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), outboundDialTimeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if isBlockedOutboundIP(addr.IP) {
+			return errOutboundAddressBlocked
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"net/url"
+	"os"
+	"project-management-backend/models"
+	"strings"
+	"time"
+)
+
+// PasswordResetSender delivers a password reset token to its user. The
+// default implementation sends an email; other channels can be plugged in
+// through NewAuthService.
+type PasswordResetSender interface {
+	SendPasswordReset(user *models.User, token string, expiresIn time.Duration) error
+}
+
+type mailPasswordResetSender struct {
+	mailer Mailer
+}
+
+func NewMailPasswordResetSender(mailer Mailer) PasswordResetSender {
+	return &mailPasswordResetSender{mailer: mailer}
+}
+
+func (s *mailPasswordResetSender) SendPasswordReset(user *models.User, token string, expiresIn time.Duration) error {
+	msg, err := RenderMail(MailTemplatePasswordReset, userLanguage(user), MailData{
+		"Name":             user.Name,
+		"ResetURL":         passwordResetURL(token),
+		"ExpiresInMinutes": int(expiresIn.Minutes()),
+	})
+	if err != nil {
+		return err
+	}
+	msg.To = []string{user.Email}
+	return s.mailer.Send(msg)
+}
+
+// passwordResetURL builds the frontend link for token. PASSWORD_RESET_URL
+// overrides the default <APP_URL>/reset-password page.
+func passwordResetURL(token string) string {
+	base := os.Getenv("PASSWORD_RESET_URL")
+	if base == "" {
+		base = strings.TrimRight(os.Getenv("APP_URL"), "/") + "/reset-password"
+	}
+
+	separator := "?"
+	if strings.Contains(base, "?") {
+		separator = "&"
+	}
+	return base + separator + "token=" + url.QueryEscape(token)
+}
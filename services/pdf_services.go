@@ -3,38 +3,50 @@ package services
 import (
 	"bytes"
 	"project-management-backend/models"
+	"project-management-backend/repositories"
 	pdf_templates "project-management-backend/services/pdf_templates"
 
 	"github.com/jung-kurt/gofpdf"
 )
 
 type PDFService interface {
-	GenerateMonitoringReportPDF(project *models.Project, tasks []models.TaskWithHistory, pic models.User, period string) (*gofpdf.Fpdf, error)
-	GenerateDailyReportPDF(project *models.Project, items []models.DailyActivityItem, pic models.User, date string) (*gofpdf.Fpdf, error)
-	GenerateWeeklyReportPDF(project *models.Project, agendaItems []models.AgendaItem, pic models.User, period string) (*gofpdf.Fpdf, error)
-	CreateAttendanceReportPDF(attendances []models.AttendanceExportResponse, workspaceName string, date string) ([]byte, error)
+	GenerateMonitoringReportPDF(project *models.Project, tasks []models.TaskWithHistory, pic models.User, period string, lang string) (*gofpdf.Fpdf, error)
+	GenerateDailyReportPDF(project *models.Project, items []models.DailyActivityItem, pic models.User, date string, lang string) (*gofpdf.Fpdf, error)
+	GenerateWeeklyReportPDF(project *models.Project, agendaItems []models.AgendaItem, pic models.User, period string, lang string) (*gofpdf.Fpdf, error)
+	CreateAttendanceReportPDF(attendances []models.AttendanceExportResponse, workspace *models.Workspace, date string, lang string) ([]byte, error)
 }
 
-type pdfService struct{}
+type pdfService struct {
+	brandingRepo repositories.WorkspaceBrandingRepository
+}
+
+func NewPDFService(brandingRepo repositories.WorkspaceBrandingRepository) PDFService {
+	return &pdfService{brandingRepo: brandingRepo}
+}
 
-func NewPDFService() PDFService {
-	return &pdfService{}
+// branding returns the workspace branding, or nil so templates fall back to the defaults.
+func (s *pdfService) branding(workspaceID uint) *models.WorkspaceBranding {
+	branding, err := s.brandingRepo.GetByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil
+	}
+	return branding
 }
 
-func (s *pdfService) GenerateMonitoringReportPDF(project *models.Project, tasks []models.TaskWithHistory, pic models.User, period string) (*gofpdf.Fpdf, error) {
-	return pdf_templates.GenerateMonitoringReportPDF(project, tasks, pic, period)
+func (s *pdfService) GenerateMonitoringReportPDF(project *models.Project, tasks []models.TaskWithHistory, pic models.User, period string, lang string) (*gofpdf.Fpdf, error) {
+	return pdf_templates.GenerateMonitoringReportPDF(project, tasks, pic, period, s.branding(project.WorkspaceID), lang)
 }
 
-func (s *pdfService) GenerateDailyReportPDF(project *models.Project, items []models.DailyActivityItem, pic models.User, date string) (*gofpdf.Fpdf, error) {
-	return pdf_templates.GenerateDailyReport(project, items, pic, date)
+func (s *pdfService) GenerateDailyReportPDF(project *models.Project, items []models.DailyActivityItem, pic models.User, date string, lang string) (*gofpdf.Fpdf, error) {
+	return pdf_templates.GenerateDailyReport(project, items, pic, date, s.branding(project.WorkspaceID), lang)
 }
 
-func (s *pdfService) GenerateWeeklyReportPDF(project *models.Project, agendaItems []models.AgendaItem, pic models.User, period string) (*gofpdf.Fpdf, error) {
-	return pdf_templates.GenerateWeeklyReportPDF(project, agendaItems, pic, period)
+func (s *pdfService) GenerateWeeklyReportPDF(project *models.Project, agendaItems []models.AgendaItem, pic models.User, period string, lang string) (*gofpdf.Fpdf, error) {
+	return pdf_templates.GenerateWeeklyReportPDF(project, agendaItems, pic, period, s.branding(project.WorkspaceID), lang)
 }
 
-func (s *pdfService) CreateAttendanceReportPDF(attendances []models.AttendanceExportResponse, workspaceName string, date string) ([]byte, error) {
-	pdf, err := pdf_templates.GenerateAttendanceReport(attendances, workspaceName, date)
+func (s *pdfService) CreateAttendanceReportPDF(attendances []models.AttendanceExportResponse, workspace *models.Workspace, date string, lang string) ([]byte, error) {
+	pdf, err := pdf_templates.GenerateAttendanceReport(attendances, workspace.Name, date, s.branding(workspace.ID), lang)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	DefaultLogoPath         = "assets/logo.png"
+	DefaultOrganisationName = "PT Asta Digital Agency"
+	DefaultAddressLine      = "www.astadigitalagency | Imogiri Timur, Gg. Tobanan V | D.I.Yogyakarta"
+)
+
+// Branding is the resolved branding used while drawing a report.
+type Branding struct {
+	LogoPath         string
+	OrganisationName string
+	AddressLine      string
+	FooterText       string
+	AccentR          int
+	AccentG          int
+	AccentB          int
+}
+
+// ResolveBranding fills in defaults for anything the workspace has not set.
+func ResolveBranding(b *models.WorkspaceBranding) Branding {
+	brand := Branding{
+		LogoPath:         DefaultLogoPath,
+		OrganisationName: DefaultOrganisationName,
+		AddressLine:      DefaultAddressLine,
+	}
+	if b == nil {
+		return brand
+	}
+
+	if b.LogoURL != nil && *b.LogoURL != "" {
+		logoPath := "." + *b.LogoURL
+		if _, err := os.Stat(logoPath); err == nil {
+			brand.LogoPath = logoPath
+		}
+	}
+	if b.OrganisationName != "" {
+		brand.OrganisationName = b.OrganisationName
+	}
+	if b.AddressLine != "" {
+		brand.AddressLine = b.AddressLine
+	}
+	brand.FooterText = b.FooterText
+	if r, g, bl, err := ParseHexColor(b.AccentColor); err == nil {
+		brand.AccentR, brand.AccentG, brand.AccentB = r, g, bl
+	}
+
+	return brand
+}
+
+// ParseHexColor parses a #RRGGBB color.
+func ParseHexColor(hex string) (int, int, int, error) {
+	var r, g, b int
+	if len(hex) != 7 || !strings.HasPrefix(hex, "#") {
+		return 0, 0, 0, fmt.Errorf("invalid color %q, expected #RRGGBB", hex)
+	}
+	if _, err := fmt.Sscanf(hex[1:], "%02x%02x%02x", &r, &g, &b); err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid color %q, expected #RRGGBB", hex)
+	}
+	return r, g, b, nil
+}
+
+// drawBrandHeader draws the logo, title, subtitle and organisation lines at
+// the top of the current page.
+func drawBrandHeader(pdf *gofpdf.Fpdf, brand Branding, title string, subtitle string) {
+	drawLogo(pdf, brand.LogoPath)
+
+	pdf.SetXY(45, 15)
+	pdf.SetFont("Arial", "B", 16)
+	pdf.SetTextColor(brand.AccentR, brand.AccentG, brand.AccentB)
+	pdf.Cell(0, 8, title)
+	pdf.SetTextColor(0, 0, 0)
+
+	pdf.Ln(6)
+	pdf.SetX(45)
+	pdf.SetFont("Arial", "", 10)
+	pdf.Cell(0, 6, subtitle)
+
+	pdf.Ln(5)
+	pdf.SetX(45)
+	pdf.SetFont("Arial", "", 8)
+	pdf.Cell(0, 5, brand.OrganisationName)
+
+	pdf.Ln(4)
+	pdf.SetX(45)
+	pdf.Cell(0, 5, brand.AddressLine)
+
+	pdf.SetDrawColor(brand.AccentR, brand.AccentG, brand.AccentB)
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	pdf.Line(left, 40, pageWidth-right, 40)
+	pdf.SetDrawColor(0, 0, 0)
+
+	pdf.SetY(45)
+}
+
+// drawLogo fits the logo into a 25x22 mm box at the top-left corner. A logo
+// gofpdf cannot read is replaced by the default logo, so it does not leave
+// the document in an error state.
+func drawLogo(pdf *gofpdf.Fpdf, logoPath string) {
+	info := pdf.RegisterImage(logoPath, "")
+	if pdf.Err() && logoPath != DefaultLogoPath {
+		pdf.ClearError()
+		logoPath = DefaultLogoPath
+		info = pdf.RegisterImage(logoPath, "")
+	}
+
+	width, height := 25.0, 0.0
+	if info != nil {
+		if w, h := info.Extent(); w > 0 && h/w*width > 22 {
+			width, height = 0, 22
+		}
+	}
+	pdf.Image(logoPath, 15, 15, width, height, false, "", 0, "")
+}
+
+// ValidateLogo reports whether gofpdf can embed the image, e.g. it rejects
+// interlaced PNGs that image.DecodeConfig accepts. imageType is "png" or
+// "jpg".
+func ValidateLogo(r io.Reader, imageType string) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.RegisterImageOptionsReader("logo", gofpdf.ImageOptions{ImageType: imageType}, r)
+	return pdf.Error()
+}
+
+// applyBrandFooter prints the workspace footer text at the bottom of every page.
+func applyBrandFooter(pdf *gofpdf.Fpdf, brand Branding) {
+	if brand.FooterText == "" {
+		return
+	}
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Arial", "I", 8)
+		pdf.SetTextColor(110, 110, 110)
+		pdf.CellFormat(0, 6, brand.FooterText, "", 0, "C", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	})
+}
+
+// GenerateBrandingPreview renders a single sample page using the branding.
+func GenerateBrandingPreview(workspace *models.Workspace, branding *models.WorkspaceBranding, lang string) (*gofpdf.Fpdf, error) {
+	brand := ResolveBranding(branding)
+
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	applyBrandFooter(pdf, brand)
+	pdf.AddPage()
+
+	drawBrandHeader(pdf, brand, i18n.T(lang, i18n.LabelPreviewTitle), fmt.Sprintf("%s - %s", i18n.T(lang, i18n.LabelDivision), workspace.Name))
+
+	pdf.SetFont("Arial", "B", 10)
+	pdf.SetFillColor(240, 240, 240)
+	headers := []string{i18n.T(lang, i18n.LabelNo), i18n.T(lang, i18n.LabelTask), i18n.T(lang, i18n.LabelAssignee), i18n.T(lang, i18n.LabelStatus)}
+	colWidths := []float64{10, 120, 80, 57}
+	for i, h := range headers {
+		pdf.CellFormat(colWidths[i], 10, h, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Arial", "", 9)
+	for i := 1; i <= 3; i++ {
+		pdf.CellFormat(colWidths[0], 8, fmt.Sprintf("%d", i), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths[1], 8, i18n.T(lang, i18n.LabelSampleTask, i), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[2], 8, i18n.T(lang, i18n.LabelSampleMember), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[3], 8, "on_progress", "1", 0, "C", false, 0, "")
+		pdf.Ln(-1)
+	}
+
+	if pdf.Error() != nil {
+		return nil, pdf.Error()
+	}
+	return pdf, nil
+}
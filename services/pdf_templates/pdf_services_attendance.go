@@ -6,65 +6,39 @@ import (
 	"strings"
 	"time"
 
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 
 	"github.com/jung-kurt/gofpdf"
 )
 
-func GenerateAttendanceReport(attendances []models.AttendanceExportResponse, workspaceName string, reportDate string) (*gofpdf.Fpdf, error) {
+func GenerateAttendanceReport(attendances []models.AttendanceExportResponse, workspaceName string, reportDate string, branding *models.WorkspaceBranding, lang string) (*gofpdf.Fpdf, error) {
+	brand := ResolveBranding(branding)
+
 	pdf := gofpdf.New("P", "mm", "A4", "")
+	applyBrandFooter(pdf, brand)
 
 	parsedDate, err := time.Parse("2006-01-02", reportDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse report date: %w", err)
 	}
-	formattedDate := parsedDate.Format("02 January 2006")
+	formattedDate := i18n.FormatLongDate(lang, parsedDate)
 
 	for i, attendance := range attendances {
 		pdf.AddPage()
 
 		// --- HEADER ---
-		pdf.Image("assets/logo.png", 15, 15, 25, 0, false, "", 0, "")
+		drawBrandHeader(pdf, brand, i18n.T(lang, i18n.LabelAttendanceTitle), fmt.Sprintf("%s - %s", i18n.T(lang, i18n.LabelDivision), workspaceName))
 		if pdf.Error() != nil {
 			return nil, fmt.Errorf("failed to add logo image to PDF: %w", pdf.Error())
 		}
-		// Header text
-		pdf.SetXY(45, 15)
-		pdf.SetFont("Arial", "B", 16)
-		pdf.SetTextColor(0, 0, 0)
-		pdf.Cell(140, 8, "LAPORAN ABSENSI HARIAN")
-		pdf.Ln(6)
-
-		pdf.SetX(45)
-		pdf.SetFont("Arial", "", 10)
-		pdf.Cell(140, 6, fmt.Sprintf("Divisi - %s", workspaceName))
-		pdf.Ln(6)
-
-		pdf.SetX(45)
-		pdf.SetFont("Arial", "", 8)
-		pdf.Cell(140, 6, "PT Asta Digital Agency")
-		pdf.Ln(4)
-
-		pdf.SetX(45)
-		pdf.SetFont("Arial", "", 8)
-		pdf.Cell(140, 6, "Imogiri Timur, Gg. Tobanan V, D.I. Yogyakarta")
-		pdf.Ln(4)
-
-		pdf.SetX(45)
-		pdf.SetFont("Arial", "", 8)
-		pdf.Cell(140, 6, "www.astadigitalagency.com")
-		pdf.Ln(15)
-
-		// --- GARIS PEMISAH ---
-		pdf.SetDrawColor(200, 200, 200)
-		pdf.Line(15, pdf.GetY(), 195, pdf.GetY())
-		pdf.Ln(10)
+		pdf.Ln(5)
 
 		// --- DETAIL ABSENSI ---
 		pdf.SetX(15)
 		pdf.SetFont("Arial", "B", 12)
 		pdf.SetFillColor(240, 240, 240)
-		pdf.CellFormat(50, 8, "DATA KARYAWAN", "0", 1, "L", true, 0, "")
+		pdf.CellFormat(50, 8, i18n.T(lang, i18n.LabelEmployeeData), "0", 1, "L", true, 0, "")
 		pdf.Ln(7)
 
 		// --- HELPER MULTIPLY LINES ---
@@ -92,14 +66,14 @@ func GenerateAttendanceReport(attendances []models.AttendanceExportResponse, wor
 
 		pdf.SetX(15)
 		pdf.SetFont("Arial", "B", 12)
-		pdf.Cell(35, 7, "Nama Karyawan ")
+		pdf.Cell(35, 7, i18n.T(lang, i18n.LabelEmployeeName))
 		pdf.SetFont("Arial", "", 11)
 		pdf.Cell(100, 7, fmt.Sprintf("               : %s", attendance.User.Name))
 		pdf.Ln(10)
 
 		pdf.SetX(15)
 		pdf.SetFont("Arial", "B", 12)
-		pdf.Cell(35, 7, "Waktu Absen")
+		pdf.Cell(35, 7, i18n.T(lang, i18n.LabelClockIn))
 		pdf.SetFont("Arial", "", 11)
 		pdf.Cell(100, 7, fmt.Sprintf("               : %s", attendance.ClockIn.Format("15:04:05 WIB")))
 		pdf.Ln(10)
@@ -107,7 +81,7 @@ func GenerateAttendanceReport(attendances []models.AttendanceExportResponse, wor
 		// Kegiatan
 		pdf.SetX(15)
 		pdf.SetFont("Arial", "B", 12)
-		pdf.Cell(40, 10, "Kegiatan yang Dilakukan :")
+		pdf.Cell(40, 10, i18n.T(lang, i18n.LabelActivity))
 		pdf.Ln(8)
 		pdf.SetFont("Arial", "", 11)
 		formattedActivity := formatMultiCellContent(attendance.Activity)
@@ -117,7 +91,7 @@ func GenerateAttendanceReport(attendances []models.AttendanceExportResponse, wor
 		// Kendala
 		pdf.SetX(15)
 		pdf.SetFont("Arial", "B", 12)
-		pdf.Cell(40, 10, "Kendala yang Dihadapi    :")
+		pdf.Cell(40, 10, i18n.T(lang, i18n.LabelObstacle))
 		pdf.Ln(8)
 		pdf.SetFont("Arial", "", 11)
 		obstacleText := "-"
@@ -131,7 +105,7 @@ func GenerateAttendanceReport(attendances []models.AttendanceExportResponse, wor
 		// Bukti Foto
 		pdf.SetX(15)
 		pdf.SetFont("Arial", "B", 12)
-		pdf.Cell(40, 10, "Bukti Foto:")
+		pdf.Cell(40, 10, i18n.T(lang, i18n.LabelPhotoEvidence))
 		pdf.Ln(8)
 
 		if len(attendance.ImageURLs) > 0 {
@@ -149,13 +123,13 @@ func GenerateAttendanceReport(attendances []models.AttendanceExportResponse, wor
 			}
 		} else {
 			pdf.SetFont("Arial", "I", 10)
-			pdf.Cell(40, 10, "(Tidak ada gambar)")
+			pdf.Cell(40, 10, i18n.T(lang, i18n.LabelNoImage))
 		}
 
 		// --- FOOTER HALAMAN ---
 		pdf.SetY(-40)
 		pdf.SetFont("Arial", "I", 8)
-		pdf.CellFormat(0, 10, fmt.Sprintf("Halaman %d dari %d | Diterbitkan: %s", i+1, len(attendances), formattedDate), "", 0, "C", false, 0, "")
+		pdf.CellFormat(0, 10, i18n.T(lang, i18n.LabelAttendancePage, i+1, len(attendances), formattedDate), "", 0, "C", false, 0, "")
 	}
 
 	if pdf.Error() != nil {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 
 	"github.com/jung-kurt/gofpdf"
@@ -14,35 +15,25 @@ func GenerateDailyReport(
 	items []models.DailyActivityItem,
 	pic models.User,
 	period string,
+	branding *models.WorkspaceBranding,
+	lang string,
 ) (*gofpdf.Fpdf, error) {
+	brand := ResolveBranding(branding)
 
 	pdf := gofpdf.New("L", "mm", "A4", "")
 	pdf.SetMargins(15, 15, 15)
 	pdf.SetAutoPageBreak(true, 20)
+	applyBrandFooter(pdf, brand)
 	pdf.AddPage()
 
-	pdf.Image("assets/logo.png", 15, 15, 25, 0, false, "", 0, "")
-	pdf.SetXY(45, 15)
-
-	pdf.SetFont("Arial", "B", 16)
-	pdf.Cell(140, 8, "LAPORAN HASIL KERJA HARIAN")
-	pdf.Ln(6)
-
-	pdf.SetX(45)
-	pdf.SetFont("Arial", "", 10)
-	pdf.Cell(140, 6, fmt.Sprintf("Divisi - %s - Liquid8", project.Workspace.Name))
-	pdf.Ln(4)
-
-	pdf.SetX(45)
-	pdf.Cell(277, 6, "www.astadigitalagency | Imogiri Timur, Gg. Tobanan V | D.I.Yogyakarta")
-	pdf.Ln(15)
+	drawBrandHeader(pdf, brand, i18n.T(lang, i18n.LabelDailyTitle), fmt.Sprintf("%s - %s", i18n.T(lang, i18n.LabelDivision), project.Workspace.Name))
 
 	pdf.SetFont("Arial", "", 10)
 	meta := [][]string{
-		{"Judul Laporan", fmt.Sprintf("Laporan Hasil Kerja Tim %s", project.Name)},
-		{"Periode Kerja", period},
-		{"Hari Kerja", "Senin - Sabtu"},
-		{"Divisi", project.Workspace.Name},
+		{i18n.T(lang, i18n.LabelReportTitle), i18n.T(lang, i18n.LabelReportSubject, project.Name)},
+		{i18n.T(lang, i18n.LabelPeriod), period},
+		{i18n.T(lang, i18n.LabelWorkingDays), i18n.T(lang, i18n.LabelWorkingDaysVal)},
+		{i18n.T(lang, i18n.LabelDivision), project.Workspace.Name},
 		{"PIC", fmt.Sprintf("%s [%s]", pic.Name, pic.Role)},
 	}
 
@@ -61,13 +52,13 @@ func GenerateDailyReport(
 	pdf.SetFillColor(240, 240, 240)
 
 	headers := []string{
-		"No",
-		"Terakhir Diperbarui",
-		"Penanggung Jawab",
-		"Sub-Agenda",
-		"Kondisi",
-		"Status Terakhir",
-		"Wkt Resolusi (Menit)",
+		i18n.T(lang, i18n.LabelNo),
+		i18n.T(lang, i18n.LabelLastUpdated),
+		i18n.T(lang, i18n.LabelAssignee),
+		i18n.T(lang, i18n.LabelSubAgenda),
+		i18n.T(lang, i18n.LabelPriority),
+		i18n.T(lang, i18n.LabelLastStatus),
+		i18n.T(lang, i18n.LabelResolutionTime),
 	}
 
 	colWidths := []float64{10, 35, 45, 80, 25, 35, 40}
@@ -80,18 +71,18 @@ func GenerateDailyReport(
 	pdf.SetFont("Arial", "", 9)
 
 	for i, item := range items {
-		drawTableRow(pdf, item, i)
+		drawTableRow(pdf, item, i, lang)
 	}
 
 	pdf.Ln(15)
 	pdf.SetFont("Arial", "", 10)
 
-	today := time.Now().Format("02-01-2006")
+	today := i18n.FormatDate(lang, time.Now())
 	pdf.Cell(190, 6, fmt.Sprintf("Yogyakarta, %s", today))
 	pdf.Ln(6)
-	pdf.Cell(190, 6, "Disusun oleh,")
+	pdf.Cell(190, 6, i18n.T(lang, i18n.LabelPreparedBy))
 	pdf.Ln(6)
-	pdf.Cell(190, 6, "Person In Charge")
+	pdf.Cell(190, 6, i18n.T(lang, i18n.LabelPersonInCharge))
 	pdf.Ln(20)
 
 	pdf.SetFont("Arial", "B", 10)
@@ -100,7 +91,7 @@ func GenerateDailyReport(
 	return pdf, nil
 }
 
-func drawTableRow(pdf *gofpdf.Fpdf, item models.DailyActivityItem, index int) {
+func drawTableRow(pdf *gofpdf.Fpdf, item models.DailyActivityItem, index int, lang string) {
 	lineHeight := 8.0
 
 	hSubAgenda := calcHeight(pdf, item.TaskTitle, 80, lineHeight)
@@ -115,7 +106,7 @@ func drawTableRow(pdf *gofpdf.Fpdf, item models.DailyActivityItem, index int) {
 
 	pdf.CellFormat(10, rowHeight, fmt.Sprintf("%d", index+1), "1", 0, "C", false, 0, "")
 
-	pdf.CellFormat(35, rowHeight, item.ActivityTime.Format("2006/01/02 15:04"), "1", 0, "C", false, 0, "")
+	pdf.CellFormat(35, rowHeight, i18n.FormatDateTime(lang, item.ActivityTime), "1", 0, "C", false, 0, "")
 
 	pdf.CellFormat(45, rowHeight, item.User, "1", 0, "L", false, 0, "")
 
@@ -128,7 +119,7 @@ func drawTableRow(pdf *gofpdf.Fpdf, item models.DailyActivityItem, index int) {
 
 	pdf.CellFormat(35, rowHeight, item.StatusAtLog, "1", 0, "C", false, 0, "")
 
-	pdf.CellFormat(40, rowHeight, formatDuration(item.Overdue), "1", 0, "C", false, 0, "")
+	pdf.CellFormat(40, rowHeight, formatDuration(item.Overdue, lang), "1", 0, "C", false, 0, "")
 
 	pdf.SetXY(startX, startY+rowHeight)
 }
@@ -6,12 +6,13 @@ import (
 	"strings"
 	"time"
 
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 
 	"github.com/jung-kurt/gofpdf"
 )
 
-func formatDuration(d time.Duration) string {
+func formatDuration(d time.Duration, lang string) string {
 	if d <= 0 {
 		return "-"
 	}
@@ -20,22 +21,26 @@ func formatDuration(d time.Duration) string {
 	minutes := int(d.Minutes()) % 60
 
 	if days > 0 {
-		return fmt.Sprintf("%d Hari", days)
+		return i18n.T(lang, i18n.LabelDurationDays, days)
 	}
 	if hours > 0 {
-		return fmt.Sprintf("%d Jam %dm", hours, minutes)
+		return i18n.T(lang, i18n.LabelDurationHours, hours, minutes)
 	}
 	if minutes > 0 {
-		return fmt.Sprintf("%dm", minutes)
+		return i18n.T(lang, i18n.LabelDurationMinutes, minutes)
 	}
-	return "< 1 menit"
+	return i18n.T(lang, i18n.LabelDurationLess)
 }
 
-func drawMonitoringReportHeader(pdf *gofpdf.Fpdf) {
+func drawMonitoringReportHeader(pdf *gofpdf.Fpdf, lang string) {
 	pdf.SetFont("Arial", "B", 10)
 	pdf.SetFillColor(240, 240, 240)
 	pdf.SetTextColor(0, 0, 0)
-	headers := []string{"No", "Hari/Tanggal\nMulai", "Penanggung Jawab", "Tugas", "Kondisi", "Estimasi", "Status", "Durasi", "Catatan"}
+	headers := []string{
+		i18n.T(lang, i18n.LabelNo), i18n.T(lang, i18n.LabelStartDay), i18n.T(lang, i18n.LabelAssignee),
+		i18n.T(lang, i18n.LabelTask), i18n.T(lang, i18n.LabelPriority), i18n.T(lang, i18n.LabelEstimate),
+		i18n.T(lang, i18n.LabelStatus), i18n.T(lang, i18n.LabelDuration), i18n.T(lang, i18n.LabelNotes),
+	}
 	colWidths := []float64{10, 25, 30, 45, 25, 25, 30, 30, 45}
 	headerRowHeight := 12.0
 	lineHeight := 5.5
@@ -50,34 +55,24 @@ func drawMonitoringReportHeader(pdf *gofpdf.Fpdf) {
 	pdf.SetXY(15.0, y+headerRowHeight)
 }
 
-func GenerateMonitoringReportPDF(project *models.Project, tasksWithHistory []models.TaskWithHistory, pic models.User, period string) (*gofpdf.Fpdf, error) {
+func GenerateMonitoringReportPDF(project *models.Project, tasksWithHistory []models.TaskWithHistory, pic models.User, period string, branding *models.WorkspaceBranding, lang string) (*gofpdf.Fpdf, error) {
+	brand := ResolveBranding(branding)
+
 	pdf := gofpdf.New("L", "mm", "A4", "")
 	pdf.SetMargins(15, 15, 15)
+	applyBrandFooter(pdf, brand)
 	pdf.AddPage()
-	pdf.SetFont("Arial", "B", 16)
 
 	// Header
-	pdf.Image("assets/logo.png", 15, 15, 25, 0, false, "", 0, "")
-	pdf.SetXY(45, 15)
-	pdf.SetFont("Arial", "B", 16)
-	pdf.SetTextColor(0, 0, 0)
-	pdf.Cell(140, 8, "LAPORAN HASIL MONITORING MINGGUAN")
-	pdf.Ln(5)
-	pdf.SetX(45)
-	pdf.SetFont("Arial", "", 10)
-	pdf.Cell(140, 6, fmt.Sprintf("Divisi %s - %s", project.Workspace.Name, project.Name))
-	pdf.Ln(4)
-	pdf.SetX(45)
-	pdf.Cell(277, 6, "www.astadigitalagency | Imogiri Timur, Gg. Tobanan V | D.I.Yogyakarta")
-	pdf.Ln(15)
+	drawBrandHeader(pdf, brand, i18n.T(lang, i18n.LabelMonitoringTitle), fmt.Sprintf("%s %s - %s", i18n.T(lang, i18n.LabelDivision), project.Workspace.Name, project.Name))
 
 	// Meta Info
 	pdf.SetFont("Arial", "", 11)
 	meta := [][]string{
-		{"Judul Lapor", fmt.Sprintf("Laporan Hasil Kerja Tim %s", project.Name)},
-		{"Periode Kerja", period},
-		{"Hari Kerja", "Senin - Sabtu"},
-		{"Agenda", project.Name},
+		{i18n.T(lang, i18n.LabelReportTitle), i18n.T(lang, i18n.LabelReportSubject, project.Name)},
+		{i18n.T(lang, i18n.LabelPeriod), period},
+		{i18n.T(lang, i18n.LabelWorkingDays), i18n.T(lang, i18n.LabelWorkingDaysVal)},
+		{i18n.T(lang, i18n.LabelAgenda), project.Name},
 		{"PIC", fmt.Sprintf("%s [%s]", pic.Name, pic.Role)},
 	}
 	for _, item := range meta {
@@ -90,7 +85,7 @@ func GenerateMonitoringReportPDF(project *models.Project, tasksWithHistory []mod
 	}
 	pdf.Ln(10)
 
-	drawMonitoringReportHeader(pdf)
+	drawMonitoringReportHeader(pdf, lang)
 
 	pdf.SetFont("Arial", "", 9)
 	cellHeight := 10.0
@@ -108,11 +103,11 @@ func GenerateMonitoringReportPDF(project *models.Project, tasksWithHistory []mod
 			penanggungJawab = "N/A"
 		}
 
-		estimasi := formatDuration(task.DueDate.Sub(task.StartDate))
+		estimasi := formatDuration(task.DueDate.Sub(task.StartDate), lang)
 
 		spanningData := []string{
 			fmt.Sprintf("%d", no),
-			task.StartDate.Format("02-01-2006"),
+			i18n.FormatDate(lang, task.StartDate),
 			penanggungJawab,
 			task.Title,
 			task.Priority,
@@ -144,7 +139,7 @@ func GenerateMonitoringReportPDF(project *models.Project, tasksWithHistory []mod
 
 		if pdf.GetY()+overallRowHeight > 185 {
 			pdf.AddPage()
-			drawMonitoringReportHeader(pdf)
+			drawMonitoringReportHeader(pdf, lang)
 			pdf.SetFillColor(255, 255, 255)
 			pdf.SetFont("Arial", "", 9)
 		}
@@ -164,7 +159,7 @@ func GenerateMonitoringReportPDF(project *models.Project, tasksWithHistory []mod
 				pdf.SetXY(currentX, startY+float64(i)*cellHeight)
 				var duration string
 				if log.ClockOut != nil && !log.ClockOut.IsZero() {
-					duration = formatDuration(log.ClockOut.Sub(log.ClockIn))
+					duration = formatDuration(log.ClockOut.Sub(log.ClockIn), lang)
 				} else {
 					duration = "-"
 				}
@@ -192,12 +187,12 @@ func GenerateMonitoringReportPDF(project *models.Project, tasksWithHistory []mod
 	}
 	pdf.Ln(15)
 	pdf.SetFont("Arial", "", 10)
-	today := time.Now().Format("02-01-2006")
+	today := i18n.FormatDate(lang, time.Now())
 	pdf.Cell(190, 6, fmt.Sprintf("Yogyakarta, %s", today))
 	pdf.Ln(5)
-	pdf.Cell(190, 6, "Disusun oleh,")
+	pdf.Cell(190, 6, i18n.T(lang, i18n.LabelPreparedBy))
 	pdf.Ln(5)
-	pdf.Cell(190, 6, "Person In Charge")
+	pdf.Cell(190, 6, i18n.T(lang, i18n.LabelPersonInCharge))
 	pdf.Ln(20)
 	pdf.SetFont("Arial", "B", 10)
 	pdf.Cell(190, 6, fmt.Sprintf("%s [%s]", pic.Name, pic.Role))
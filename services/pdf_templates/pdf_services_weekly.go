@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 
 	"github.com/jung-kurt/gofpdf"
@@ -14,31 +15,16 @@ const (
 	lineHeight = 5.0
 )
 
-func drawHeader(pdf *gofpdf.Fpdf, project *models.Project) {
-	pdf.Image("assets/logo.png", 15, 15, 25, 0, false, "", 0, "")
-
-	pdf.SetXY(45, 15)
-	pdf.SetFont("Arial", "B", 16)
-	pdf.Cell(0, 8, "LAPORAN HASIL KERJA MINGGUAN")
-
-	pdf.Ln(6)
-	pdf.SetX(45)
-	pdf.SetFont("Arial", "", 10)
-	pdf.Cell(0, 6, fmt.Sprintf("%s - %s", project.Workspace.Name, project.Name))
-
-	pdf.Ln(5)
-	pdf.SetX(45)
-	pdf.Cell(0, 6, "www.astadigitalagency | Imogiri Timur, Gg. Tobanan V | D.I.Yogyakarta")
-
-	pdf.Ln(15)
+func drawHeader(pdf *gofpdf.Fpdf, project *models.Project, brand Branding, lang string) {
+	drawBrandHeader(pdf, brand, i18n.T(lang, i18n.LabelWeeklyTitle), fmt.Sprintf("%s - %s", project.Workspace.Name, project.Name))
 }
 
-func drawMeta(pdf *gofpdf.Fpdf, project *models.Project, pic models.User, period string) {
+func drawMeta(pdf *gofpdf.Fpdf, project *models.Project, pic models.User, period string, lang string) {
 	meta := [][]string{
-		{"Judul Lapor", fmt.Sprintf("Laporan Hasil Kerja Tim %s", project.Workspace.Name)},
-		{"Periode Kerja", period},
-		{"Hari Kerja", "Senin - Sabtu"},
-		{"Agenda", project.Name},
+		{i18n.T(lang, i18n.LabelReportTitle), i18n.T(lang, i18n.LabelReportSubject, project.Workspace.Name)},
+		{i18n.T(lang, i18n.LabelPeriod), period},
+		{i18n.T(lang, i18n.LabelWorkingDays), i18n.T(lang, i18n.LabelWorkingDaysVal)},
+		{i18n.T(lang, i18n.LabelAgenda), project.Name},
 		{"PIC", fmt.Sprintf("%s [%s]", pic.Name, pic.Role)},
 	}
 
@@ -54,12 +40,12 @@ func drawMeta(pdf *gofpdf.Fpdf, project *models.Project, pic models.User, period
 	pdf.Ln(10)
 }
 
-func drawTableHeader(pdf *gofpdf.Fpdf) {
+func drawTableHeader(pdf *gofpdf.Fpdf, lang string) {
 
 	headers := []string{
-		"No", "Tugas", "Penanggung Jawab",
-		"Status", "Kondisi", "Tgl Mulai",
-		"Deadline", "Waktu Selesai", "Catatan",
+		i18n.T(lang, i18n.LabelNo), i18n.T(lang, i18n.LabelTask), i18n.T(lang, i18n.LabelAssignee),
+		i18n.T(lang, i18n.LabelStatus), i18n.T(lang, i18n.LabelPriority), i18n.T(lang, i18n.LabelStartDate),
+		i18n.T(lang, i18n.LabelDeadline), i18n.T(lang, i18n.LabelFinishedAt), i18n.T(lang, i18n.LabelNotes),
 	}
 
 	colWidths := []float64{10, 45, 45, 25, 25, 25, 25, 25, 40}
@@ -79,16 +65,18 @@ func drawTableHeader(pdf *gofpdf.Fpdf) {
 
 	pdf.SetXY(15, y+10)
 }
-func GenerateWeeklyReportPDF(project *models.Project, items []models.AgendaItem, pic models.User, period string) (*gofpdf.Fpdf, error) {
+func GenerateWeeklyReportPDF(project *models.Project, items []models.AgendaItem, pic models.User, period string, branding *models.WorkspaceBranding, lang string) (*gofpdf.Fpdf, error) {
+	brand := ResolveBranding(branding)
 
 	pdf := gofpdf.New("L", "mm", "A4", "")
 	pdf.SetMargins(15, 15, 15)
 	pdf.SetAutoPageBreak(false, 15)
+	applyBrandFooter(pdf, brand)
 	pdf.AddPage()
 
-	drawHeader(pdf, project)
-	drawMeta(pdf, project, pic, period)
-	drawTableHeader(pdf)
+	drawHeader(pdf, project, brand, lang)
+	drawMeta(pdf, project, pic, period, lang)
+	drawTableHeader(pdf, lang)
 
 	colWidths := []float64{10, 45, 45, 25, 25, 25, 25, 25, 40}
 
@@ -97,7 +85,7 @@ func GenerateWeeklyReportPDF(project *models.Project, items []models.AgendaItem,
 	for i, item := range items {
 		waktuSelesai := "-"
 		if strings.ToLower(item.Status) == "done" {
-			waktuSelesai = item.FinishedAt.Format("02-01-2006")
+			waktuSelesai = i18n.FormatDate(lang, *item.FinishedAt)
 		}
 
 		rowData := []string{
@@ -106,8 +94,8 @@ func GenerateWeeklyReportPDF(project *models.Project, items []models.AgendaItem,
 			item.MemberName,
 			item.Status,
 			item.Kondisi,
-			item.StartDate.Format("02-01-2006"),
-			item.DueDate.Format("02-01-2006"),
+			i18n.FormatDate(lang, item.StartDate),
+			i18n.FormatDate(lang, item.DueDate),
 			waktuSelesai,
 			item.Notes,
 		}
@@ -117,15 +105,15 @@ func GenerateWeeklyReportPDF(project *models.Project, items []models.AgendaItem,
 		// Auto page break
 		if pdf.GetY()+rowHeight > 190 {
 			pdf.AddPage()
-			drawHeader(pdf, project)
-			drawMeta(pdf, project, pic, period)
-			drawTableHeader(pdf)
+			drawHeader(pdf, project, brand, lang)
+			drawMeta(pdf, project, pic, period, lang)
+			drawTableHeader(pdf, lang)
 		}
 
 		drawRow(pdf, rowData, colWidths, rowHeight, i)
 	}
 
-	drawFooter(pdf, pic)
+	drawFooter(pdf, pic, lang)
 
 	return pdf, nil
 }
@@ -199,18 +187,18 @@ func setStatusColor(pdf *gofpdf.Fpdf, status string) {
 	}
 }
 
-func drawFooter(pdf *gofpdf.Fpdf, pic models.User) {
+func drawFooter(pdf *gofpdf.Fpdf, pic models.User, lang string) {
 
 	pdf.Ln(15)
 
 	pdf.SetFont("Arial", "", 10)
-	today := time.Now().Format("02-01-2006")
+	today := i18n.FormatDate(lang, time.Now())
 
 	pdf.Cell(0, 6, fmt.Sprintf("Yogyakarta, %s", today))
 	pdf.Ln(6)
-	pdf.Cell(0, 6, "Disusun oleh,")
+	pdf.Cell(0, 6, i18n.T(lang, i18n.LabelPreparedBy))
 	pdf.Ln(6)
-	pdf.Cell(0, 6, "Person In Charge")
+	pdf.Cell(0, 6, i18n.T(lang, i18n.LabelPersonInCharge))
 	pdf.Ln(20)
 
 	pdf.SetFont("Arial", "B", 10)
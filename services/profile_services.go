@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/repositories"
+	"project-management-backend/utils"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -32,13 +34,13 @@ func (s *ProfileService) GetProfile(userID uint) (*models.User, error) {
 func (s *ProfileService) UpdateProfile(c *gin.Context) {
 	user, exists := c.Get("currentUser")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		utils.RespondCode(c, http.StatusUnauthorized, i18n.CodeUnauthenticated)
 		return
 	}
 
 	u, ok := user.(*models.User)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user type in context"})
+		utils.RespondCode(c, http.StatusInternalServerError, i18n.CodeInternalError)
 		return
 	}
 
@@ -52,9 +54,41 @@ func (s *ProfileService) UpdateProfile(c *gin.Context) {
 		u.Position = &position
 	}
 
-	telegramChatID := c.PostForm("telegram_chat_id")
-	if telegramChatID != "" {
-		u.TelegramChatID = &telegramChatID
+	language := c.PostForm("language")
+	if language != "" {
+		if !i18n.IsSupported(language) {
+			utils.RespondCode(c, http.StatusBadRequest, i18n.CodeUnsupportedLanguage, language)
+			return
+		}
+		u.Language = &language
+	}
+
+	// Event yang dikirim menimpa preferensi lama, event lain tidak berubah
+	if rawPrefs := c.PostForm("notification_preferences"); rawPrefs != "" {
+		prefs, err := ParseNotificationPreferences(rawPrefs)
+		if err != nil {
+			utils.RespondError(c, http.StatusBadRequest, err)
+			return
+		}
+		if u.NotificationPreferences == nil {
+			u.NotificationPreferences = models.NotificationPreferences{}
+		}
+		for event, channels := range prefs {
+			u.NotificationPreferences[event] = channels
+		}
+	}
+
+	// Field kosong menghapus URL webhook
+	if webhookURL, ok := c.GetPostForm("notification_webhook_url"); ok {
+		if webhookURL == "" {
+			u.NotificationWebhookURL = nil
+		} else {
+			if err := ValidateWebhookURL(webhookURL); err != nil {
+				utils.RespondError(c, http.StatusBadRequest, err)
+				return
+			}
+			u.NotificationWebhookURL = &webhookURL
+		}
 	}
 
 	file, err := c.FormFile("profile_image")
@@ -63,7 +97,7 @@ func (s *ProfileService) UpdateProfile(c *gin.Context) {
 
 		uploadDir, _ := filepath.Abs("uploads/profile_images")
 		if err := os.MkdirAll(uploadDir, os.ModePerm); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create directory"})
+			utils.RespondCode(c, http.StatusInternalServerError, i18n.CodeUploadDirFailed)
 			return
 		}
 
@@ -72,7 +106,7 @@ func (s *ProfileService) UpdateProfile(c *gin.Context) {
 		urlPath := fmt.Sprintf("/profile-images/%s", filename)
 
 		if err := c.SaveUploadedFile(file, serverPath); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
+			utils.RespondCode(c, http.StatusInternalServerError, i18n.CodeFileSaveFailed, filename)
 			return
 		}
 		u.ProfileImage = &urlPath
@@ -89,8 +123,30 @@ func (s *ProfileService) UpdateProfile(c *gin.Context) {
 		}
 	}
 
+	// Field kosong mematikan digest harian
+	if digestTime, ok := c.GetPostForm("digest_time"); ok {
+		if digestTime == "" {
+			u.DigestTime = nil
+		} else {
+			if _, err := ParseDigestTime(digestTime); err != nil {
+				utils.RespondError(c, http.StatusBadRequest, err)
+				return
+			}
+			u.DigestTime = &digestTime
+		}
+	}
+
+	timezone := c.PostForm("timezone")
+	if timezone != "" {
+		if err := ValidateTimezone(timezone); err != nil {
+			utils.RespondError(c, http.StatusBadRequest, err)
+			return
+		}
+		u.Timezone = &timezone
+	}
+
 	if err := s.UserRepo.UpdateUser(u); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update profile"})
+		utils.RespondCode(c, http.StatusInternalServerError, i18n.CodeProfileUpdateFailed)
 		return
 	}
 
@@ -100,7 +156,7 @@ func (s *ProfileService) UpdateProfile(c *gin.Context) {
 func (s *ProfileService) DeleteProfileImage(c *gin.Context) {
 	user, exists := c.Get("currentUser")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		utils.RespondCode(c, http.StatusUnauthorized, i18n.CodeUnauthenticated)
 		return
 	}
 
@@ -115,9 +171,9 @@ func (s *ProfileService) DeleteProfileImage(c *gin.Context) {
 
 	u.ProfileImage = nil
 	if err := s.UserRepo.UpdateUser(u); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update profile"})
+		utils.RespondCode(c, http.StatusInternalServerError, i18n.CodeProfileUpdateFailed)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Profile image deleted successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": i18n.T(utils.RequestLanguage(c), i18n.LabelMsgProfileImageDeleted)})
 }
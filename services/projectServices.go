@@ -3,14 +3,13 @@ package services
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
+	"log"
 	"project-management-backend/config"
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/repositories"
 	"project-management-backend/utils"
-	"regexp"
-	"sort"
 	"strings"
 
 	"time"
@@ -30,10 +29,14 @@ type ProjectService interface {
 	GetMembers(projectID uint, user *models.User) ([]models.ProjectUser, error)
 	RemoveMember(projectID uint, userID uint, currentUser *models.User) error
 	RemoveMembers(projectID uint, userIDs []uint, currentUser *models.User) error
-	ExportWeeklyBackward(projectID uint, userID uint) ([]byte, error)
-	ExportWeeklyForward(projectID uint, userID uint) ([]byte, error)
-	ExportDaily(projectID uint, userID uint) ([]byte, error)
-	ExportMonitoring(projectID uint, userID uint) ([]byte, error)
+	ExportWeeklyBackward(projectID uint, userID uint, lang string) ([]byte, error)
+	ExportWeeklyForward(projectID uint, userID uint, lang string) ([]byte, error)
+	ExportDaily(projectID uint, userID uint, lang string) ([]byte, error)
+	ExportMonitoring(projectID uint, userID uint, lang string) ([]byte, error)
+	GetWeeklyBackwardData(projectID uint) (*models.AgendaReport, error)
+	GetWeeklyForwardData(projectID uint) (*models.AgendaReport, error)
+	GetDailyData(projectID uint) (*models.DailyReport, error)
+	GetMonitoringData(projectID uint) (*models.MonitoringReport, error)
 }
 
 type ProjectMember struct {
@@ -49,9 +52,11 @@ type projectService struct {
 	taskStatusLogRepo repositories.TaskStatusLogRepository
 	pdfService        PDFService
 	activityLogger    utils.ActivityLogger
+	webhooks          WebhookService
+	events            EventPublisher
 }
 
-func NewProjectService(repo repositories.ProjectRepository, userRepo repositories.UserRepository, workspaceRepo repositories.WorkspaceRepository, taskRepo repositories.TaskRepository, taskStatusLogRepo repositories.TaskStatusLogRepository, pdfService PDFService, activityLogger utils.ActivityLogger) ProjectService {
+func NewProjectService(repo repositories.ProjectRepository, userRepo repositories.UserRepository, workspaceRepo repositories.WorkspaceRepository, taskRepo repositories.TaskRepository, taskStatusLogRepo repositories.TaskStatusLogRepository, pdfService PDFService, activityLogger utils.ActivityLogger, webhooks WebhookService, events EventPublisher) ProjectService {
 	return &projectService{
 		repo:              repo,
 		userRepo:          userRepo,
@@ -60,6 +65,8 @@ func NewProjectService(repo repositories.ProjectRepository, userRepo repositorie
 		taskStatusLogRepo: taskStatusLogRepo,
 		pdfService:        pdfService,
 		activityLogger:    activityLogger,
+		webhooks:          webhooks,
+		events:            events,
 	}
 }
 
@@ -78,7 +85,7 @@ func (s *projectService) CreateProject(project *models.Project, user *models.Use
 
 	if err := s.repo.AddMember(creatorMember); err != nil {
 		s.repo.DeleteProject(project.ID)
-		return errors.New("gagal menambahkan creator sebagai member project")
+		return i18n.NewError(i18n.CodeProjectCreatorMemberFailed)
 	}
 
 	return nil
@@ -95,17 +102,17 @@ func (s *projectService) GetAllProjects(user *models.User) ([]models.Project, er
 func (s *projectService) GetByID(projectID uint, user *models.User) (*models.Project, error) {
 	project, err := s.repo.GetByID(projectID)
 	if err != nil {
-		return nil, errors.New("project tidak ditemukan")
+		return nil, i18n.NewError(i18n.CodeProjectNotFound)
 	}
 
 	if user.Role != "admin" {
 		isProjectMember, err := s.repo.IsUserMember(projectID, user.ID)
 		if err != nil {
-			return nil, errors.New("gagal memeriksa keanggotaan project")
+			return nil, i18n.NewError(i18n.CodeProjectMembershipCheckFailed)
 		}
 
 		if !isProjectMember {
-			return nil, errors.New("anda tidak memiliki akses ke project ini")
+			return nil, i18n.NewError(i18n.CodeProjectAccessDenied)
 		}
 	}
 
@@ -115,7 +122,7 @@ func (s *projectService) GetByID(projectID uint, user *models.User) (*models.Pro
 func (s *projectService) UpdateProject(project *models.Project, user *models.User) error {
 	existingProject, err := s.repo.GetByID(project.ID)
 	if err != nil {
-		return errors.New("project tidak ditemukan")
+		return i18n.NewError(i18n.CodeProjectNotFound)
 	}
 
 	existingProject.Name = project.Name
@@ -127,16 +134,18 @@ func (s *projectService) UpdateProject(project *models.Project, user *models.Use
 func (s *projectService) SoftDeleteProject(projectID uint, user *models.User) error {
 	_, err := s.repo.GetByID(projectID)
 	if err != nil {
-		return errors.New("project tidak ditemukan")
+		return i18n.NewError(i18n.CodeProjectNotFound)
 	}
 
 	return config.DB.Transaction(func(tx *gorm.DB) error {
 		if err := s.taskRepo.SoftDeleteAllTasksInProject(projectID); err != nil {
-			return fmt.Errorf("gagal soft delete tasks di dalam project: %w", err)
+			log.Printf("[Project] Failed to soft delete tasks of project %d: %v", projectID, err)
+			return i18n.NewError(i18n.CodeProjectTasksDeleteFailed, projectID)
 		}
 
 		if err := s.repo.SoftDeleteProject(projectID); err != nil {
-			return fmt.Errorf("gagal soft delete project: %w", err)
+			log.Printf("[Project] Failed to soft delete project %d: %v", projectID, err)
+			return i18n.NewError(i18n.CodeProjectDeleteFailed, projectID)
 		}
 
 		return nil
@@ -147,16 +156,18 @@ func (s *projectService) DeleteProject(projectID uint, user *models.User) error
 	var project models.Project
 	err := config.DB.Unscoped().Where("id = ?", projectID).First(&project).Error
 	if err != nil {
-		return errors.New("project tidak ditemukan")
+		return i18n.NewError(i18n.CodeProjectNotFound)
 	}
 
 	return config.DB.Transaction(func(tx *gorm.DB) error {
 		if err := s.taskRepo.SoftDeleteAllTasksInProject(projectID); err != nil {
-			return fmt.Errorf("gagal soft delete tasks di dalam project: %w", err)
+			log.Printf("[Project] Failed to soft delete tasks of project %d: %v", projectID, err)
+			return i18n.NewError(i18n.CodeProjectTasksDeleteFailed, projectID)
 		}
 
 		if err := s.repo.SoftDeleteProject(projectID); err != nil {
-			return fmt.Errorf("gagal soft delete project: %w", err)
+			log.Printf("[Project] Failed to soft delete project %d: %v", projectID, err)
+			return i18n.NewError(i18n.CodeProjectDeleteFailed, projectID)
 		}
 
 		return nil
@@ -164,17 +175,17 @@ func (s *projectService) DeleteProject(projectID uint, user *models.User) error
 }
 
 func (s *projectService) AddMember(projectID uint, userID uint, role string, currentUser *models.User) error {
-	_, err := s.repo.GetByID(projectID)
+	project, err := s.repo.GetByID(projectID)
 	if err != nil {
-		return errors.New("project tidak ditemukan")
+		return i18n.NewError(i18n.CodeProjectNotFound)
 	}
 
 	isMember, err := s.repo.IsUserMember(projectID, userID)
 	if err != nil {
-		return errors.New("gagal memvalidasi member")
+		return i18n.NewError(i18n.CodeMemberCheckFailed)
 	}
 	if isMember {
-		return errors.New("user sudah menjadi member di project ini")
+		return i18n.NewError(i18n.CodeUserAlreadyProjectMember)
 	}
 
 	member := &models.ProjectUser{
@@ -182,27 +193,42 @@ func (s *projectService) AddMember(projectID uint, userID uint, role string, cur
 		UserID:        userID,
 		RoleInProject: role,
 	}
-	return s.repo.AddMember(member)
+	if err := s.repo.AddMember(member); err != nil {
+		return err
+	}
+
+	s.publishMemberAdded(project, userID, role, currentUser)
+	return nil
+}
+
+func (s *projectService) publishMemberAdded(project *models.Project, userID uint, role string, currentUser *models.User) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return
+	}
+	event := newRealtimeEvent(models.EventMemberAdded, project.WorkspaceID, currentUser, memberAddedData("project", user, role))
+	event.ProjectID = project.ID
+	s.events.PublishEvent(event)
 }
 
 func (s *projectService) AddMembers(projectID uint, members []ProjectMember, currentUser *models.User) error {
 	project, err := s.repo.GetByID(projectID)
 	if err != nil {
-		return errors.New("project tidak ditemukan")
+		return i18n.NewError(i18n.CodeProjectNotFound)
 	}
 
 	for _, member := range members {
 		isTargetUserInWorkspace, err := s.workspaceRepo.IsUserMember(project.WorkspaceID, member.UserID)
 		if err != nil || !isTargetUserInWorkspace {
-			return fmt.Errorf("user %d harus menjadi member workspace terlebih dahulu", member.UserID)
+			return i18n.NewError(i18n.CodeMemberNotInWorkspace, member.UserID)
 		}
 
 		isMember, err := s.repo.IsUserMember(projectID, member.UserID)
 		if err != nil {
-			return fmt.Errorf("gagal memvalidasi member %d", member.UserID)
+			return i18n.NewError(i18n.CodeMemberValidationFailed, member.UserID)
 		}
 		if isMember {
-			return fmt.Errorf("user %d sudah menjadi member di project ini", member.UserID)
+			return i18n.NewError(i18n.CodeMemberAlreadyInProject, member.UserID)
 		}
 
 		projectMember := &models.ProjectUser{
@@ -212,8 +238,10 @@ func (s *projectService) AddMembers(projectID uint, members []ProjectMember, cur
 		}
 
 		if err := s.repo.AddMember(projectMember); err != nil {
-			return fmt.Errorf("gagal menambahkan user %d", member.UserID)
+			return i18n.NewError(i18n.CodeMemberAddFailed, member.UserID)
 		}
+
+		s.publishMemberAdded(project, member.UserID, member.Role, currentUser)
 	}
 
 	return nil
@@ -222,7 +250,7 @@ func (s *projectService) AddMembers(projectID uint, members []ProjectMember, cur
 func (s *projectService) GetMembers(projectID uint, user *models.User) ([]models.ProjectUser, error) {
 	project, err := s.repo.GetByID(projectID)
 	if err != nil {
-		return nil, errors.New("project tidak ditemukan")
+		return nil, i18n.NewError(i18n.CodeProjectNotFound)
 	}
 
 	if user.Role == "admin" {
@@ -232,15 +260,15 @@ func (s *projectService) GetMembers(projectID uint, user *models.User) ([]models
 	if user.Role != "admin" {
 		isProjectMember, err := s.repo.IsUserMember(projectID, user.ID)
 		if err != nil {
-			return nil, errors.New("gagal memvalidasi akses project")
+			return nil, i18n.NewError(i18n.CodeProjectAccessCheckFailed)
 		}
 		if !isProjectMember {
-			return nil, errors.New("akses ditolak untuk melihat members project")
+			return nil, i18n.NewError(i18n.CodeProjectMembersAccessDenied)
 		}
 
 		isWorkspaceMember, err := s.workspaceRepo.IsUserMember(project.WorkspaceID, user.ID)
 		if err != nil || !isWorkspaceMember {
-			return nil, errors.New("hanya member workspace yang boleh melihat members project")
+			return nil, i18n.NewError(i18n.CodeWorkspaceMemberOnlyProjectMembers)
 		}
 	}
 
@@ -250,16 +278,16 @@ func (s *projectService) GetMembers(projectID uint, user *models.User) ([]models
 func (s *projectService) RemoveMember(projectID uint, userID uint, currentUser *models.User) error {
 	_, err := s.repo.GetByID(projectID)
 	if err != nil {
-		return errors.New("project tidak ditemukan")
+		return i18n.NewError(i18n.CodeProjectNotFound)
 	}
 
 	targetMember, err := s.repo.GetProjectMember(projectID, userID)
 	if err != nil {
-		return errors.New("member tidak ditemukan di project ini")
+		return i18n.NewError(i18n.CodeProjectMemberNotFound)
 	}
 
 	if targetMember.RoleInProject == "admin" {
-		return errors.New("tidak bisa menghapus admin project lain")
+		return i18n.NewError(i18n.CodeCannotRemoveProjectAdmin)
 	}
 
 	return s.repo.RemoveMember(projectID, userID)
@@ -268,21 +296,21 @@ func (s *projectService) RemoveMember(projectID uint, userID uint, currentUser *
 func (s *projectService) RemoveMembers(projectID uint, userIDs []uint, currentUser *models.User) error {
 	_, err := s.repo.GetByID(projectID)
 	if err != nil {
-		return errors.New("project tidak ditemukan")
+		return i18n.NewError(i18n.CodeProjectNotFound)
 	}
 
 	for _, userID := range userIDs {
 		targetMember, err := s.repo.GetProjectMember(projectID, userID)
 		if err != nil {
-			return fmt.Errorf("user %d tidak ditemukan di project ini", userID)
+			return i18n.NewError(i18n.CodeMemberNotInProject, userID)
 		}
 
 		if userID == currentUser.ID {
-			return fmt.Errorf("tidak bisa menghapus diri sendiri (user_id: %d)", userID)
+			return i18n.NewError(i18n.CodeCannotRemoveSelfID, userID)
 		}
 
 		if targetMember.RoleInProject == "admin" {
-			return fmt.Errorf("tidak bisa menghapus admin project (user_id: %d)", userID)
+			return i18n.NewError(i18n.CodeCannotRemoveProjectAdminID, userID)
 		}
 	}
 
@@ -323,72 +351,32 @@ func (s *projectService) generatePDFAndLog(
 	}
 	s.activityLogger.Log(activity)
 
+	s.webhooks.Publish(project.WorkspaceID, models.WebhookEventProjectExported, map[string]interface{}{
+		"project": map[string]interface{}{
+			"id":   project.ID,
+			"name": project.Name,
+		},
+		"report":      reportName,
+		"exported_by": userID,
+		"size":        buf.Len(),
+	})
+
 	return buf.Bytes(), nil
 }
 
 // Export 1: Weekly Backward Report
-func (s *projectService) ExportWeeklyBackward(projectID uint, userID uint) ([]byte, error) {
-	now := time.Now()
-	oneWeekAgo := now.AddDate(0, 0, -7)
-
-	tasks, err := s.taskRepo.GetTasksStartingBetween(projectID, oneWeekAgo, now)
+func (s *projectService) ExportWeeklyBackward(projectID uint, userID uint, lang string) ([]byte, error) {
+	project, pic, err := s.getProjectAndPIC(projectID, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tasks: %w", err)
+		return nil, err
 	}
 
-	var agendaItems []models.AgendaItem
-	for _, task := range tasks {
-		if task.Status == "on_board" {
-			continue
-		}
-
-		var memberName string
-
-		if len(task.Members) > 0 && task.Members[0].User.ID != 0 {
-			memberName = task.Members[0].User.Name
-		} else {
-			memberName = "N/A"
-		}
-
-		logs, err := s.taskStatusLogRepo.GetLogsByTaskID(task.ID)
-		var totalDuration time.Duration
-		if err == nil {
-			for _, log := range logs {
-				if log.ClockOut != nil {
-					totalDuration += log.ClockOut.Sub(log.ClockIn)
-				}
-			}
-		}
-
-		agendaItems = append(agendaItems, models.AgendaItem{
-			ProjectTitle: task.Project.Name,
-			TaskTitle:    task.Title,
-			MemberName:   memberName,
-			Status:       task.Status,
-			Kondisi:      task.Priority,
-			StartDate:    task.StartDate,
-			DueDate:      task.DueDate,
-			Notes:        *task.Notes,
-			WorkDuration: formatDuration(totalDuration),
-			FinishedAt:   task.FinishedAt,
-		})
-	}
-
-	period := fmt.Sprintf("%s - %s", oneWeekAgo.Format("02 Jan 2006"), now.Format("02 Jan 2006"))
-
-	sort.SliceStable(agendaItems, func(i, j int) bool {
-		if agendaItems[i].MemberName != agendaItems[j].MemberName {
-			return agendaItems[i].MemberName < agendaItems[j].MemberName
-		}
-		return agendaItems[i].StartDate.Before(agendaItems[j].StartDate)
-	})
-
-	project, pic, err := s.getProjectAndPIC(projectID, userID)
+	report, err := s.buildWeeklyBackward(project, time.Now())
 	if err != nil {
 		return nil, err
 	}
 
-	pdf, err := s.pdfService.GenerateWeeklyReportPDF(project, agendaItems, *pic, period)
+	pdf, err := s.pdfService.GenerateWeeklyReportPDF(project, report.Items, *pic, formatReportPeriod(lang, report.ReportPeriod), lang)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate PDF: %w", err)
 	}
@@ -397,85 +385,18 @@ func (s *projectService) ExportWeeklyBackward(projectID uint, userID uint) ([]by
 }
 
 // Export 2: Weekly Forward Report
-func (s *projectService) ExportWeeklyForward(projectID uint, userID uint) ([]byte, error) {
-	now := time.Now()
-	oneWeekForward := now.AddDate(0, 0, 7)
-
-	tasks, err := s.taskRepo.GetTasksStartingBetween(projectID, now, oneWeekForward)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get tasks: %w", err)
-	}
-
-	statusesToExclude := []string{"done"}
-	additionalTasks, err := s.taskRepo.GetTasksWithStatusOtherThan(projectID, statusesToExclude)
+func (s *projectService) ExportWeeklyForward(projectID uint, userID uint, lang string) ([]byte, error) {
+	project, pic, err := s.getProjectAndPIC(projectID, userID)
 	if err != nil {
-		fmt.Printf("Warning: could not fetch additional tasks: %v", err)
-	}
-
-	taskMap := make(map[uint]models.Task)
-	for _, task := range tasks {
-		taskMap[task.ID] = task
-	}
-
-	for _, task := range additionalTasks {
-		if _, exists := taskMap[task.ID]; !exists {
-			taskMap[task.ID] = task
-		}
-	}
-
-	mergedTasks := make([]models.Task, 0, len(taskMap))
-	for _, task := range taskMap {
-		mergedTasks = append(mergedTasks, task)
-	}
-
-	var agendaItems []models.AgendaItem
-	for _, task := range mergedTasks {
-		var memberName string
-
-		if len(task.Members) > 0 && task.Members[0].User.ID != 0 {
-			memberName = task.Members[0].User.Name
-		} else {
-			memberName = "N/A"
-		}
-
-		logs, err := s.taskStatusLogRepo.GetLogsByTaskID(task.ID)
-		var totalDuration time.Duration
-		if err == nil {
-			for _, log := range logs {
-				if log.ClockOut != nil {
-					totalDuration += log.ClockOut.Sub(log.ClockIn)
-				}
-			}
-		}
-
-		agendaItems = append(agendaItems, models.AgendaItem{
-			ProjectTitle: task.Project.Name,
-			TaskTitle:    task.Title,
-			MemberName:   memberName,
-			Status:       task.Status,
-			Kondisi:      task.Priority,
-			StartDate:    task.StartDate,
-			DueDate:      task.DueDate,
-			Notes:        *task.Notes,
-			WorkDuration: formatDuration(totalDuration),
-		})
+		return nil, err
 	}
 
-	period := fmt.Sprintf("%s - %s", now.Format("02 Jan 2006"), oneWeekForward.Format("02 Jan 2006"))
-
-	sort.SliceStable(agendaItems, func(i, j int) bool {
-		if agendaItems[i].MemberName != agendaItems[j].MemberName {
-			return agendaItems[i].MemberName < agendaItems[j].MemberName
-		}
-		return agendaItems[i].StartDate.Before(agendaItems[j].StartDate)
-	})
-
-	project, pic, err := s.getProjectAndPIC(projectID, userID)
+	report, err := s.buildWeeklyForward(project, time.Now())
 	if err != nil {
 		return nil, err
 	}
 
-	pdf, err := s.pdfService.GenerateWeeklyReportPDF(project, agendaItems, *pic, period)
+	pdf, err := s.pdfService.GenerateWeeklyReportPDF(project, report.Items, *pic, formatReportPeriod(lang, report.ReportPeriod), lang)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate PDF: %w", err)
 	}
@@ -484,54 +405,20 @@ func (s *projectService) ExportWeeklyForward(projectID uint, userID uint) ([]byt
 }
 
 // Export 3: Daily Report
-func (s *projectService) ExportDaily(projectID uint, userID uint) ([]byte, error) {
-	now := time.Now()
-	year, month, day := now.Date()
-	startOfDay := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
-	endOfDay := startOfDay.Add(24 * time.Hour)
-
-	activities, err := s.repo.GetActivityLogsBetween(projectID, startOfDay, endOfDay)
+func (s *projectService) ExportDaily(projectID uint, userID uint, lang string) ([]byte, error) {
+	project, pic, err := s.getProjectAndPIC(projectID, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	project, pic, err := s.getProjectAndPIC(projectID, userID)
+	report, err := s.buildDaily(project, time.Now())
 	if err != nil {
 		return nil, err
 	}
 
-	var dailyItems []models.DailyActivityItem
-	statusChangeRegex := regexp.MustCompile(`changed status of task '.*' from '(.*)' to '(.*)'`)
-
-	for _, activity := range activities {
-		matches := statusChangeRegex.FindStringSubmatch(activity.Action)
-		if len(matches) == 3 {
-			task, err := s.taskRepo.GetByID(activity.ItemID)
-			if err != nil {
-				continue
-			}
-
-			user, err := s.userRepo.GetUserByID(activity.UserID)
-			if err != nil {
-				continue
-			}
-
-			newStatus := matches[2]
-			dailyItems = append(dailyItems, models.DailyActivityItem{
-				ActivityTime: activity.CreatedAt,
-				User:         user.Name,
-				ProjectTitle: project.Name,
-				TaskTitle:    task.Title,
-				TaskPriority: task.Priority,
-				StatusAtLog:  newStatus,
-				Overdue:      task.OverdueDuration,
-			})
-		}
-	}
+	period := i18n.T(lang, i18n.LabelDailyPeriod, i18n.FormatMediumDate(lang, report.Start))
 
-	period := fmt.Sprintf("Daily Report - %s", now.Format("02 Jan 2006"))
-
-	pdf, err := s.pdfService.GenerateDailyReportPDF(project, dailyItems, *pic, period)
+	pdf, err := s.pdfService.GenerateDailyReportPDF(project, report.Items, *pic, period, lang)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate PDF: %w", err)
 	}
@@ -540,36 +427,18 @@ func (s *projectService) ExportDaily(projectID uint, userID uint) ([]byte, error
 }
 
 // Export 4: Monitoring Report
-func (s *projectService) ExportMonitoring(projectID uint, userID uint) ([]byte, error) {
-	now := time.Now()
-	oneWeekAgo := now.AddDate(0, 0, -7)
-
-	tasks, err := s.taskRepo.GetTasksStartingBetween(projectID, oneWeekAgo, now)
+func (s *projectService) ExportMonitoring(projectID uint, userID uint, lang string) ([]byte, error) {
+	project, pic, err := s.getProjectAndPIC(projectID, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tasks: %w", err)
-	}
-
-	var tasksWithHistory []models.TaskWithHistory
-	for _, task := range tasks {
-		logs, err := s.taskStatusLogRepo.GetLogsByTaskID(task.ID)
-		if err != nil {
-			// Handle error, maybe log it and continue
-			continue
-		}
-		tasksWithHistory = append(tasksWithHistory, models.TaskWithHistory{
-			Task:       task,
-			StatusLogs: logs,
-		})
+		return nil, err
 	}
 
-	period := fmt.Sprintf("%s - %s", oneWeekAgo.Format("02 Jan 2006"), now.Format("02 Jan 2006"))
-
-	project, pic, err := s.getProjectAndPIC(projectID, userID)
+	report, err := s.buildMonitoring(project, time.Now())
 	if err != nil {
 		return nil, err
 	}
 
-	pdf, err := s.pdfService.GenerateMonitoringReportPDF(project, tasksWithHistory, *pic, period)
+	pdf, err := s.pdfService.GenerateMonitoringReportPDF(project, report.Tasks, *pic, formatReportPeriod(lang, report.ReportPeriod), lang)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate PDF: %w", err)
 	}
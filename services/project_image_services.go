@@ -2,10 +2,10 @@
 package services
 
 import (
-	"errors"
 	"mime/multipart"
 	"os"
 	"path/filepath"
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/repositories"
 
@@ -23,32 +23,33 @@ type projectImageService struct {
 	projectRepo   repositories.ProjectRepository
 	workspaceRepo repositories.WorkspaceRepository
 	userRepo      repositories.UserRepository
+	events        EventPublisher
 }
 
-func NewProjectImageService(repo repositories.ProjectImageRepository, projectRepo repositories.ProjectRepository, workspaceRepo repositories.WorkspaceRepository, userRepo repositories.UserRepository) ProjectImageService {
-	return &projectImageService{repo: repo, projectRepo: projectRepo, workspaceRepo: workspaceRepo, userRepo: userRepo}
+func NewProjectImageService(repo repositories.ProjectImageRepository, projectRepo repositories.ProjectRepository, workspaceRepo repositories.WorkspaceRepository, userRepo repositories.UserRepository, events EventPublisher) ProjectImageService {
+	return &projectImageService{repo: repo, projectRepo: projectRepo, workspaceRepo: workspaceRepo, userRepo: userRepo, events: events}
 }
 
 func (s *projectImageService) UploadProjectImage(projectID uint, file *multipart.FileHeader, userID uint) (*models.ProjectImage, error) {
 	project, err := s.projectRepo.GetByID(projectID)
 	if err != nil {
-		return nil, errors.New("project tidak ditemukan atau workspace sudah dihapus")
+		return nil, i18n.NewError(i18n.CodeProjectWorkspaceDeleted)
 	}
 
 	user, err := s.userRepo.GetUserByID(userID)
 	if err != nil {
-		return nil, errors.New("user tidak ditemukan")
+		return nil, i18n.NewError(i18n.CodeUserNotFound)
 	}
 
 	if user.Role != "admin" {
 		hasWorkspaceAccess, err := s.workspaceRepo.IsUserMember(project.WorkspaceID, userID)
 		if err != nil || !hasWorkspaceAccess {
-			return nil, errors.New("tidak memiliki akses ke workspace project ini")
+			return nil, i18n.NewError(i18n.CodeProjectWorkspaceAccessDenied)
 		}
 
 		isMember, err := s.projectRepo.IsUserMember(projectID, userID)
 		if err != nil || !isMember {
-			return nil, errors.New("hanya member project yang boleh upload image")
+			return nil, i18n.NewError(i18n.CodeProjectMemberOnlyUpload)
 		}
 	}
 
@@ -63,12 +64,12 @@ func (s *projectImageService) UploadProjectImage(projectID uint, file *multipart
 
 	fileType := file.Header.Get("Content-Type")
 	if !allowedTypes[fileType] {
-		return nil, errors.New("format file tidak didukung. Hanya JPEG, PNG, GIF, WebP")
+		return nil, i18n.NewError(i18n.CodeImageTypeNotAllowed)
 	}
 
 	// Validasi file size (max 5MB)
 	if file.Size > 5*1024*1024 {
-		return nil, errors.New("ukuran file maksimal 5MB")
+		return nil, i18n.NewError(i18n.CodeFileTooLarge, "5MB")
 	}
 
 	// Generate unique filename
@@ -78,13 +79,13 @@ func (s *projectImageService) UploadProjectImage(projectID uint, file *multipart
 	// Create uploads directory if not exists
 	uploadDir := "./uploads/projects"
 	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		return nil, errors.New("gagal membuat directory upload")
+		return nil, i18n.NewError(i18n.CodeUploadDirFailed)
 	}
 
 	// Save file
 	filePath := filepath.Join(uploadDir, fileName)
 	if err := saveUploadedFile(file, filePath); err != nil {
-		return nil, errors.New("gagal menyimpan file: " + err.Error())
+		return nil, i18n.NewError(i18n.CodeFileSaveFailed, err.Error())
 	}
 
 	// Create relative URL untuk database
@@ -99,21 +100,27 @@ func (s *projectImageService) UploadProjectImage(projectID uint, file *multipart
 
 	if err := s.repo.CreateProjectImage(projectImage); err != nil {
 		os.Remove(filePath)
-		return nil, errors.New("gagal menyimpan data image: " + err.Error())
+		return nil, i18n.NewError(i18n.CodeImageSaveFailed, err.Error())
 	}
 
+	event := newRealtimeEvent(models.EventImageUploaded, project.WorkspaceID, user, map[string]interface{}{
+		"scope": "project",
+		"image": projectImage,
+	})
+	event.ProjectID = projectID
+	s.events.PublishEvent(event)
 	return projectImage, nil
 }
 
 func (s *projectImageService) GetProjectImages(projectID uint, userID uint) ([]models.ProjectImage, error) {
 	project, err := s.projectRepo.GetByID(projectID)
 	if err != nil {
-		return nil, errors.New("project tidak ditemukan atau workspace sudah dihapus")
+		return nil, i18n.NewError(i18n.CodeProjectWorkspaceDeleted)
 	}
 
 	user, err := s.projectRepo.GetUserByID(userID)
 	if err != nil {
-		return nil, errors.New("user tidak ditemukan")
+		return nil, i18n.NewError(i18n.CodeUserNotFound)
 	}
 
 	if user.Role == "admin" {
@@ -122,12 +129,12 @@ func (s *projectImageService) GetProjectImages(projectID uint, userID uint) ([]m
 
 	hasWorkspaceAccess, err := s.workspaceRepo.IsUserMember(project.WorkspaceID, userID)
 	if err != nil || !hasWorkspaceAccess {
-		return nil, errors.New("tidak memiliki akses ke workspace project ini")
+		return nil, i18n.NewError(i18n.CodeProjectWorkspaceAccessDenied)
 	}
 
 	isMember, err := s.projectRepo.IsUserMember(projectID, userID)
 	if err != nil || !isMember {
-		return nil, errors.New("hanya member project yang boleh melihat images")
+		return nil, i18n.NewError(i18n.CodeProjectMemberOnlyImages)
 	}
 
 	return s.repo.GetProjectImages(projectID)
@@ -136,17 +143,17 @@ func (s *projectImageService) GetProjectImages(projectID uint, userID uint) ([]m
 func (s *projectImageService) DeleteProjectImage(imageID uint, userID uint) error {
 	image, err := s.repo.GetProjectImageByID(imageID)
 	if err != nil {
-		return errors.New("image tidak ditemukan")
+		return i18n.NewError(i18n.CodeImageNotFound)
 	}
 
 	_, err = s.projectRepo.GetByID(image.ProjectID)
 	if err != nil {
-		return errors.New("project tidak ditemukan atau workspace sudah dihapus")
+		return i18n.NewError(i18n.CodeProjectWorkspaceDeleted)
 	}
 
 	filePath := "." + image.URL
 	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
-		return errors.New("gagal menghapus file: " + err.Error())
+		return i18n.NewError(i18n.CodeFileDeleteFailed, err.Error())
 	}
 
 	return s.repo.DeleteProjectImage(imageID)
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"log"
+	"os"
+	"project-management-backend/models"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Channel pub/sub antar instance server
+const (
+	realtimeChannelEvents    = "events"
+	realtimeChannelDirect    = "direct"
+	realtimeChannelEphemeral = "ephemeral"
+)
+
+// RealtimeBackend carries realtime events, direct messages and presence
+// between server instances. Every instance, including the publisher, gets
+// each published message through the handler passed to Subscribe.
+type RealtimeBackend interface {
+	Publish(channel string, message []byte) error
+	// Subscribe starts delivering messages to handler. It is called once,
+	// before the first Publish.
+	Subscribe(handler func(channel string, message []byte)) error
+	// NextSequence returns the next event sequence of a workspace, shared by
+	// all instances.
+	NextSequence(workspaceID uint) (uint64, error)
+	// Epoch identifies the sequence space; it changes when the sequences are
+	// reset so that old event IDs are not replayed.
+	Epoch() string
+	// UpdatePresence records the presence state of one connection of a
+	// user; an empty state removes the connection. It returns the combined
+	// state of the user across all connections before and after the change.
+	UpdatePresence(userID uint, connectionID string, state string) (before string, after string, err error)
+}
+
+// NewRealtimeBackendFromEnv picks the backend from REALTIME_BACKEND
+// (memory or redis). The memory backend only works with a single instance.
+func NewRealtimeBackendFromEnv() (RealtimeBackend, error) {
+	switch strings.ToLower(os.Getenv("REALTIME_BACKEND")) {
+	case "redis":
+		return NewRedisRealtimeBackend(os.Getenv("REDIS_URL"))
+	case "", "memory":
+		return NewMemoryRealtimeBackend(), nil
+	}
+	log.Printf("[Realtime] Unknown REALTIME_BACKEND %q, using memory", os.Getenv("REALTIME_BACKEND"))
+	return NewMemoryRealtimeBackend(), nil
+}
+
+type memoryRealtimeBackend struct {
+	mu          sync.Mutex
+	handler     func(channel string, message []byte)
+	epoch       string
+	sequences   map[uint]uint64
+	connections map[uint]map[string]string
+}
+
+// NewMemoryRealtimeBackend returns a backend that keeps everything in the
+// current process.
+func NewMemoryRealtimeBackend() RealtimeBackend {
+	return &memoryRealtimeBackend{
+		epoch:       strconv.FormatInt(time.Now().Unix(), 10),
+		sequences:   make(map[uint]uint64),
+		connections: make(map[uint]map[string]string),
+	}
+}
+
+func (b *memoryRealtimeBackend) Publish(channel string, message []byte) error {
+	b.mu.Lock()
+	handler := b.handler
+	b.mu.Unlock()
+
+	if handler != nil {
+		handler(channel, message)
+	}
+	return nil
+}
+
+func (b *memoryRealtimeBackend) Subscribe(handler func(channel string, message []byte)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handler = handler
+	return nil
+}
+
+func (b *memoryRealtimeBackend) NextSequence(workspaceID uint) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sequences[workspaceID]++
+	return b.sequences[workspaceID], nil
+}
+
+func (b *memoryRealtimeBackend) Epoch() string {
+	return b.epoch
+}
+
+func (b *memoryRealtimeBackend) UpdatePresence(userID uint, connectionID string, state string) (string, string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	connections := b.connections[userID]
+	before := combinePresence(connections)
+	if state == "" {
+		delete(connections, connectionID)
+		if len(connections) == 0 {
+			delete(b.connections, userID)
+		}
+	} else {
+		if connections == nil {
+			connections = make(map[string]string)
+			b.connections[userID] = connections
+		}
+		connections[connectionID] = state
+	}
+	return before, combinePresence(connections), nil
+}
+
+// combinePresence picks the most active state of a user's connections.
+func combinePresence(states map[string]string) string {
+	combined := models.PresenceOffline
+	for _, state := range states {
+		if presenceRank(state) > presenceRank(combined) {
+			combined = state
+		}
+	}
+	return combined
+}
+
+func presenceRank(state string) int {
+	switch state {
+	case models.PresenceOnline:
+		return 3
+	case models.PresenceAway:
+		return 2
+	case models.PresenceIdle:
+		return 1
+	}
+	return 0
+}
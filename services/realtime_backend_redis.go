@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisRealtimePrefix      = "realtime:"
+	redisInstanceTTL         = 60 * time.Second
+	redisInstanceHeartbeat   = 20 * time.Second
+	redisRealtimeOpTimeout   = 5 * time.Second
+	redisRealtimeEpochKey    = redisRealtimePrefix + "epoch"
+	redisRealtimeInstanceKey = redisRealtimePrefix + "instance:"
+)
+
+// redisRealtimeBackend shares realtime state through Redis. Messages use
+// pub/sub, sequences use INCR, and the presence of each connection is stored
+// in a hash per user keyed by "<instance>/<connection>", so that connections
+// of an instance that stopped without cleaning up are ignored once its
+// heartbeat key expires.
+type redisRealtimeBackend struct {
+	client     *redis.Client
+	instanceID string
+	epoch      string
+}
+
+// NewRedisRealtimeBackend connects to the Redis server at url, for example
+// redis://localhost:6379/0.
+func NewRedisRealtimeBackend(url string) (RealtimeBackend, error) {
+	if url == "" {
+		return nil, fmt.Errorf("REDIS_URL is required for the redis realtime backend")
+	}
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	backend := &redisRealtimeBackend{
+		client:     redis.NewClient(options),
+		instanceID: uuid.New().String(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisRealtimeOpTimeout)
+	defer cancel()
+
+	if err := backend.client.Ping(ctx).Err(); err != nil {
+		return nil, err
+	}
+	if err := backend.client.SetNX(ctx, redisRealtimeEpochKey, strconv.FormatInt(time.Now().Unix(), 10), 0).Err(); err != nil {
+		return nil, err
+	}
+	if backend.epoch, err = backend.client.Get(ctx, redisRealtimeEpochKey).Result(); err != nil {
+		return nil, err
+	}
+	if err := backend.heartbeat(ctx); err != nil {
+		return nil, err
+	}
+
+	go backend.runHeartbeat()
+	return backend, nil
+}
+
+func (b *redisRealtimeBackend) Publish(channel string, message []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisRealtimeOpTimeout)
+	defer cancel()
+	return b.client.Publish(ctx, redisRealtimePrefix+channel, message).Err()
+}
+
+func (b *redisRealtimeBackend) Subscribe(handler func(channel string, message []byte)) error {
+	ctx := context.Background()
+	pubsub := b.client.Subscribe(ctx,
+		redisRealtimePrefix+realtimeChannelEvents,
+		redisRealtimePrefix+realtimeChannelDirect,
+		redisRealtimePrefix+realtimeChannelEphemeral,
+	)
+	// Tunggu konfirmasi subscribe agar pesan pertama tidak terlewat
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return err
+	}
+
+	go func() {
+		for message := range pubsub.Channel() {
+			handler(strings.TrimPrefix(message.Channel, redisRealtimePrefix), []byte(message.Payload))
+		}
+	}()
+	return nil
+}
+
+func (b *redisRealtimeBackend) NextSequence(workspaceID uint) (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisRealtimeOpTimeout)
+	defer cancel()
+
+	seq, err := b.client.Incr(ctx, fmt.Sprintf("%sseq:%d", redisRealtimePrefix, workspaceID)).Result()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(seq), nil
+}
+
+func (b *redisRealtimeBackend) Epoch() string {
+	return b.epoch
+}
+
+func (b *redisRealtimeBackend) UpdatePresence(userID uint, connectionID string, state string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisRealtimeOpTimeout)
+	defer cancel()
+
+	key := fmt.Sprintf("%spresence:%d", redisRealtimePrefix, userID)
+	before, err := b.presence(ctx, key)
+	if err != nil {
+		return "", "", err
+	}
+
+	field := b.instanceID + "/" + connectionID
+	if state == "" {
+		err = b.client.HDel(ctx, key, field).Err()
+	} else {
+		err = b.client.HSet(ctx, key, field, state).Err()
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	after, err := b.presence(ctx, key)
+	if err != nil {
+		return "", "", err
+	}
+	return before, after, nil
+}
+
+// presence combines the connection states stored in key, dropping the
+// connections of instances whose heartbeat has expired.
+func (b *redisRealtimeBackend) presence(ctx context.Context, key string) (string, error) {
+	connections, err := b.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return "", err
+	}
+
+	alive := map[string]bool{b.instanceID: true}
+	for field := range connections {
+		instanceID, _, _ := strings.Cut(field, "/")
+		if _, checked := alive[instanceID]; !checked {
+			exists, err := b.client.Exists(ctx, redisRealtimeInstanceKey+instanceID).Result()
+			if err != nil {
+				return "", err
+			}
+			alive[instanceID] = exists > 0
+		}
+		if !alive[instanceID] {
+			// Instance sudah mati, koneksinya tidak dihitung lagi
+			b.client.HDel(ctx, key, field)
+			delete(connections, field)
+		}
+	}
+	return combinePresence(connections), nil
+}
+
+func (b *redisRealtimeBackend) heartbeat(ctx context.Context) error {
+	return b.client.Set(ctx, redisRealtimeInstanceKey+b.instanceID, "1", redisInstanceTTL).Err()
+}
+
+func (b *redisRealtimeBackend) runHeartbeat() {
+	ticker := time.NewTicker(redisInstanceHeartbeat)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), redisRealtimeOpTimeout)
+		if err := b.heartbeat(ctx); err != nil {
+			log.Printf("[Realtime] Failed to refresh instance heartbeat: %v", err)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"project-management-backend/models"
+	"time"
+)
+
+// EventPublisher publishes realtime events to the clients connected to a
+// workspace. It is implemented by WebSocketService.
+type EventPublisher interface {
+	PublishEvent(event *models.RealtimeEvent)
+}
+
+func newRealtimeEvent(eventType string, workspaceID uint, actor *models.User, data interface{}) *models.RealtimeEvent {
+	event := &models.RealtimeEvent{
+		Type:        eventType,
+		WorkspaceID: workspaceID,
+		Data:        data,
+		OccurredAt:  time.Now(),
+	}
+	if actor != nil {
+		event.ActorID = actor.ID
+	}
+	return event
+}
+
+func newTaskEvent(eventType string, workspaceID uint, task *models.Task, actor *models.User, data map[string]interface{}) *models.RealtimeEvent {
+	event := newRealtimeEvent(eventType, workspaceID, actor, data)
+	event.ProjectID = task.ProjectID
+	event.TaskID = task.ID
+	return event
+}
+
+// memberAddedData builds the payload of a member.added event. scope is
+// "workspace", "project" or "task".
+func memberAddedData(scope string, user *models.User, role string) map[string]interface{} {
+	return map[string]interface{}{
+		"scope": scope,
+		"user":  webhookActor(user),
+		"role":  role,
+	}
+}
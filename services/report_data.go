@@ -0,0 +1,234 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+)
+
+// Data assembly shared by the PDF exports and the JSON report endpoints, so
+// both always show the same rows.
+
+func (s *projectService) GetWeeklyBackwardData(projectID uint) (*models.AgendaReport, error) {
+	project, err := s.repo.GetByID(projectID)
+	if err != nil {
+		return nil, i18n.NewError(i18n.CodeProjectNotFound)
+	}
+	return s.buildWeeklyBackward(project, time.Now())
+}
+
+func (s *projectService) GetWeeklyForwardData(projectID uint) (*models.AgendaReport, error) {
+	project, err := s.repo.GetByID(projectID)
+	if err != nil {
+		return nil, i18n.NewError(i18n.CodeProjectNotFound)
+	}
+	return s.buildWeeklyForward(project, time.Now())
+}
+
+func (s *projectService) GetDailyData(projectID uint) (*models.DailyReport, error) {
+	project, err := s.repo.GetByID(projectID)
+	if err != nil {
+		return nil, i18n.NewError(i18n.CodeProjectNotFound)
+	}
+	return s.buildDaily(project, time.Now())
+}
+
+func (s *projectService) GetMonitoringData(projectID uint) (*models.MonitoringReport, error) {
+	project, err := s.repo.GetByID(projectID)
+	if err != nil {
+		return nil, i18n.NewError(i18n.CodeProjectNotFound)
+	}
+	return s.buildMonitoring(project, time.Now())
+}
+
+func newReportPeriod(project *models.Project, start time.Time, end time.Time) models.ReportPeriod {
+	return models.ReportPeriod{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		Start:       start,
+		End:         end,
+	}
+}
+
+func formatReportPeriod(lang string, period models.ReportPeriod) string {
+	return fmt.Sprintf("%s - %s", i18n.FormatMediumDate(lang, period.Start), i18n.FormatMediumDate(lang, period.End))
+}
+
+// Weekly backward: task yang mulai dalam 7 hari terakhir, kecuali on_board.
+func (s *projectService) buildWeeklyBackward(project *models.Project, now time.Time) (*models.AgendaReport, error) {
+	oneWeekAgo := now.AddDate(0, 0, -7)
+
+	tasks, err := s.taskRepo.GetTasksStartingBetween(project.ID, oneWeekAgo, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	agendaItems := make([]models.AgendaItem, 0, len(tasks))
+	for _, task := range tasks {
+		if task.Status == "on_board" {
+			continue
+		}
+		item := s.agendaItem(task)
+		item.FinishedAt = task.FinishedAt
+		agendaItems = append(agendaItems, item)
+	}
+	sortAgendaItems(agendaItems)
+
+	return &models.AgendaReport{
+		ReportPeriod: newReportPeriod(project, oneWeekAgo, now),
+		Items:        agendaItems,
+	}, nil
+}
+
+// Weekly forward: task yang mulai dalam 7 hari ke depan ditambah semua task yang belum done.
+func (s *projectService) buildWeeklyForward(project *models.Project, now time.Time) (*models.AgendaReport, error) {
+	oneWeekForward := now.AddDate(0, 0, 7)
+
+	tasks, err := s.taskRepo.GetTasksStartingBetween(project.ID, now, oneWeekForward)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	statusesToExclude := []string{"done"}
+	additionalTasks, err := s.taskRepo.GetTasksWithStatusOtherThan(project.ID, statusesToExclude)
+	if err != nil {
+		log.Printf("[Report] Could not fetch additional tasks for project %d: %v", project.ID, err)
+	}
+
+	taskMap := make(map[uint]models.Task)
+	for _, task := range tasks {
+		taskMap[task.ID] = task
+	}
+
+	for _, task := range additionalTasks {
+		if _, exists := taskMap[task.ID]; !exists {
+			taskMap[task.ID] = task
+		}
+	}
+
+	agendaItems := make([]models.AgendaItem, 0, len(taskMap))
+	for _, task := range taskMap {
+		agendaItems = append(agendaItems, s.agendaItem(task))
+	}
+	sortAgendaItems(agendaItems)
+
+	return &models.AgendaReport{
+		ReportPeriod: newReportPeriod(project, now, oneWeekForward),
+		Items:        agendaItems,
+	}, nil
+}
+
+func (s *projectService) agendaItem(task models.Task) models.AgendaItem {
+	var memberName string
+
+	if len(task.Members) > 0 && task.Members[0].User.ID != 0 {
+		memberName = task.Members[0].User.Name
+	} else {
+		memberName = "N/A"
+	}
+
+	logs, err := s.taskStatusLogRepo.GetLogsByTaskID(task.ID)
+	var totalDuration time.Duration
+	if err == nil {
+		for _, log := range logs {
+			if log.ClockOut != nil {
+				totalDuration += log.ClockOut.Sub(log.ClockIn)
+			}
+		}
+	}
+
+	notes := ""
+	if task.Notes != nil {
+		notes = *task.Notes
+	}
+
+	return models.AgendaItem{
+		ProjectTitle: task.Project.Name,
+		TaskTitle:    task.Title,
+		MemberName:   memberName,
+		Status:       task.Status,
+		Kondisi:      task.Priority,
+		StartDate:    task.StartDate,
+		DueDate:      task.DueDate,
+		Notes:        notes,
+		WorkDuration: formatDuration(totalDuration),
+	}
+}
+
+func sortAgendaItems(agendaItems []models.AgendaItem) {
+	sort.SliceStable(agendaItems, func(i, j int) bool {
+		if agendaItems[i].MemberName != agendaItems[j].MemberName {
+			return agendaItems[i].MemberName < agendaItems[j].MemberName
+		}
+		return agendaItems[i].StartDate.Before(agendaItems[j].StartDate)
+	})
+}
+
+// Daily: perubahan status task hari ini, diambil dari task_status_logs.
+func (s *projectService) buildDaily(project *models.Project, now time.Time) (*models.DailyReport, error) {
+	year, month, day := now.Date()
+	startOfDay := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	endOfDay := startOfDay.Add(24 * time.Hour)
+
+	changes, err := s.taskStatusLogRepo.GetStatusChangesBetween(project.ID, startOfDay, endOfDay)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyItems := make([]models.DailyActivityItem, 0, len(changes))
+	for _, change := range changes {
+		actor := "N/A"
+		if change.Actor != nil {
+			actor = change.Actor.Name
+		}
+
+		dailyItems = append(dailyItems, models.DailyActivityItem{
+			ActivityTime:   change.CreatedAt,
+			User:           actor,
+			ProjectTitle:   project.Name,
+			TaskTitle:      change.Task.Title,
+			TaskPriority:   change.Task.Priority,
+			PreviousStatus: *change.PreviousStatus,
+			StatusAtLog:    change.Status,
+			Overdue:        change.Task.OverdueDuration,
+			OverdueSeconds: int64(change.Task.OverdueDuration.Seconds()),
+		})
+	}
+
+	return &models.DailyReport{
+		ReportPeriod: newReportPeriod(project, startOfDay, endOfDay),
+		Items:        dailyItems,
+	}, nil
+}
+
+// Monitoring: task yang mulai dalam 7 hari terakhir beserta riwayat statusnya.
+func (s *projectService) buildMonitoring(project *models.Project, now time.Time) (*models.MonitoringReport, error) {
+	oneWeekAgo := now.AddDate(0, 0, -7)
+
+	tasks, err := s.taskRepo.GetTasksStartingBetween(project.ID, oneWeekAgo, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	tasksWithHistory := make([]models.TaskWithHistory, 0, len(tasks))
+	for _, task := range tasks {
+		logs, err := s.taskStatusLogRepo.GetLogsByTaskID(task.ID)
+		if err != nil {
+			// Handle error, maybe log it and continue
+			continue
+		}
+		tasksWithHistory = append(tasksWithHistory, models.TaskWithHistory{
+			Task:       task,
+			StatusLogs: logs,
+		})
+	}
+
+	return &models.MonitoringReport{
+		ReportPeriod: newReportPeriod(project, oneWeekAgo, now),
+		Tasks:        tasksWithHistory,
+	}, nil
+}
@@ -0,0 +1,313 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"project-management-backend/repositories"
+	"project-management-backend/utils"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultReportWorkers   = 2
+	defaultReportRetention = 24 * time.Hour
+	reportQueueSize        = 100
+	reportCleanupInterval  = time.Hour
+	reportStorageDir       = "./storage/reports"
+)
+
+var (
+	ErrReportNotReady  = i18n.NewError(i18n.CodeReportNotReady)
+	ErrReportQueueBusy = i18n.NewError(i18n.CodeReportQueueBusy)
+)
+
+type ReportJobService interface {
+	Start()
+	CreateJob(projectID uint, reportType string, user *models.User, lang string) (*models.ReportJob, error)
+	GetJob(jobID uint, user *models.User) (*models.ReportJob, error)
+	GetJobs(user *models.User) ([]models.ReportJob, error)
+	GetArtifact(jobID uint, user *models.User) (*models.ReportJob, error)
+}
+
+type reportJobService struct {
+	repo             repositories.ReportJobRepository
+	projectRepo      repositories.ProjectRepository
+	projectService   ProjectService
+	webSocketService WebSocketService
+	notifications    NotificationService
+	queue            chan uint
+	workers          int
+	retention        time.Duration
+}
+
+func NewReportJobService(repo repositories.ReportJobRepository, projectRepo repositories.ProjectRepository, projectService ProjectService, webSocketService WebSocketService, notifications NotificationService) ReportJobService {
+	workers := defaultReportWorkers
+	if n, err := strconv.Atoi(os.Getenv("REPORT_WORKERS")); err == nil && n > 0 {
+		workers = n
+	}
+
+	retention := defaultReportRetention
+	if h, err := strconv.Atoi(os.Getenv("REPORT_RETENTION_HOURS")); err == nil && h > 0 {
+		retention = time.Duration(h) * time.Hour
+	}
+
+	return &reportJobService{
+		repo:             repo,
+		projectRepo:      projectRepo,
+		projectService:   projectService,
+		webSocketService: webSocketService,
+		notifications:    notifications,
+		queue:            make(chan uint, reportQueueSize),
+		workers:          workers,
+		retention:        retention,
+	}
+}
+
+// Start launches the worker pool and the retention cleanup loop. Jobs left
+// queued or processing by a previous run are put back on the queue.
+func (s *reportJobService) Start() {
+	if err := os.MkdirAll(reportStorageDir, 0755); err != nil {
+		log.Printf("[ReportJob] Failed to create storage directory: %v", err)
+	}
+
+	for i := 0; i < s.workers; i++ {
+		go s.worker()
+	}
+
+	go func() {
+		pending, err := s.repo.GetByStatuses([]string{models.ReportJobQueued, models.ReportJobProcessing})
+		if err != nil {
+			log.Printf("[ReportJob] Failed to load pending jobs: %v", err)
+			return
+		}
+		for _, job := range pending {
+			s.queue <- job.ID
+		}
+	}()
+
+	go s.cleanupLoop()
+}
+
+func IsValidReportType(reportType string) bool {
+	switch reportType {
+	case models.ReportTypeDaily, models.ReportTypeWeeklyBackward, models.ReportTypeWeeklyForward, models.ReportTypeMonitoring:
+		return true
+	}
+	return false
+}
+
+func (s *reportJobService) CreateJob(projectID uint, reportType string, user *models.User, lang string) (*models.ReportJob, error) {
+	if !IsValidReportType(reportType) {
+		return nil, i18n.NewError(i18n.CodeReportTypeUnknown, reportType)
+	}
+
+	if _, err := s.projectRepo.GetByID(projectID); err != nil {
+		return nil, i18n.NewError(i18n.CodeProjectNotFound)
+	}
+
+	job := &models.ReportJob{
+		ProjectID:   projectID,
+		ReportType:  reportType,
+		Status:      models.ReportJobQueued,
+		RequestedBy: user.ID,
+		Language:    lang,
+	}
+	if err := s.repo.Create(job); err != nil {
+		return nil, i18n.NewError(i18n.CodeReportJobCreateFailed)
+	}
+
+	select {
+	case s.queue <- job.ID:
+	default:
+		// Queue penuh, tolak daripada menumpuk goroutine yang menunggu
+		log.Printf("[ReportJob] Queue full, rejecting job %d", job.ID)
+		s.repo.Update(job.ID, map[string]interface{}{
+			"status":    models.ReportJobFailed,
+			"error_msg": "queue full",
+		})
+		return nil, ErrReportQueueBusy
+	}
+
+	return job, nil
+}
+
+func (s *reportJobService) GetJob(jobID uint, user *models.User) (*models.ReportJob, error) {
+	job, err := s.repo.GetByID(jobID)
+	if err != nil {
+		return nil, i18n.NewError(i18n.CodeReportJobNotFound)
+	}
+
+	if user.Role != "admin" && job.RequestedBy != user.ID {
+		return nil, i18n.NewError(i18n.CodeReportJobAccessDenied)
+	}
+
+	return job, nil
+}
+
+func (s *reportJobService) GetJobs(user *models.User) ([]models.ReportJob, error) {
+	return s.repo.GetByUserID(user.ID)
+}
+
+func (s *reportJobService) GetArtifact(jobID uint, user *models.User) (*models.ReportJob, error) {
+	job, err := s.GetJob(jobID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	switch job.Status {
+	case models.ReportJobDone:
+	case models.ReportJobExpired:
+		return nil, i18n.NewError(i18n.CodeReportExpired)
+	case models.ReportJobFailed:
+		return nil, i18n.NewError(i18n.CodeReportFailed, job.ErrorMsg)
+	default:
+		return nil, ErrReportNotReady
+	}
+
+	if _, err := os.Stat(job.FilePath); err != nil {
+		return nil, i18n.NewError(i18n.CodeReportFileNotFound)
+	}
+
+	return job, nil
+}
+
+func (s *reportJobService) worker() {
+	for jobID := range s.queue {
+		s.process(jobID)
+	}
+}
+
+func (s *reportJobService) process(jobID uint) {
+	job, err := s.repo.GetByID(jobID)
+	if err != nil {
+		log.Printf("[ReportJob] Job %d not found: %v", jobID, err)
+		return
+	}
+
+	startedAt := time.Now()
+	s.repo.Update(job.ID, map[string]interface{}{
+		"status":     models.ReportJobProcessing,
+		"started_at": &startedAt,
+	})
+
+	pdfBytes, err := s.render(job)
+	if err != nil {
+		s.fail(job, err)
+		return
+	}
+
+	fileName := fmt.Sprintf("project_%d_%s_%s.pdf", job.ProjectID, job.ReportType, startedAt.Format("20060102_150405"))
+	filePath := filepath.Join(reportStorageDir, fmt.Sprintf("%d_%s", job.ID, fileName))
+	if err := os.WriteFile(filePath, pdfBytes, 0644); err != nil {
+		s.fail(job, fmt.Errorf("failed to store report: %w", err))
+		return
+	}
+
+	completedAt := time.Now()
+	expiresAt := completedAt.Add(s.retention)
+	err = s.repo.Update(job.ID, map[string]interface{}{
+		"status":       models.ReportJobDone,
+		"file_path":    filePath,
+		"file_name":    fileName,
+		"file_size":    int64(len(pdfBytes)),
+		"error_msg":    "",
+		"completed_at": &completedAt,
+		"expires_at":   &expiresAt,
+	})
+	if err != nil {
+		os.Remove(filePath)
+		s.fail(job, err)
+		return
+	}
+
+	job.Status = models.ReportJobDone
+	job.FileName = fileName
+	job.FileSize = int64(len(pdfBytes))
+	job.CompletedAt = &completedAt
+	job.ExpiresAt = &expiresAt
+	s.notify(job)
+	if err := s.notifications.NotifyReportReady(job); err != nil {
+		log.Printf("[ReportJob] Failed to queue notification for job %d: %v", job.ID, err)
+	}
+}
+
+func (s *reportJobService) render(job *models.ReportJob) ([]byte, error) {
+	return renderProjectReport(s.projectService, job.ReportType, job.ProjectID, job.RequestedBy, job.Language)
+}
+
+// renderProjectReport builds the PDF for one of the project report types.
+func renderProjectReport(projectService ProjectService, reportType string, projectID uint, userID uint, lang string) ([]byte, error) {
+	switch reportType {
+	case models.ReportTypeDaily:
+		return projectService.ExportDaily(projectID, userID, lang)
+	case models.ReportTypeWeeklyBackward:
+		return projectService.ExportWeeklyBackward(projectID, userID, lang)
+	case models.ReportTypeWeeklyForward:
+		return projectService.ExportWeeklyForward(projectID, userID, lang)
+	case models.ReportTypeMonitoring:
+		return projectService.ExportMonitoring(projectID, userID, lang)
+	}
+	return nil, fmt.Errorf("unknown report type: %s", reportType)
+}
+
+func (s *reportJobService) fail(job *models.ReportJob, cause error) {
+	utils.Error(job.RequestedBy, "generate_report", "report_jobs", job.ID, cause.Error(), "")
+
+	completedAt := time.Now()
+	s.repo.Update(job.ID, map[string]interface{}{
+		"status":       models.ReportJobFailed,
+		"error_msg":    cause.Error(),
+		"completed_at": &completedAt,
+	})
+
+	job.Status = models.ReportJobFailed
+	job.ErrorMsg = cause.Error()
+	job.CompletedAt = &completedAt
+	s.notify(job)
+}
+
+func (s *reportJobService) notify(job *models.ReportJob) {
+	message, err := json.Marshal(map[string]interface{}{
+		"type": "report_job",
+		"job":  job,
+	})
+	if err != nil {
+		return
+	}
+	s.webSocketService.SendToUser(job.RequestedBy, message)
+}
+
+func (s *reportJobService) cleanupLoop() {
+	ticker := time.NewTicker(reportCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		s.cleanupExpired()
+		<-ticker.C
+	}
+}
+
+func (s *reportJobService) cleanupExpired() {
+	jobs, err := s.repo.GetExpired(time.Now())
+	if err != nil {
+		log.Printf("[ReportJob] Failed to load expired jobs: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		if err := os.Remove(job.FilePath); err != nil && !os.IsNotExist(err) {
+			log.Printf("[ReportJob] Failed to delete %s: %v", job.FilePath, err)
+			continue
+		}
+		s.repo.Update(job.ID, map[string]interface{}{
+			"status":    models.ReportJobExpired,
+			"file_path": "",
+		})
+	}
+}
@@ -0,0 +1,410 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"project-management-backend/repositories"
+	"project-management-backend/utils"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	reportScheduleTickInterval = time.Minute
+	reportFormatPDF            = "pdf"
+)
+
+type ReportScheduleInput struct {
+	Name        string                  `json:"name" binding:"required"`
+	ReportType  string                  `json:"report_type" binding:"required"`
+	ProjectID   *uint                   `json:"project_id"`
+	WorkspaceID *uint                   `json:"workspace_id"`
+	Cadence     string                  `json:"cadence" binding:"required"`
+	Recipients  models.ReportRecipients `json:"recipients"`
+	Channels    []string                `json:"channels" binding:"required"`
+	Enabled     *bool                   `json:"enabled"`
+	Language    string                  `json:"language"`
+	Format      string                  `json:"format"` // hanya pdf yang didukung
+}
+
+type ReportScheduleService interface {
+	Start()
+	CreateSchedule(input ReportScheduleInput, user *models.User) (*models.ReportSchedule, error)
+	GetSchedules() ([]models.ReportSchedule, error)
+	GetByID(scheduleID uint) (*models.ReportSchedule, error)
+	UpdateSchedule(scheduleID uint, input ReportScheduleInput) (*models.ReportSchedule, error)
+	DeleteSchedule(scheduleID uint) error
+	RunNow(scheduleID uint) error
+}
+
+type reportScheduleService struct {
+	repo              repositories.ReportScheduleRepository
+	projectRepo       repositories.ProjectRepository
+	workspaceRepo     repositories.WorkspaceRepository
+	userRepo          repositories.UserRepository
+	projectService    ProjectService
+	attendanceService *AttendanceService
+	pdfService        PDFService
+	telegramService   TelegramService
+	mailer            Mailer
+
+	mu      sync.Mutex
+	running map[uint]bool
+}
+
+func NewReportScheduleService(
+	repo repositories.ReportScheduleRepository,
+	projectRepo repositories.ProjectRepository,
+	workspaceRepo repositories.WorkspaceRepository,
+	userRepo repositories.UserRepository,
+	projectService ProjectService,
+	attendanceService *AttendanceService,
+	pdfService PDFService,
+	telegramService TelegramService,
+	mailer Mailer,
+) ReportScheduleService {
+	return &reportScheduleService{
+		repo:              repo,
+		projectRepo:       projectRepo,
+		workspaceRepo:     workspaceRepo,
+		userRepo:          userRepo,
+		projectService:    projectService,
+		attendanceService: attendanceService,
+		pdfService:        pdfService,
+		telegramService:   telegramService,
+		mailer:            mailer,
+		running:           make(map[uint]bool),
+	}
+}
+
+// Start runs the background loop that executes due schedules every minute.
+func (s *reportScheduleService) Start() {
+	go func() {
+		ticker := time.NewTicker(reportScheduleTickInterval)
+		defer ticker.Stop()
+
+		for {
+			s.runDue(time.Now())
+			<-ticker.C
+		}
+	}()
+}
+
+func (s *reportScheduleService) runDue(now time.Time) {
+	schedules, err := s.repo.GetDue(now)
+	if err != nil {
+		log.Printf("[ReportSchedule] Failed to load due schedules: %v", err)
+		return
+	}
+
+	for i := range schedules {
+		go s.execute(&schedules[i])
+	}
+}
+
+func (s *reportScheduleService) validate(input ReportScheduleInput) (*utils.CronSchedule, error) {
+	cron, err := utils.ParseCron(input.Cadence)
+	if err != nil {
+		return nil, i18n.NewError(i18n.CodeCadenceInvalid, err.Error())
+	}
+
+	if input.ReportType == models.ReportTypeAttendance {
+		if input.WorkspaceID == nil {
+			return nil, i18n.NewError(i18n.CodeReportWorkspaceRequired)
+		}
+		if _, err := s.workspaceRepo.GetByID(*input.WorkspaceID); err != nil {
+			return nil, i18n.NewError(i18n.CodeWorkspaceNotFound)
+		}
+	} else {
+		if !IsValidReportType(input.ReportType) {
+			return nil, i18n.NewError(i18n.CodeReportTypeUnknown, input.ReportType)
+		}
+		if input.ProjectID == nil {
+			return nil, i18n.NewError(i18n.CodeReportProjectRequired)
+		}
+		if _, err := s.projectRepo.GetByID(*input.ProjectID); err != nil {
+			return nil, i18n.NewError(i18n.CodeProjectNotFound)
+		}
+	}
+
+	if input.Format != "" && input.Format != reportFormatPDF {
+		return nil, i18n.NewError(i18n.CodeReportFormatUnsupported, input.Format)
+	}
+
+	if len(input.Channels) == 0 {
+		return nil, i18n.NewError(i18n.CodeReportChannelRequired)
+	}
+	for _, channel := range input.Channels {
+		if channel != models.DeliveryChannelTelegram && channel != models.DeliveryChannelEmail {
+			return nil, i18n.NewError(i18n.CodeChannelUnsupported, channel)
+		}
+	}
+
+	recipients := input.Recipients
+	if len(recipients.UserIDs) == 0 && len(recipients.Emails) == 0 && len(recipients.TelegramChatIDs) == 0 {
+		return nil, i18n.NewError(i18n.CodeReportRecipientRequired)
+	}
+
+	if input.Language != "" && !i18n.IsSupported(input.Language) {
+		return nil, i18n.NewError(i18n.CodeUnsupportedLanguage, input.Language)
+	}
+
+	return cron, nil
+}
+
+func scheduleLanguage(lang string) string {
+	if lang == "" {
+		return i18n.DefaultLang
+	}
+	return lang
+}
+
+func (s *reportScheduleService) CreateSchedule(input ReportScheduleInput, user *models.User) (*models.ReportSchedule, error) {
+	cron, err := s.validate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	nextRun := cron.Next(time.Now())
+	schedule := &models.ReportSchedule{
+		Name:        input.Name,
+		ReportType:  input.ReportType,
+		ProjectID:   input.ProjectID,
+		WorkspaceID: input.WorkspaceID,
+		Cadence:     input.Cadence,
+		Recipients:  input.Recipients,
+		Channels:    input.Channels,
+		Language:    scheduleLanguage(input.Language),
+		Enabled:     input.Enabled == nil || *input.Enabled,
+		NextRunAt:   &nextRun,
+		CreatedBy:   user.ID,
+	}
+	if input.ReportType == models.ReportTypeAttendance {
+		schedule.ProjectID = nil
+	} else {
+		schedule.WorkspaceID = nil
+	}
+
+	if err := s.repo.Create(schedule); err != nil {
+		return nil, i18n.NewError(i18n.CodeReportScheduleSaveFailed)
+	}
+
+	return schedule, nil
+}
+
+func (s *reportScheduleService) GetSchedules() ([]models.ReportSchedule, error) {
+	return s.repo.GetAll()
+}
+
+func (s *reportScheduleService) GetByID(scheduleID uint) (*models.ReportSchedule, error) {
+	schedule, err := s.repo.GetByID(scheduleID)
+	if err != nil {
+		return nil, i18n.NewError(i18n.CodeReportScheduleNotFound)
+	}
+	return schedule, nil
+}
+
+func (s *reportScheduleService) UpdateSchedule(scheduleID uint, input ReportScheduleInput) (*models.ReportSchedule, error) {
+	schedule, err := s.GetByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	cron, err := s.validate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	nextRun := cron.Next(time.Now())
+	schedule.Name = input.Name
+	schedule.ReportType = input.ReportType
+	schedule.ProjectID = input.ProjectID
+	schedule.WorkspaceID = input.WorkspaceID
+	schedule.Cadence = input.Cadence
+	schedule.Recipients = input.Recipients
+	schedule.Channels = input.Channels
+	schedule.Language = scheduleLanguage(input.Language)
+	schedule.NextRunAt = &nextRun
+	if input.Enabled != nil {
+		schedule.Enabled = *input.Enabled
+	}
+	if input.ReportType == models.ReportTypeAttendance {
+		schedule.ProjectID = nil
+	} else {
+		schedule.WorkspaceID = nil
+	}
+
+	if err := s.repo.Save(schedule); err != nil {
+		return nil, i18n.NewError(i18n.CodeReportScheduleUpdateFailed)
+	}
+
+	return schedule, nil
+}
+
+func (s *reportScheduleService) DeleteSchedule(scheduleID uint) error {
+	if _, err := s.GetByID(scheduleID); err != nil {
+		return err
+	}
+	return s.repo.Delete(scheduleID)
+}
+
+func (s *reportScheduleService) RunNow(scheduleID uint) error {
+	schedule, err := s.GetByID(scheduleID)
+	if err != nil {
+		return err
+	}
+
+	go s.execute(schedule)
+	return nil
+}
+
+func (s *reportScheduleService) execute(schedule *models.ReportSchedule) {
+	s.mu.Lock()
+	if s.running[schedule.ID] {
+		s.mu.Unlock()
+		return
+	}
+	s.running[schedule.ID] = true
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, schedule.ID)
+		s.mu.Unlock()
+	}()
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"last_run_at": &now,
+		"last_error":  "",
+	}
+
+	if cron, err := utils.ParseCron(schedule.Cadence); err == nil {
+		nextRun := cron.Next(now)
+		updates["next_run_at"] = &nextRun
+	} else {
+		updates["next_run_at"] = nil
+	}
+
+	if err := s.deliver(schedule); err != nil {
+		utils.Error(schedule.CreatedBy, "run_report_schedule", "report_schedules", schedule.ID, err.Error(), "")
+		updates["last_error"] = err.Error()
+	}
+
+	if err := s.repo.Update(schedule.ID, updates); err != nil {
+		log.Printf("[ReportSchedule] Failed to update schedule %d: %v", schedule.ID, err)
+	}
+}
+
+func (s *reportScheduleService) render(schedule *models.ReportSchedule) ([]byte, string, error) {
+	date := time.Now().Format("2006-01-02")
+
+	if schedule.ReportType == models.ReportTypeAttendance {
+		workspace, err := s.workspaceRepo.GetByID(*schedule.WorkspaceID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get workspace: %w", err)
+		}
+
+		attendances, err := s.attendanceService.GetAttendancesForExport(workspace.ID, date)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get attendances: %w", err)
+		}
+
+		pdfBytes, err := s.pdfService.CreateAttendanceReportPDF(attendances, workspace, date, schedule.Language)
+		if err != nil {
+			return nil, "", err
+		}
+		return pdfBytes, fmt.Sprintf("attendance_report_%s_%s.pdf", workspace.Name, date), nil
+	}
+
+	pdfBytes, err := renderProjectReport(s.projectService, schedule.ReportType, *schedule.ProjectID, schedule.CreatedBy, schedule.Language)
+	if err != nil {
+		return nil, "", err
+	}
+	return pdfBytes, fmt.Sprintf("project_%d_%s_%s.pdf", *schedule.ProjectID, schedule.ReportType, date), nil
+}
+
+func (s *reportScheduleService) deliver(schedule *models.ReportSchedule) error {
+	pdfBytes, fileName, err := s.render(schedule)
+	if err != nil {
+		return fmt.Errorf("failed to generate report: %w", err)
+	}
+
+	emails := append([]string{}, schedule.Recipients.Emails...)
+	chatIDs := append([]string{}, schedule.Recipients.TelegramChatIDs...)
+	for _, userID := range schedule.Recipients.UserIDs {
+		user, err := s.userRepo.GetUserByID(userID)
+		if err != nil {
+			continue
+		}
+		emails = append(emails, user.Email)
+		if user.TelegramChatID != nil && *user.TelegramChatID != "" {
+			chatIDs = append(chatIDs, *user.TelegramChatID)
+		}
+	}
+
+	caption := fmt.Sprintf("%s - %s", schedule.Name, time.Now().Format("02 Jan 2006"))
+
+	var failures []string
+	for _, channel := range schedule.Channels {
+		switch channel {
+		case models.DeliveryChannelTelegram:
+			for _, chatID := range uniqueStrings(chatIDs) {
+				if err := s.telegramService.SendDocument(chatID, fileName, pdfBytes, caption); err != nil {
+					failures = append(failures, fmt.Sprintf("telegram %s: %v", chatID, err))
+				}
+			}
+		case models.DeliveryChannelEmail:
+			recipients := uniqueStrings(emails)
+			if len(recipients) == 0 {
+				continue
+			}
+			if err := s.sendEmail(schedule, recipients, fileName, pdfBytes); err != nil {
+				failures = append(failures, fmt.Sprintf("email: %v", err))
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+func (s *reportScheduleService) sendEmail(schedule *models.ReportSchedule, recipients []string, fileName string, pdfBytes []byte) error {
+	lang := scheduleLanguage(schedule.Language)
+	msg, err := RenderMail(MailTemplateScheduledReport, lang, MailData{
+		"ScheduleName": schedule.Name,
+		"Date":         i18n.FormatMediumDate(lang, time.Now()),
+		"FileName":     fileName,
+	})
+	if err != nil {
+		return err
+	}
+
+	msg.To = recipients
+	msg.Attachments = []MailAttachment{{
+		FileName:    fileName,
+		ContentType: "application/pdf",
+		Data:        pdfBytes,
+	}}
+	return s.mailer.Send(msg)
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	return result
+}
@@ -3,7 +3,9 @@ package services
 import (
 	"errors"
 	"fmt"
+	"log"
 	"project-management-backend/config"
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/repositories"
 	"project-management-backend/utils"
@@ -25,20 +27,26 @@ type TaskService interface {
 	DeleteMember(taskID uint, projectID uint, workspaceID uint, userID uint, currentUser *models.User) error
 }
 type taskService struct {
-	repo            repositories.TaskRepository
-	userRepo        repositories.UserRepository
-	activityLogger  utils.ActivityLogger
-	taskStatusLog   repositories.TaskStatusLogRepository
-	telegramService TelegramService
+	repo           repositories.TaskRepository
+	userRepo       repositories.UserRepository
+	activityLogger utils.ActivityLogger
+	taskStatusLog  repositories.TaskStatusLogRepository
+	notifications  NotificationService
+	webhooks       WebhookService
+	chat           ChatIntegrationService
+	events         EventPublisher
 }
 
-func NewTaskService(repo repositories.TaskRepository, userRepo repositories.UserRepository, taskStatusLogRepo repositories.TaskStatusLogRepository, activityLogger utils.ActivityLogger, telegramService TelegramService) TaskService {
+func NewTaskService(repo repositories.TaskRepository, userRepo repositories.UserRepository, taskStatusLogRepo repositories.TaskStatusLogRepository, activityLogger utils.ActivityLogger, notifications NotificationService, webhooks WebhookService, chat ChatIntegrationService, events EventPublisher) TaskService {
 	return &taskService{
-		repo:            repo,
-		userRepo:        userRepo,
-		activityLogger:  activityLogger,
-		taskStatusLog:   taskStatusLogRepo,
-		telegramService: telegramService,
+		repo:           repo,
+		userRepo:       userRepo,
+		activityLogger: activityLogger,
+		taskStatusLog:  taskStatusLogRepo,
+		notifications:  notifications,
+		webhooks:       webhooks,
+		chat:           chat,
+		events:         events,
 	}
 
 }
@@ -46,7 +54,7 @@ func NewTaskService(repo repositories.TaskRepository, userRepo repositories.User
 func (s *taskService) CreateTask(task *models.Task, workspaceID uint, user *models.User) error {
 	isProjectInWorkspace, err := s.repo.IsProjectInWorkspace(task.ProjectID, workspaceID)
 	if err != nil || !isProjectInWorkspace {
-		return errors.New("project tidak ditemukan di workspace ini")
+		return i18n.NewError(i18n.CodeProjectNotInWorkspace)
 	}
 
 	if task.Status == "" {
@@ -56,7 +64,25 @@ func (s *taskService) CreateTask(task *models.Task, workspaceID uint, user *mode
 		task.Priority = "Normal"
 	}
 
-	err = s.repo.CreateTask(task)
+	// Task, log status awal dan notifikasi mention disimpan dalam satu transaksi
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.WithTx(tx).CreateTask(task); err != nil {
+			return err
+		}
+
+		taskStatusLog := &models.TaskStatusLog{
+			TaskID:    task.ID,
+			Status:    task.Status,
+			ChangedBy: &user.ID,
+			ClockIn:   task.StartDate,
+			ClockOut:  nil,
+		}
+		if err := s.taskStatusLog.WithTx(tx).Create(taskStatusLog); err != nil {
+			return err
+		}
+
+		return s.notifications.WithTx(tx).NotifyMentions(task, "", task.Description, user)
+	})
 	if err != nil {
 		return err
 	}
@@ -69,20 +95,20 @@ func (s *taskService) CreateTask(task *models.Task, workspaceID uint, user *mode
 	}
 	s.activityLogger.Log(activity)
 
-	taskStatusLog := &models.TaskStatusLog{
-		TaskID:   task.ID,
-		Status:   task.Status,
-		ClockIn:  task.StartDate,
-		ClockOut: nil,
-	}
-
-	return s.taskStatusLog.Create(taskStatusLog)
+	s.webhooks.Publish(workspaceID, models.WebhookEventTaskCreated, map[string]interface{}{
+		"task":  webhookTask(task),
+		"actor": webhookActor(user),
+	})
+	s.events.PublishEvent(newTaskEvent(models.EventTaskCreated, workspaceID, task, user, map[string]interface{}{
+		"task": task,
+	}))
+	return nil
 }
 
 func (s *taskService) GetAllTasks(projectID uint, workspaceID uint, user *models.User) ([]models.Task, error) {
 	isProjectInWorkspace, err := s.repo.IsProjectInWorkspace(projectID, workspaceID)
 	if err != nil || !isProjectInWorkspace {
-		return nil, errors.New("project tidak ditemukan di workspace ini")
+		return nil, i18n.NewError(i18n.CodeProjectNotInWorkspace)
 	}
 
 	if user.Role == "admin" {
@@ -91,7 +117,7 @@ func (s *taskService) GetAllTasks(projectID uint, workspaceID uint, user *models
 
 	isProjectMember, err := s.repo.IsUserInProject(projectID, user.ID)
 	if err != nil || !isProjectMember {
-		return nil, errors.New("hanya member project yang boleh lihat tasks")
+		return nil, i18n.NewError(i18n.CodeProjectMemberOnlyTasks)
 	}
 
 	return s.repo.GetTasksByUserID(projectID, user.ID)
@@ -100,11 +126,11 @@ func (s *taskService) GetAllTasks(projectID uint, workspaceID uint, user *models
 func (s *taskService) GetByID(taskID uint, workspaceID uint, user *models.User) (*models.Task, error) {
 	task, err := s.repo.GetByID(taskID)
 	if err != nil {
-		return nil, errors.New("task tidak ditemukan")
+		return nil, i18n.NewError(i18n.CodeTaskNotFound)
 	}
 
 	if task.Project.WorkspaceID != workspaceID {
-		return nil, errors.New("task tidak ditemukan di workspace ini")
+		return nil, i18n.NewError(i18n.CodeTaskNotInWorkspace)
 	}
 
 	if user.Role != "admin" {
@@ -112,7 +138,7 @@ func (s *taskService) GetByID(taskID uint, workspaceID uint, user *models.User)
 		isProjectMember, _ := s.repo.IsUserInProject(task.ProjectID, user.ID)
 
 		if !isTaskMember && !isProjectMember {
-			return nil, errors.New("akses ditolak untuk task ini")
+			return nil, i18n.NewError(i18n.CodeTaskAccessDenied)
 		}
 	}
 
@@ -122,11 +148,11 @@ func (s *taskService) GetByID(taskID uint, workspaceID uint, user *models.User)
 func (s *taskService) UpdateTask(taskID uint, updates map[string]interface{}, workspaceID uint, user *models.User) error {
 	existingTask, err := s.repo.GetByID(taskID)
 	if err != nil {
-		return errors.New("task tidak ditemukan")
+		return i18n.NewError(i18n.CodeTaskNotFound)
 	}
 
 	if existingTask.Project.WorkspaceID != workspaceID {
-		return errors.New("task tidak ditemukan di workspace ini")
+		return i18n.NewError(i18n.CodeTaskNotInWorkspace)
 	}
 
 	finalUpdates := updates
@@ -135,7 +161,7 @@ func (s *taskService) UpdateTask(taskID uint, updates map[string]interface{}, wo
 	if !isProjectAdminOrAdmin {
 		isPAdmin, err := s.isProjectAdmin(existingTask.ProjectID, user.ID)
 		if err != nil {
-			return errors.New("gagal memvalidasi admin project")
+			return i18n.NewError(i18n.CodeProjectAdminCheckFailed)
 		}
 		isProjectAdminOrAdmin = isPAdmin
 	}
@@ -143,10 +169,10 @@ func (s *taskService) UpdateTask(taskID uint, updates map[string]interface{}, wo
 	if !isProjectAdminOrAdmin {
 		isMember, err := s.repo.IsUserMember(taskID, user.ID)
 		if err != nil {
-			return errors.New("gagal memvalidasi member task")
+			return i18n.NewError(i18n.CodeTaskMemberCheckFailed)
 		}
 		if !isMember {
-			return errors.New("anda bukan member dari task ini, tidak bisa mengupdate")
+			return i18n.NewError(i18n.CodeNotTaskMemberUpdate)
 		}
 
 		allowedUpdates := make(map[string]interface{})
@@ -154,46 +180,25 @@ func (s *taskService) UpdateTask(taskID uint, updates map[string]interface{}, wo
 			if key == "status" || key == "notes" {
 				allowedUpdates[key] = value
 			} else {
-				return errors.New("anda hanya diizinkan untuk mengupdate status dan notes")
+				return i18n.NewError(i18n.CodeTaskUpdateFieldsRestricted)
 			}
 		}
 		finalUpdates = allowedUpdates
 	}
 
 	if len(finalUpdates) == 0 {
-		return errors.New("tidak ada field yang diizinkan untuk diupdate")
+		return i18n.NewError(i18n.CodeNoUpdatableFields)
 	}
 
+	oldStatus := existingTask.Status
+	var statusActivity *models.ActivityLog
 	if newStatus, ok := finalUpdates["status"].(string); ok && newStatus != existingTask.Status {
-		activity := models.ActivityLog{
+		statusActivity = &models.ActivityLog{
 			UserID:    user.ID,
 			Action:    fmt.Sprintf("User changed status of task '%s' from '%s' to '%s'", existingTask.Title, existingTask.Status, newStatus),
 			TableName: "tasks",
 			ItemID:    taskID,
 		}
-		s.activityLogger.Log(activity)
-
-		now := time.Now()
-		lastLog, err := s.taskStatusLog.FindLastLog(taskID)
-		if err != nil {
-			return err
-		}
-		if lastLog != nil {
-			err = s.taskStatusLog.UpdateClockOut(lastLog.ID, now)
-			if err != nil {
-				return err
-			}
-		}
-
-		newLog := &models.TaskStatusLog{
-			TaskID:  taskID,
-			Status:  newStatus,
-			ClockIn: now,
-		}
-		err = s.taskStatusLog.Create(newLog)
-		if err != nil {
-			return err
-		}
 
 		// Set has_been_pending flag jika status berubah menjadi "pending"
 		if strings.ToLower(newStatus) == "pending" && !existingTask.HasBeenPending {
@@ -217,6 +222,13 @@ func (s *taskService) UpdateTask(taskID uint, updates map[string]interface{}, wo
 		}
 	}
 
+	// Due date baru berarti reminder dan notifikasi overdue dikirim ulang
+	if _, ok := finalUpdates["due_date"]; ok {
+		finalUpdates["reminder_sent_at"] = nil
+		finalUpdates["overdue_notified_at"] = nil
+		finalUpdates["escalated_at"] = nil
+	}
+
 	if time.Now().After(existingTask.DueDate) {
 		duration := time.Since(existingTask.DueDate)
 		finalUpdates["overdue_duration"] = duration
@@ -224,13 +236,128 @@ func (s *taskService) UpdateTask(taskID uint, updates map[string]interface{}, wo
 		finalUpdates["overdue_duration"] = time.Duration(0)
 	}
 
-	return s.repo.UpdateTask(taskID, finalUpdates)
+	// Log status, perubahan task dan notifikasinya disimpan bersama sehingga
+	// notifikasi hanya masuk antrean jika perubahan berhasil
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if statusActivity != nil {
+			if err := s.recordStatusChange(tx, existingTask, finalUpdates["status"].(string), user); err != nil {
+				return err
+			}
+		}
+
+		if err := s.repo.WithTx(tx).UpdateTask(taskID, finalUpdates); err != nil {
+			return err
+		}
+
+		return s.notifyTaskUpdate(s.notifications.WithTx(tx), existingTask, finalUpdates, user)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Event setelah commit memakai task terbaru; jika gagal dimuat ulang,
+	// status baru diterapkan pada salinan task lama
+	updatedTask, reloadErr := s.repo.GetByID(taskID)
+	if reloadErr != nil {
+		log.Printf("[TaskService] Failed to reload task %d after update: %v", taskID, reloadErr)
+		updatedTask = nil
+	}
+
+	if statusActivity != nil {
+		s.activityLogger.Log(*statusActivity)
+
+		newStatus := finalUpdates["status"].(string)
+		statusTask := updatedTask
+		if statusTask == nil {
+			taskCopy := *existingTask
+			taskCopy.Status = newStatus
+			statusTask = &taskCopy
+		}
+
+		s.webhooks.Publish(workspaceID, models.WebhookEventTaskStatusChanged, map[string]interface{}{
+			"task":       webhookTask(statusTask),
+			"old_status": oldStatus,
+			"new_status": newStatus,
+			"actor":      webhookActor(user),
+		})
+		s.chat.NotifyStatusChanged(statusTask, oldStatus, newStatus, user)
+		s.events.PublishEvent(newTaskEvent(models.EventTaskStatusChanged, workspaceID, statusTask, user, map[string]interface{}{
+			"old_status": oldStatus,
+			"new_status": newStatus,
+		}))
+	}
+
+	// Kirim task terbaru agar board client bisa langsung diperbarui
+	if updatedTask != nil {
+		s.events.PublishEvent(newTaskEvent(models.EventTaskUpdated, workspaceID, updatedTask, user, map[string]interface{}{
+			"task":    updatedTask,
+			"changes": finalUpdates,
+		}))
+	}
+	return nil
+}
+
+// recordStatusChange closes the current status log of the task and opens a
+// new one for newStatus.
+func (s *taskService) recordStatusChange(tx *gorm.DB, task *models.Task, newStatus string, user *models.User) error {
+	statusLogs := s.taskStatusLog.WithTx(tx)
+
+	now := time.Now()
+	lastLog, err := statusLogs.FindLastLog(task.ID)
+	if err != nil {
+		return err
+	}
+	if lastLog != nil {
+		if err := statusLogs.UpdateClockOut(lastLog.ID, now); err != nil {
+			return err
+		}
+	}
+
+	previousStatus := task.Status
+	return statusLogs.Create(&models.TaskStatusLog{
+		TaskID:         task.ID,
+		Status:         newStatus,
+		PreviousStatus: &previousStatus,
+		ChangedBy:      &user.ID,
+		ClockIn:        now,
+	})
+}
+
+// notifyTaskUpdate queues notifications for a status change and for new
+// mentions in the description or notes.
+func (s *taskService) notifyTaskUpdate(notifications NotificationService, task *models.Task, updates map[string]interface{}, user *models.User) error {
+	oldStatus := task.Status
+	oldDescription := task.Description
+	oldNotes := ""
+	if task.Notes != nil {
+		oldNotes = *task.Notes
+	}
+	if title, ok := updates["title"].(string); ok {
+		task.Title = title
+	}
+
+	if newStatus, ok := updates["status"].(string); ok && newStatus != oldStatus {
+		if err := notifications.NotifyStatusChanged(task, oldStatus, newStatus, user); err != nil {
+			return err
+		}
+	}
+	if description, ok := updates["description"].(string); ok {
+		if err := notifications.NotifyMentions(task, oldDescription, description, user); err != nil {
+			return err
+		}
+	}
+	if notes, ok := updates["notes"].(string); ok {
+		if err := notifications.NotifyMentions(task, oldNotes, notes, user); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (s *taskService) SoftDeleteTask(taskID uint, workspaceID uint, user *models.User) error {
 	_, err := s.GetByID(taskID, workspaceID, user)
 	if err != nil {
-		return errors.New("task tidak ditemukan")
+		return i18n.NewError(i18n.CodeTaskNotFound)
 	}
 
 	return s.repo.SoftDeleteTask(taskID)
@@ -239,7 +366,7 @@ func (s *taskService) SoftDeleteTask(taskID uint, workspaceID uint, user *models
 func (s *taskService) DeleteTask(taskID uint, workspaceID uint, user *models.User) error {
 	_, err := s.GetByID(taskID, workspaceID, user)
 	if err != nil {
-		return errors.New("task tidak ditemukan")
+		return i18n.NewError(i18n.CodeTaskNotFound)
 	}
 
 	return s.repo.DeleteTask(taskID)
@@ -248,23 +375,28 @@ func (s *taskService) DeleteTask(taskID uint, workspaceID uint, user *models.Use
 func (s *taskService) AddMember(taskID uint, projectID uint, workspaceID uint, userID uint, role string, currentUser *models.User) error {
 	task, err := s.repo.GetByID(taskID)
 	if err != nil {
-		return errors.New("task tidak ditemukan")
+		return i18n.NewError(i18n.CodeTaskNotFound)
 	}
 
 	if task.Project.WorkspaceID != workspaceID {
-		return errors.New("task tidak ditemukan di workspace ini")
+		return i18n.NewError(i18n.CodeTaskNotInWorkspace)
 	}
 
 	if task.ProjectID != projectID {
-		return errors.New("task tidak ditemukan di project ini")
+		return i18n.NewError(i18n.CodeTaskNotInProject)
 	}
 
 	isMember, err := s.repo.IsUserMember(taskID, userID)
 	if err != nil {
-		return errors.New("gagal memvalidasi member")
+		return i18n.NewError(i18n.CodeMemberCheckFailed)
 	}
 	if isMember {
-		return errors.New("user sudah menjadi member di task ini")
+		return i18n.NewError(i18n.CodeUserAlreadyTaskMember)
+	}
+
+	assignedUser, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return i18n.NewError(i18n.CodeUserNotFound)
 	}
 
 	member := &models.TaskUser{
@@ -273,31 +405,33 @@ func (s *taskService) AddMember(taskID uint, projectID uint, workspaceID uint, u
 		RoleInTask: role,
 		AssignedAt: task.CreatedAt,
 	}
-	if err := s.repo.AddMember(member); err != nil {
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.WithTx(tx).AddMember(member); err != nil {
+			return err
+		}
+		return s.notifications.WithTx(tx).NotifyTaskAssigned(task, assignedUser, currentUser)
+	})
+	if err != nil {
 		return err
 	}
 
-	assignedUser, err := s.userRepo.GetByID(userID)
-	if err == nil && assignedUser.TelegramChatID != nil && *assignedUser.TelegramChatID != "" {
-		message := fmt.Sprintf("Anda telah ditugaskan untuk task baru: %s, kerjakan sebelum %s", task.Title, task.DueDate.Format("02-01-2006 15:04"))
-		go s.telegramService.SendNotification(*assignedUser.TelegramChatID, message)
-	}
-
+	s.chat.NotifyTaskAssigned(task, assignedUser, currentUser)
+	s.events.PublishEvent(newTaskEvent(models.EventMemberAdded, workspaceID, task, currentUser, memberAddedData("task", assignedUser, role)))
 	return nil
 }
 
 func (s *taskService) GetMembers(taskID uint, projectID uint, workspaceID uint, user *models.User) ([]models.TaskUser, error) {
 	task, err := s.repo.GetByID(taskID)
 	if err != nil {
-		return nil, errors.New("task tidak ditemukan")
+		return nil, i18n.NewError(i18n.CodeTaskNotFound)
 	}
 
 	if task.Project.WorkspaceID != workspaceID {
-		return nil, errors.New("task tidak ditemukan di workspace ini")
+		return nil, i18n.NewError(i18n.CodeTaskNotInWorkspace)
 	}
 
 	if task.ProjectID != projectID {
-		return nil, errors.New("task tidak ditemukan di project ini")
+		return nil, i18n.NewError(i18n.CodeTaskNotInProject)
 	}
 
 	if user.Role == "admin" {
@@ -307,20 +441,20 @@ func (s *taskService) GetMembers(taskID uint, projectID uint, workspaceID uint,
 	if user.Role != "admin" {
 		isTaskMember, err := s.repo.IsUserMember(taskID, user.ID)
 		if err != nil {
-			return nil, errors.New("gagal memvalidasi member task")
+			return nil, i18n.NewError(i18n.CodeTaskMemberCheckFailed)
 		}
 		if !isTaskMember {
-			return nil, errors.New("anda bukan member dari task ini")
+			return nil, i18n.NewError(i18n.CodeNotTaskMember)
 		}
 
 		isProjectMember, err := s.repo.IsUserInProject(task.ProjectID, user.ID)
 		if err != nil || !isProjectMember {
-			return nil, errors.New("hanya member project yang boleh melihat members task")
+			return nil, i18n.NewError(i18n.CodeProjectMemberOnlyTaskMembers)
 		}
 
 		isWorkspaceMember, err := s.repo.IsProjectInWorkspace(task.ProjectID, workspaceID)
 		if err != nil || !isWorkspaceMember {
-			return nil, errors.New("task tidak ditemukan di workspace ini")
+			return nil, i18n.NewError(i18n.CodeTaskNotInWorkspace)
 		}
 
 	}
@@ -330,15 +464,15 @@ func (s *taskService) GetMembers(taskID uint, projectID uint, workspaceID uint,
 func (s *taskService) DeleteMember(taskID uint, projectID uint, workspaceID uint, userID uint, currentUser *models.User) error {
 	task, err := s.repo.GetByID(taskID)
 	if err != nil {
-		return errors.New("task tidak ditemukan")
+		return i18n.NewError(i18n.CodeTaskNotFound)
 	}
 
 	if task.Project.WorkspaceID != workspaceID {
-		return errors.New("task tidak ditemukan di workspace ini")
+		return i18n.NewError(i18n.CodeTaskNotInWorkspace)
 	}
 
 	if task.ProjectID != projectID {
-		return errors.New("task tidak ditemukan di project ini")
+		return i18n.NewError(i18n.CodeTaskNotInProject)
 	}
 
 	return s.repo.DeleteMember(taskID, userID)
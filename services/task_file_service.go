@@ -1,11 +1,11 @@
 package services
 
 import (
-	"errors"
 	"io"
 	"mime/multipart"
 	"os"
 	"path/filepath"
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/repositories"
 
@@ -29,19 +29,19 @@ func NewTaskFileService(repo *repositories.TaskFileRepository, taskRepo reposito
 func (s *TaskFileService) UploadFile(workspaceID, projectID, taskID, userID uint, file *multipart.FileHeader) (*models.TaskFile, error) {
 	task, err := s.taskRepo.GetByID(taskID)
 	if err != nil {
-		return nil, errors.New("task not found")
+		return nil, i18n.NewError(i18n.CodeTaskNotFound)
 	}
 	if task.ProjectID != projectID {
-		return nil, errors.New("task not found in project")
+		return nil, i18n.NewError(i18n.CodeTaskNotInProject)
 	}
 
 	project, err := s.projectRepo.GetByID(projectID)
 	if err != nil {
-		return nil, errors.New("project not found")
+		return nil, i18n.NewError(i18n.CodeProjectNotFound)
 	}
 
 	if project.WorkspaceID != workspaceID {
-		return nil, errors.New("project not found in workspace")
+		return nil, i18n.NewError(i18n.CodeProjectNotInWorkspace)
 	}
 
 	src, err := file.Open()
@@ -55,12 +55,12 @@ func (s *TaskFileService) UploadFile(workspaceID, projectID, taskID, userID uint
 
 	uploadDir := "./uploads/tasks/files"
 	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		return nil, errors.New("gagal membuat directory upload")
+		return nil, i18n.NewError(i18n.CodeUploadDirFailed)
 	}
 
 	filePath, err := filepath.Abs(filepath.Join(uploadDir, fileName))
 	if err != nil {
-		return nil, errors.New("gagal membuat absolute path")
+		return nil, i18n.NewError(i18n.CodeUploadPathFailed)
 	}
 
 	out, err := os.Create(filePath)
@@ -95,19 +95,19 @@ func (s *TaskFileService) UploadFile(workspaceID, projectID, taskID, userID uint
 func (s *TaskFileService) GetFilesByTaskID(taskID, projectID, workspaceID uint) ([]models.TaskFile, error) {
 	task, err := s.taskRepo.GetByID(taskID)
 	if err != nil {
-		return nil, errors.New("task not found")
+		return nil, i18n.NewError(i18n.CodeTaskNotFound)
 	}
 	if task.ProjectID != projectID {
-		return nil, errors.New("task not found in project")
+		return nil, i18n.NewError(i18n.CodeTaskNotInProject)
 	}
 
 	project, err := s.projectRepo.GetByID(projectID)
 	if err != nil {
-		return nil, errors.New("project not found")
+		return nil, i18n.NewError(i18n.CodeProjectNotFound)
 	}
 
 	if project.WorkspaceID != workspaceID {
-		return nil, errors.New("project not found in workspace")
+		return nil, i18n.NewError(i18n.CodeProjectNotInWorkspace)
 	}
 	return s.repo.FindByTaskID(taskID)
 }
@@ -142,7 +142,7 @@ func (s *TaskFileService) ValidateFile(file *multipart.FileHeader) error {
 	const maxFileSize = 10 * 1024 * 1024
 
 	if file.Size > maxFileSize {
-		return errors.New("file size exceeds 10MB limit")
+		return i18n.NewError(i18n.CodeFileTooLarge, "10MB")
 	}
 
 	allowedTypes := map[string]bool{
@@ -157,7 +157,7 @@ func (s *TaskFileService) ValidateFile(file *multipart.FileHeader) error {
 
 	contentType := file.Header.Get("Content-Type")
 	if !allowedTypes[contentType] {
-		return errors.New("file type not allowed")
+		return i18n.NewError(i18n.CodeFileTypeNotAllowed)
 	}
 
 	return nil
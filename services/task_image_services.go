@@ -2,10 +2,10 @@
 package services
 
 import (
-	"errors"
 	"mime/multipart"
 	"os"
 	"path/filepath"
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/repositories"
 
@@ -24,6 +24,7 @@ type taskImageService struct {
 	taskRepo      repositories.TaskRepository
 	projectRepo   repositories.ProjectRepository
 	workspaceRepo repositories.WorkspaceRepository
+	events        EventPublisher
 }
 
 func NewTaskImageService(
@@ -31,34 +32,36 @@ func NewTaskImageService(
 	taskRepo repositories.TaskRepository,
 	projectRepo repositories.ProjectRepository,
 	workspaceRepo repositories.WorkspaceRepository,
+	events EventPublisher,
 ) TaskImageService {
 	return &taskImageService{
 		repo:          repo,
 		taskRepo:      taskRepo,
 		projectRepo:   projectRepo,
 		workspaceRepo: workspaceRepo,
+		events:        events,
 	}
 }
 
 func (s *taskImageService) UploadTaskImage(taskID uint, workspaceID uint, file *multipart.FileHeader, user *models.User) (*models.TaskImage, error) {
 	task, err := s.taskRepo.GetByID(taskID)
 	if err != nil {
-		return nil, errors.New("task tidak ditemukan")
+		return nil, i18n.NewError(i18n.CodeTaskNotFound)
 	}
 
 	if task.Project.WorkspaceID != workspaceID {
-		return nil, errors.New("task tidak ditemukan di workspace ini")
+		return nil, i18n.NewError(i18n.CodeTaskNotInWorkspace)
 	}
 
 	if user.Role != "admin" {
 		hasWorkspaceAccess, err := s.workspaceRepo.IsUserMember(workspaceID, user.ID)
 		if err != nil || !hasWorkspaceAccess {
-			return nil, errors.New("tidak memiliki akses ke workspace ini")
+			return nil, i18n.NewError(i18n.CodeWorkspaceAccessDenied)
 		}
 
 		isTaskMember, err := s.taskRepo.IsUserMember(taskID, user.ID)
 		if err != nil || !isTaskMember {
-			return nil, errors.New("hanya task member yang boleh upload image")
+			return nil, i18n.NewError(i18n.CodeTaskMemberOnlyUpload)
 		}
 	}
 
@@ -72,12 +75,12 @@ func (s *taskImageService) UploadTaskImage(taskID uint, workspaceID uint, file *
 
 	fileType := file.Header.Get("Content-Type")
 	if !allowedTypes[fileType] {
-		return nil, errors.New("format file tidak didukung. Hanya JPEG, PNG, GIF, WebP")
+		return nil, i18n.NewError(i18n.CodeImageTypeNotAllowed)
 	}
 
 	// Validasi file size (max 5MB)
 	if file.Size > 5*1024*1024 {
-		return nil, errors.New("ukuran file maksimal 5MB")
+		return nil, i18n.NewError(i18n.CodeFileTooLarge, "5MB")
 	}
 
 	// Generate unique filename
@@ -87,13 +90,13 @@ func (s *taskImageService) UploadTaskImage(taskID uint, workspaceID uint, file *
 	// Create uploads directory
 	uploadDir := "./uploads/tasks"
 	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		return nil, errors.New("gagal membuat directory upload")
+		return nil, i18n.NewError(i18n.CodeUploadDirFailed)
 	}
 
 	// Save file
 	filePath := filepath.Join(uploadDir, fileName)
 	if err := saveUploadedFile(file, filePath); err != nil {
-		return nil, errors.New("gagal menyimpan file: " + err.Error())
+		return nil, i18n.NewError(i18n.CodeFileSaveFailed, err.Error())
 	}
 
 	// Create relative URL
@@ -109,9 +112,10 @@ func (s *taskImageService) UploadTaskImage(taskID uint, workspaceID uint, file *
 	if err := s.repo.CreateTaskImage(taskImage); err != nil {
 		// Rollback: delete file jika gagal save ke database
 		os.Remove(filePath)
-		return nil, errors.New("gagal menyimpan data image: " + err.Error())
+		return nil, i18n.NewError(i18n.CodeImageSaveFailed, err.Error())
 	}
 
+	s.publishImageUploaded(task, workspaceID, taskImage, user)
 	return taskImage, nil
 }
 
@@ -119,19 +123,19 @@ func (s *taskImageService) UploadTaskImage(taskID uint, workspaceID uint, file *
 func (s *taskImageService) UploadTaskImageWithType(taskID uint, workspaceID uint, file *multipart.FileHeader, user *models.User, imgType string) (*models.TaskImage, error) {
 	task, err := s.taskRepo.GetByID(taskID)
 	if err != nil {
-		return nil, errors.New("task tidak ditemukan")
+		return nil, i18n.NewError(i18n.CodeTaskNotFound)
 	}
 	if task.Project.WorkspaceID != workspaceID {
-		return nil, errors.New("task tidak ditemukan di workspace ini")
+		return nil, i18n.NewError(i18n.CodeTaskNotInWorkspace)
 	}
 	if user.Role != "admin" {
 		hasWorkspaceAccess, err := s.workspaceRepo.IsUserMember(workspaceID, user.ID)
 		if err != nil || !hasWorkspaceAccess {
-			return nil, errors.New("tidak memiliki akses ke workspace ini")
+			return nil, i18n.NewError(i18n.CodeWorkspaceAccessDenied)
 		}
 		isTaskMember, err := s.taskRepo.IsUserMember(taskID, user.ID)
 		if err != nil || !isTaskMember {
-			return nil, errors.New("hanya task member yang boleh upload image")
+			return nil, i18n.NewError(i18n.CodeTaskMemberOnlyUpload)
 		}
 	}
 	allowedTypes := map[string]bool{
@@ -143,20 +147,20 @@ func (s *taskImageService) UploadTaskImageWithType(taskID uint, workspaceID uint
 	}
 	fileType := file.Header.Get("Content-Type")
 	if !allowedTypes[fileType] {
-		return nil, errors.New("format file tidak didukung. Hanya JPEG, PNG, GIF, WebP")
+		return nil, i18n.NewError(i18n.CodeImageTypeNotAllowed)
 	}
 	if file.Size > 5*1024*1024 {
-		return nil, errors.New("ukuran file maksimal 5MB")
+		return nil, i18n.NewError(i18n.CodeFileTooLarge, "5MB")
 	}
 	fileExt := filepath.Ext(file.Filename)
 	fileName := uuid.New().String() + fileExt
 	uploadDir := "./uploads/tasks"
 	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		return nil, errors.New("gagal membuat directory upload")
+		return nil, i18n.NewError(i18n.CodeUploadDirFailed)
 	}
 	filePath := filepath.Join(uploadDir, fileName)
 	if err := saveUploadedFile(file, filePath); err != nil {
-		return nil, errors.New("gagal menyimpan file: " + err.Error())
+		return nil, i18n.NewError(i18n.CodeFileSaveFailed, err.Error())
 	}
 	relativeURL := "/uploads/tasks/" + fileName
 	taskImage := &models.TaskImage{
@@ -167,23 +171,31 @@ func (s *taskImageService) UploadTaskImageWithType(taskID uint, workspaceID uint
 	}
 	if err := s.repo.CreateTaskImage(taskImage); err != nil {
 		os.Remove(filePath)
-		return nil, errors.New("gagal menyimpan data image: " + err.Error())
+		return nil, i18n.NewError(i18n.CodeImageSaveFailed, err.Error())
 	}
+	s.publishImageUploaded(task, workspaceID, taskImage, user)
 	return taskImage, nil
 }
 
+func (s *taskImageService) publishImageUploaded(task *models.Task, workspaceID uint, image *models.TaskImage, user *models.User) {
+	s.events.PublishEvent(newTaskEvent(models.EventImageUploaded, workspaceID, task, user, map[string]interface{}{
+		"scope": "task",
+		"image": image,
+	}))
+}
+
 func (s *taskImageService) GetTaskImages(taskID uint, projectID uint, workspaceID uint, user *models.User) ([]models.TaskImage, error) {
 	task, err := s.taskRepo.GetByID(taskID)
 	if err != nil {
-		return nil, errors.New("task tidak ditemukan")
+		return nil, i18n.NewError(i18n.CodeTaskNotFound)
 	}
 
 	if task.Project.WorkspaceID != workspaceID {
-		return nil, errors.New("task tidak ditemukan di workspace ini")
+		return nil, i18n.NewError(i18n.CodeTaskNotInWorkspace)
 	}
 
 	if task.ProjectID != projectID {
-		return nil, errors.New("task tidak ditemukan di project ini")
+		return nil, i18n.NewError(i18n.CodeTaskNotInProject)
 	}
 
 	if user.Role == "admin" {
@@ -192,17 +204,17 @@ func (s *taskImageService) GetTaskImages(taskID uint, projectID uint, workspaceI
 
 	hasWorkspaceAccess, err := s.workspaceRepo.IsUserMember(workspaceID, user.ID)
 	if err != nil || !hasWorkspaceAccess {
-		return nil, errors.New("tidak memiliki akses ke workspace ini")
+		return nil, i18n.NewError(i18n.CodeWorkspaceAccessDenied)
 	}
 
 	isTaskMember, err := s.taskRepo.IsUserMember(taskID, user.ID)
 	if err != nil || !isTaskMember {
-		return nil, errors.New("hanya task member yang boleh melihat image")
+		return nil, i18n.NewError(i18n.CodeTaskMemberOnlyImages)
 	}
 
 	isProjectMember, err := s.taskRepo.IsUserInProject(task.ProjectID, user.ID)
 	if err != nil || !isProjectMember {
-		return nil, errors.New("hanya project member yang boleh melihat image")
+		return nil, i18n.NewError(i18n.CodeProjectMemberOnlyImages)
 	}
 
 	return s.repo.GetTaskImages(taskID)
@@ -211,25 +223,25 @@ func (s *taskImageService) GetTaskImages(taskID uint, projectID uint, workspaceI
 func (s *taskImageService) DeleteTaskImage(imageID uint, workspaceID uint, user *models.User) error {
 	image, err := s.repo.GetTaskImageByID(imageID)
 	if err != nil {
-		return errors.New("image tidak ditemukan")
+		return i18n.NewError(i18n.CodeImageNotFound)
 	}
 
 	task, err := s.taskRepo.GetByID(image.TaskID)
 	if err != nil {
-		return errors.New("task tidak ditemukan")
+		return i18n.NewError(i18n.CodeTaskNotFound)
 	}
 
 	if task.Project.WorkspaceID != workspaceID {
-		return errors.New("task tidak ditemukan di workspace ini")
+		return i18n.NewError(i18n.CodeTaskNotInWorkspace)
 	}
 
 	if user.Role != "admin" {
 		hasWorkspaceAccess, err := s.workspaceRepo.IsUserMember(workspaceID, user.ID)
 		if err != nil || !hasWorkspaceAccess {
-			return errors.New("tidak memiliki akses ke workspace ini")
+			return i18n.NewError(i18n.CodeWorkspaceAccessDenied)
 		}
 		if image.UploadedBy != user.ID {
-			return errors.New("hanya uploader yang boleh menghapus image")
+			return i18n.NewError(i18n.CodeUploaderOnlyDelete)
 		}
 	}
 
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"project-management-backend/config"
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"project-management-backend/repositories"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	telegramLinkCodeTTL      = 10 * time.Minute
+	telegramLinkCodeLength   = 8
+	telegramLinkCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+var errTelegramLinkCodeUsed = errors.New("telegram link code already used")
+
+type TelegramLinkCodeResult struct {
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+	DeepLink  string    `json:"deep_link,omitempty"`
+}
+
+// TelegramBotService handles commands sent to the bot through the webhook
+// and manages the one-time codes used to link a chat to an account.
+type TelegramBotService interface {
+	HandleUpdate(update *models.TelegramUpdate)
+	CreateLinkCode(user *models.User) (*TelegramLinkCodeResult, error)
+	Unlink(user *models.User) error
+}
+
+type telegramBotService struct {
+	linkCodeRepo      repositories.TelegramLinkCodeRepository
+	userRepo          repositories.UserRepository
+	taskRepo          repositories.TaskRepository
+	workspaceRepo     repositories.WorkspaceRepository
+	taskService       TaskService
+	attendanceService *AttendanceService
+	telegramService   TelegramService
+	botUsername       string
+}
+
+func NewTelegramBotService(
+	linkCodeRepo repositories.TelegramLinkCodeRepository,
+	userRepo repositories.UserRepository,
+	taskRepo repositories.TaskRepository,
+	workspaceRepo repositories.WorkspaceRepository,
+	taskService TaskService,
+	attendanceService *AttendanceService,
+	telegramService TelegramService,
+) TelegramBotService {
+	return &telegramBotService{
+		linkCodeRepo:      linkCodeRepo,
+		userRepo:          userRepo,
+		taskRepo:          taskRepo,
+		workspaceRepo:     workspaceRepo,
+		taskService:       taskService,
+		attendanceService: attendanceService,
+		telegramService:   telegramService,
+		botUsername:       os.Getenv("TELEGRAM_BOT_USERNAME"),
+	}
+}
+
+func (s *telegramBotService) CreateLinkCode(user *models.User) (*TelegramLinkCodeResult, error) {
+	code, err := generateLinkCode()
+	if err != nil {
+		return nil, i18n.NewError(i18n.CodeTelegramLinkCodeFailed)
+	}
+
+	// Kode lama yang belum dipakai tidak berlaku lagi
+	if err := s.linkCodeRepo.DeleteUnusedByUserID(user.ID); err != nil {
+		return nil, i18n.NewError(i18n.CodeTelegramLinkCodeFailed)
+	}
+
+	linkCode := &models.TelegramLinkCode{
+		UserID:    user.ID,
+		Code:      code,
+		ExpiresAt: time.Now().Add(telegramLinkCodeTTL),
+	}
+	if err := s.linkCodeRepo.Create(linkCode); err != nil {
+		return nil, i18n.NewError(i18n.CodeTelegramLinkCodeFailed)
+	}
+
+	result := &TelegramLinkCodeResult{Code: linkCode.Code, ExpiresAt: linkCode.ExpiresAt}
+	if s.botUsername != "" {
+		result.DeepLink = fmt.Sprintf("https://t.me/%s?start=%s", s.botUsername, linkCode.Code)
+	}
+	return result, nil
+}
+
+func (s *telegramBotService) Unlink(user *models.User) error {
+	return s.userRepo.UpdateFields(user.ID, map[string]interface{}{"telegram_chat_id": nil})
+}
+
+func (s *telegramBotService) HandleUpdate(update *models.TelegramUpdate) {
+	if update.Message == nil || !strings.HasPrefix(update.Message.Text, "/") {
+		return
+	}
+
+	message := update.Message
+	chatID := strconv.FormatInt(message.Chat.ID, 10)
+	command, args := parseTelegramCommand(message.Text)
+
+	lang := i18n.DefaultLang
+	if message.From != nil {
+		if l := i18n.Normalize(message.From.LanguageCode); l != "" {
+			lang = l
+		}
+	}
+
+	// /start <kode> dikirim Telegram saat user membuka deep link
+	if command == "/link" || (command == "/start" && args != "") {
+		s.reply(chatID, s.link(chatID, args, lang))
+		return
+	}
+
+	user, err := s.userRepo.GetByTelegramChatID(chatID)
+	if err != nil {
+		s.reply(chatID, i18n.T(lang, i18n.LabelTelegramNotLinked))
+		return
+	}
+	lang = userLanguage(user)
+
+	var reply string
+	switch command {
+	case "/start", "/help":
+		reply = i18n.T(lang, i18n.LabelTelegramHelp)
+	case "/mytasks":
+		reply = s.myTasks(user, lang)
+	case "/done":
+		reply = s.done(user, args, lang)
+	case "/attend":
+		reply = s.attend(user, args, lang)
+	default:
+		reply = i18n.T(lang, i18n.LabelTelegramUnknownCommand)
+	}
+	s.reply(chatID, reply)
+}
+
+func (s *telegramBotService) link(chatID, code, lang string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return i18n.T(lang, i18n.LabelTelegramLinkUsage)
+	}
+
+	linkCode, err := s.linkCodeRepo.GetValid(code, time.Now())
+	if err != nil {
+		return i18n.T(lang, i18n.LabelTelegramLinkInvalid)
+	}
+
+	// Kode dipakai dan chat dihubungkan dalam satu transaksi, sehingga kode
+	// yang sama tidak bisa dipakai dua chat sekaligus
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		used, err := s.linkCodeRepo.MarkUsed(tx, linkCode.ID, time.Now())
+		if err != nil {
+			return err
+		}
+		if !used {
+			return errTelegramLinkCodeUsed
+		}
+		return s.userRepo.LinkTelegramChat(tx, linkCode.UserID, chatID)
+	})
+	if errors.Is(err, errTelegramLinkCodeUsed) {
+		return i18n.T(lang, i18n.LabelTelegramLinkInvalid)
+	}
+	if err != nil {
+		log.Printf("[TelegramBot] Failed to link chat %s to user %d: %v", chatID, linkCode.UserID, err)
+		return i18n.T(lang, i18n.LabelTelegramFailed, i18n.T(lang, i18n.CodeTelegramLinkFailed))
+	}
+
+	lang = userLanguage(&linkCode.User)
+	return i18n.T(lang, i18n.LabelTelegramLinked, linkCode.User.Email)
+}
+
+func (s *telegramBotService) myTasks(user *models.User, lang string) string {
+	tasks, err := s.taskRepo.GetUserOpenTasks(user.ID)
+	if err != nil {
+		return i18n.T(lang, i18n.LabelTelegramFailed, i18n.T(lang, i18n.CodeFetchFailed, "task"))
+	}
+	if len(tasks) == 0 {
+		return i18n.T(lang, i18n.LabelTelegramNoTasks)
+	}
+
+	loc := UserLocation(user)
+	lines := []string{i18n.T(lang, i18n.LabelTelegramMyTasks, len(tasks))}
+	for _, task := range tasks {
+		lines = append(lines, i18n.T(lang, i18n.LabelTelegramTaskLine, task.ID, task.Title, task.Status, i18n.FormatDateTime(lang, task.DueDate.In(loc))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s *telegramBotService) done(user *models.User, args, lang string) string {
+	taskID, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(args), "#"), 10, 64)
+	if err != nil {
+		return i18n.T(lang, i18n.LabelTelegramDoneUsage)
+	}
+
+	task, err := s.taskRepo.GetByID(uint(taskID))
+	if err != nil {
+		return i18n.T(lang, i18n.LabelTelegramFailed, i18n.T(lang, i18n.CodeTaskNotFound))
+	}
+
+	err = s.taskService.UpdateTask(task.ID, map[string]interface{}{"status": "done"}, task.Project.WorkspaceID, user)
+	if err != nil {
+		_, msg := i18n.Localize(lang, err)
+		return i18n.T(lang, i18n.LabelTelegramFailed, msg)
+	}
+
+	return i18n.T(lang, i18n.LabelTelegramDone, task.ID, task.Title)
+}
+
+func (s *telegramBotService) attend(user *models.User, args, lang string) string {
+	args = strings.TrimSpace(args)
+
+	workspaces, err := s.workspaceRepo.GetWorkspacesByUserID(user.ID)
+	if err != nil {
+		return i18n.T(lang, i18n.LabelTelegramFailed, i18n.T(lang, i18n.CodeFetchFailed, "workspace"))
+	}
+	if len(workspaces) == 0 {
+		return i18n.T(lang, i18n.LabelTelegramNoWorkspace)
+	}
+
+	var workspace *models.Workspace
+	if strings.HasPrefix(args, "#") {
+		idPart, rest, _ := strings.Cut(args[1:], " ")
+		workspaceID, err := strconv.ParseUint(idPart, 10, 64)
+		if err != nil {
+			return i18n.T(lang, i18n.LabelTelegramAttendUsage)
+		}
+		for i := range workspaces {
+			if workspaces[i].ID == uint(workspaceID) {
+				workspace = &workspaces[i]
+			}
+		}
+		if workspace == nil {
+			return i18n.T(lang, i18n.LabelTelegramFailed, i18n.T(lang, i18n.CodeNotWorkspaceMember))
+		}
+		args = strings.TrimSpace(rest)
+	} else if len(workspaces) == 1 {
+		workspace = &workspaces[0]
+	}
+
+	if args == "" {
+		return i18n.T(lang, i18n.LabelTelegramAttendUsage)
+	}
+	if workspace == nil {
+		options := make([]string, 0, len(workspaces))
+		for _, w := range workspaces {
+			options = append(options, fmt.Sprintf("#%d %s", w.ID, w.Name))
+		}
+		return i18n.T(lang, i18n.LabelTelegramAttendChoose, strings.Join(options, "\n"))
+	}
+
+	attendance := &models.Attendance{
+		WorkspaceID: workspace.ID,
+		UserID:      user.ID,
+		Activity:    args,
+		ClockIn:     time.Now(),
+	}
+	if err := s.attendanceService.SubmitAttendance(attendance); err != nil {
+		_, msg := i18n.Localize(lang, err)
+		return i18n.T(lang, i18n.LabelTelegramFailed, msg)
+	}
+
+	return i18n.T(lang, i18n.LabelTelegramAttended, workspace.Name, attendance.ClockIn.In(UserLocation(user)).Format("15:04"))
+}
+
+func (s *telegramBotService) reply(chatID, text string) {
+	if err := s.telegramService.SendNotification(chatID, text); err != nil {
+		log.Printf("[TelegramBot] Failed to reply to chat %s: %v", chatID, err)
+	}
+}
+
+// parseTelegramCommand splits "/done@MyBot 12" into "/done" and "12".
+func parseTelegramCommand(text string) (string, string) {
+	command, args, _ := strings.Cut(strings.TrimSpace(text), " ")
+	if i := strings.Index(command, "@"); i >= 0 {
+		command = command[:i]
+	}
+	return strings.ToLower(command), strings.TrimSpace(args)
+}
+
+func generateLinkCode() (string, error) {
+	buf := make([]byte, telegramLinkCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	for i, b := range buf {
+		buf[i] = telegramLinkCodeAlphabet[int(b)%len(telegramLinkCodeAlphabet)]
+	}
+	return string(buf), nil
+}
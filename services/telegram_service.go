@@ -4,27 +4,43 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"mime/multipart"
 	"net/http"
+	"strings"
 )
 
 type TelegramService interface {
 	SendNotification(chatID string, message string) error
+	SendDocument(chatID string, fileName string, data []byte, caption string) error
 }
 
+const defaultTelegramAPIBaseURL = "https://api.telegram.org"
+
 type telegramService struct {
-	botToken string
-	client   *http.Client
+	botToken   string
+	apiBaseURL string
+	client     *http.Client
 }
 
-func NewTelegramService(botToken string) TelegramService {
+// NewTelegramService creates the Bot API client. apiBaseURL may point to a
+// local fake Telegram server for testing; empty uses api.telegram.org.
+func NewTelegramService(botToken string, apiBaseURL string) TelegramService {
+	if apiBaseURL == "" {
+		apiBaseURL = defaultTelegramAPIBaseURL
+	}
 	return &telegramService{
-		botToken: botToken,
-		client:   &http.Client{},
+		botToken:   botToken,
+		apiBaseURL: strings.TrimRight(apiBaseURL, "/"),
+		client:     &http.Client{},
 	}
 }
 
+func (s *telegramService) methodURL(method string) string {
+	return fmt.Sprintf("%s/bot%s/%s", s.apiBaseURL, s.botToken, method)
+}
+
 func (s *telegramService) SendNotification(chatID string, message string) error {
-	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", s.botToken)
+	apiURL := s.methodURL("sendMessage")
 
 	requestBody, err := json.Marshal(map[string]string{
 		"chat_id": chatID,
@@ -52,3 +68,43 @@ func (s *telegramService) SendNotification(chatID string, message string) error
 
 	return nil
 }
+
+func (s *telegramService) SendDocument(chatID string, fileName string, data []byte, caption string) error {
+	apiURL := s.methodURL("sendDocument")
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("chat_id", chatID)
+	if caption != "" {
+		writer.WriteField("caption", caption)
+	}
+
+	part, err := writer.CreateFormFile("document", fileName)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(data); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", apiURL, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to send document, status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
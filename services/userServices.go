@@ -1,7 +1,7 @@
 package services
 
 import (
-	"errors"
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/repositories"
 )
@@ -45,7 +45,7 @@ type PaginatedUsersResponse struct {
 
 func (s *userService) GetAllUsers(currentUser *models.User) ([]models.User, error) {
 	if currentUser.Role != "admin" {
-		return nil, errors.New("hanya admin yang bisa mengakses semua user")
+		return nil, i18n.NewError(i18n.CodeAdminOnlyAllUsers)
 	}
 
 	return s.repo.GetAllUsers()
@@ -53,7 +53,7 @@ func (s *userService) GetAllUsers(currentUser *models.User) ([]models.User, erro
 
 func (s *userService) GetAllUsersPaginated(page, limit int, currentUser *models.User) (PaginatedUsersResponse, error) {
 	if currentUser.Role != "admin" {
-		return PaginatedUsersResponse{}, errors.New("hanya admin yang bisa mengakses semua user")
+		return PaginatedUsersResponse{}, i18n.NewError(i18n.CodeAdminOnlyAllUsers)
 	}
 
 	if page < 1 {
@@ -84,7 +84,7 @@ func (s *userService) GetAllUsersPaginated(page, limit int, currentUser *models.
 
 func (s *userService) GetAllUsersWithFilters(filters UserFilters, currentUser *models.User) (PaginatedUsersResponse, error) {
 	if currentUser.Role != "admin" {
-		return PaginatedUsersResponse{}, errors.New("hanya admin yang bisa mengakses semua user")
+		return PaginatedUsersResponse{}, i18n.NewError(i18n.CodeAdminOnlyAllUsers)
 	}
 
 	if filters.Page < 1 {
@@ -123,7 +123,7 @@ func (s *userService) GetAllUsersWithFilters(filters UserFilters, currentUser *m
 
 func (s *userService) GetUserByID(userID uint, currentUser *models.User) (*models.User, error) {
 	if currentUser.Role != "admin" && currentUser.ID != userID {
-		return nil, errors.New("hanya bisa melihat profil sendiri")
+		return nil, i18n.NewError(i18n.CodeOwnProfileOnly)
 	}
 
 	return s.repo.GetUserByID(userID)
@@ -131,11 +131,11 @@ func (s *userService) GetUserByID(userID uint, currentUser *models.User) (*model
 
 func (s *userService) SearchUsers(query string, currentUser *models.User) ([]models.User, error) {
 	if currentUser.Role != "admin" {
-		return nil, errors.New("hanya admin yang bisa mencari semua user")
+		return nil, i18n.NewError(i18n.CodeAdminOnlySearchUsers)
 	}
 
 	if len(query) < 2 {
-		return nil, errors.New("minimal 2 karakter untuk pencarian")
+		return nil, i18n.NewError(i18n.CodeSearchQueryTooShort)
 	}
 
 	return s.repo.SearchUsers(query)
@@ -143,7 +143,7 @@ func (s *userService) SearchUsers(query string, currentUser *models.User) ([]mod
 
 func (s *userService) GetUsersWithDetails(userIDs []uint, currentUser *models.User) ([]models.User, error) {
 	if currentUser.Role != "admin" {
-		return nil, errors.New("hanya admin yang bisa mengakses detail multiple users")
+		return nil, i18n.NewError(i18n.CodeAdminOnlyMultipleUsers)
 	}
 
 	return s.repo.GetUsersWithDetails(userIDs)
@@ -161,5 +161,17 @@ func (s *userService) IsUserMemberOfWorkspace(userID uint, workspaceID uint) (bo
 }
 
 func (s *userService) DeleteUser(userID uint, currentUser *models.User) error {
+	// Anggota workspace yang mewajibkan 2FA hanya bisa dihapus admin yang
+	// sudah mengaktifkan 2FA
+	if !currentUser.TwoFactorEnabled {
+		protected, err := s.repo.IsMemberOfTwoFactorWorkspace(userID)
+		if err != nil {
+			return i18n.NewError(i18n.CodeInternalError)
+		}
+		if protected {
+			return i18n.NewError(i18n.CodeWorkspaceTwoFactorRequired)
+		}
+	}
+
 	return s.repo.DeleteUser(userID)
 }
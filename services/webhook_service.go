@@ -0,0 +1,473 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"project-management-backend/repositories"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	webhookTimeout            = 10 * time.Second
+	webhookPollInterval       = 2 * time.Second
+	webhookBatchSize          = 20
+	webhookClaimTimeout       = 15 * time.Minute
+	webhookConcurrency        = 5
+	webhookResponseBodyLimit  = 2048
+	defaultWebhookMaxAttempts = 6
+)
+
+// Header yang dikirim bersama setiap payload webhook
+const (
+	WebhookHeaderEvent     = "X-Webhook-Event"
+	WebhookHeaderDelivery  = "X-Webhook-Delivery"
+	WebhookHeaderTimestamp = "X-Webhook-Timestamp"
+	WebhookHeaderSignature = "X-Webhook-Signature"
+)
+
+type WebhookInput struct {
+	URL          *string  `json:"url"`
+	Events       []string `json:"events"`
+	IsActive     *bool    `json:"is_active"`
+	RotateSecret bool     `json:"rotate_secret"`
+}
+
+// WebhookService manages workspace webhook subscriptions and delivers
+// events to them. Publish only queues a delivery; the worker started by
+// Start sends it and retries failures with exponential backoff.
+type WebhookService interface {
+	Start()
+	Publish(workspaceID uint, event string, data interface{})
+	List(workspaceID uint) ([]models.WorkspaceWebhook, error)
+	Create(workspaceID uint, input WebhookInput, user *models.User) (*models.WorkspaceWebhook, error)
+	Update(workspaceID, webhookID uint, input WebhookInput) (*models.WorkspaceWebhook, error)
+	Delete(workspaceID, webhookID uint) error
+	Deliveries(workspaceID, webhookID uint, limit, offset int) ([]models.WebhookDelivery, int64, error)
+	SendTest(workspaceID, webhookID uint, user *models.User) (*models.WebhookDelivery, error)
+	Redeliver(workspaceID, webhookID, deliveryID uint) (*models.WebhookDelivery, error)
+}
+
+type webhookService struct {
+	repo        repositories.WebhookRepository
+	client      *http.Client
+	maxAttempts int
+	wake        chan struct{}
+}
+
+func NewWebhookService(repo repositories.WebhookRepository) WebhookService {
+	maxAttempts := defaultWebhookMaxAttempts
+	if n, err := strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS")); err == nil && n > 0 {
+		maxAttempts = n
+	}
+
+	return &webhookService{
+		repo:        repo,
+		client:      newOutboundHTTPClient(webhookTimeout),
+		maxAttempts: maxAttempts,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// SignWebhookPayload returns the value of the X-Webhook-Signature header:
+// "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>".
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *webhookService) Publish(workspaceID uint, event string, data interface{}) {
+	webhooks, err := s.repo.GetActiveByWorkspaceID(workspaceID)
+	if err != nil {
+		log.Printf("[Webhook] Failed to load webhooks of workspace %d: %v", workspaceID, err)
+		return
+	}
+
+	queued := false
+	for i := range webhooks {
+		webhook := &webhooks[i]
+		if !webhook.Subscribes(event) {
+			continue
+		}
+		if _, err := s.queue(webhook, event, data); err != nil {
+			log.Printf("[Webhook] Failed to queue %s for webhook %d: %v", event, webhook.ID, err)
+			continue
+		}
+		queued = true
+	}
+
+	if queued {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *webhookService) queue(webhook *models.WorkspaceWebhook, event string, data interface{}) (*models.WebhookDelivery, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"event":        event,
+		"workspace_id": webhook.WorkspaceID,
+		"occurred_at":  time.Now().UTC().Format(time.RFC3339),
+		"data":         data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &models.WebhookDelivery{
+		WebhookID:     webhook.ID,
+		Event:         event,
+		Payload:       string(payload),
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: time.Now(),
+	}
+	if err := s.repo.CreateDelivery(delivery); err != nil {
+		return nil, err
+	}
+	delivery.Webhook = *webhook
+	return delivery, nil
+}
+
+func (s *webhookService) Start() {
+	go s.requeueStale()
+
+	go func() {
+		timer := time.NewTimer(0)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-timer.C:
+			case <-s.wake:
+			}
+
+			s.processDue()
+
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(webhookPollInterval)
+		}
+	}()
+}
+
+// requeueStale periodically returns deliveries whose worker stopped
+// mid-delivery to the queue. Claims younger than webhookClaimTimeout are left
+// alone because another instance may still be sending them.
+func (s *webhookService) requeueStale() {
+	ticker := time.NewTicker(webhookClaimTimeout / 3)
+	defer ticker.Stop()
+
+	for {
+		if err := s.repo.ResetProcessingDeliveries(time.Now().Add(-webhookClaimTimeout)); err != nil {
+			log.Printf("[Webhook] Failed to requeue stale deliveries: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
+func (s *webhookService) processDue() {
+	for {
+		deliveries, err := s.repo.ClaimDueDeliveries(time.Now(), webhookBatchSize)
+		if err != nil {
+			log.Printf("[Webhook] Failed to claim deliveries: %v", err)
+			return
+		}
+		if len(deliveries) == 0 {
+			return
+		}
+
+		// Endpoint yang lambat tidak boleh menahan seluruh antrean
+		var wg sync.WaitGroup
+		slots := make(chan struct{}, webhookConcurrency)
+		for i := range deliveries {
+			wg.Add(1)
+			slots <- struct{}{}
+			go func(delivery *models.WebhookDelivery) {
+				defer wg.Done()
+				defer func() { <-slots }()
+				s.attempt(delivery, true)
+			}(&deliveries[i])
+		}
+		wg.Wait()
+	}
+}
+
+// attempt sends the delivery once and stores the outcome. When retry is
+// true a failed delivery is scheduled again until maxAttempts is reached.
+func (s *webhookService) attempt(delivery *models.WebhookDelivery, retry bool) {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastError = ""
+	delivery.ResponseStatus = nil
+	delivery.ResponseBody = ""
+	delivery.ClaimedAt = nil
+
+	started := time.Now()
+	err := s.send(delivery, now)
+	delivery.DurationMs = time.Since(started).Milliseconds()
+
+	if err == nil {
+		delivery.Status = models.WebhookDeliverySuccess
+		delivery.DeliveredAt = &now
+	} else {
+		delivery.LastError = err.Error()
+		if retry && delivery.Attempts < s.maxAttempts {
+			delivery.Status = models.WebhookDeliveryPending
+			delivery.NextAttemptAt = now.Add(retryBackoff(delivery.Attempts))
+		} else {
+			delivery.Status = models.WebhookDeliveryFailed
+		}
+	}
+
+	updates := map[string]interface{}{
+		"status":          delivery.Status,
+		"attempts":        delivery.Attempts,
+		"next_attempt_at": delivery.NextAttemptAt,
+		"response_status": delivery.ResponseStatus,
+		"response_body":   delivery.ResponseBody,
+		"last_error":      delivery.LastError,
+		"duration_ms":     delivery.DurationMs,
+		"delivered_at":    delivery.DeliveredAt,
+		"claimed_at":      delivery.ClaimedAt,
+	}
+	if err := s.repo.UpdateDelivery(delivery.ID, updates); err != nil {
+		log.Printf("[Webhook] Failed to record delivery %d: %v", delivery.ID, err)
+	}
+}
+
+func (s *webhookService) send(delivery *models.WebhookDelivery, now time.Time) error {
+	body := []byte(delivery.Payload)
+	timestamp := now.Unix()
+
+	req, err := http.NewRequest(http.MethodPost, delivery.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "project-management-backend-webhook")
+	req.Header.Set(WebhookHeaderEvent, delivery.Event)
+	req.Header.Set(WebhookHeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(WebhookHeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookHeaderSignature, SignWebhookPayload(delivery.Webhook.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseBodyLimit))
+	status := resp.StatusCode
+	delivery.ResponseStatus = &status
+	delivery.ResponseBody = string(responseBody)
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("webhook responded with status code %d", status)
+	}
+	return nil
+}
+
+func (s *webhookService) List(workspaceID uint) ([]models.WorkspaceWebhook, error) {
+	return s.repo.GetByWorkspaceID(workspaceID)
+}
+
+func (s *webhookService) Create(workspaceID uint, input WebhookInput, user *models.User) (*models.WorkspaceWebhook, error) {
+	if input.URL == nil {
+		return nil, i18n.NewError(i18n.CodeWebhookURLInvalid)
+	}
+	if err := ValidateWebhookURL(*input.URL); err != nil {
+		return nil, err
+	}
+	events, err := validateWebhookEvents(input.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &models.WorkspaceWebhook{
+		WorkspaceID: workspaceID,
+		URL:         *input.URL,
+		Secret:      secret,
+		Events:      events,
+		IsActive:    input.IsActive == nil || *input.IsActive,
+		CreatedBy:   user.ID,
+	}
+	if err := s.repo.Create(webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *webhookService) Update(workspaceID, webhookID uint, input WebhookInput) (*models.WorkspaceWebhook, error) {
+	webhook, err := s.get(workspaceID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL != nil {
+		if err := ValidateWebhookURL(*input.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = *input.URL
+	}
+	if input.Events != nil {
+		events, err := validateWebhookEvents(input.Events)
+		if err != nil {
+			return nil, err
+		}
+		webhook.Events = events
+	}
+	if input.IsActive != nil {
+		webhook.IsActive = *input.IsActive
+	}
+	if input.RotateSecret {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		webhook.Secret = secret
+	}
+
+	if err := s.repo.Update(webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *webhookService) Delete(workspaceID, webhookID uint) error {
+	if _, err := s.get(workspaceID, webhookID); err != nil {
+		return err
+	}
+	return s.repo.Delete(webhookID)
+}
+
+func (s *webhookService) Deliveries(workspaceID, webhookID uint, limit, offset int) ([]models.WebhookDelivery, int64, error) {
+	if _, err := s.get(workspaceID, webhookID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.GetDeliveries(webhookID, limit, offset)
+}
+
+// SendTest delivers a webhook.test event right away, regardless of the
+// subscribed events, and returns the logged result. It is not retried.
+func (s *webhookService) SendTest(workspaceID, webhookID uint, user *models.User) (*models.WebhookDelivery, error) {
+	webhook, err := s.get(workspaceID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.queue(webhook, models.WebhookEventTest, map[string]interface{}{
+		"webhook_id": webhook.ID,
+		"sent_by":    webhookActor(user),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.attempt(delivery, false)
+	return delivery, nil
+}
+
+// Redeliver sends a logged delivery again with the same payload and
+// returns the new result.
+func (s *webhookService) Redeliver(workspaceID, webhookID, deliveryID uint) (*models.WebhookDelivery, error) {
+	webhook, err := s.get(workspaceID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.repo.GetDeliveryByID(deliveryID)
+	if err != nil || delivery.WebhookID != webhook.ID {
+		return nil, i18n.NewError(i18n.CodeWebhookDeliveryNotFound)
+	}
+	if delivery.Status == models.WebhookDeliveryProcessing {
+		return nil, i18n.NewError(i18n.CodeWebhookDeliveryInProgress)
+	}
+
+	delivery.Webhook = *webhook
+	s.attempt(delivery, false)
+	return delivery, nil
+}
+
+func (s *webhookService) get(workspaceID, webhookID uint) (*models.WorkspaceWebhook, error) {
+	webhook, err := s.repo.GetByID(webhookID)
+	if err != nil || webhook.WorkspaceID != workspaceID {
+		return nil, i18n.NewError(i18n.CodeWebhookNotFound)
+	}
+	return webhook, nil
+}
+
+func validateWebhookEvents(events []string) ([]string, error) {
+	if len(events) == 0 {
+		return nil, i18n.NewError(i18n.CodeWebhookEventsRequired)
+	}
+
+	unique := make([]string, 0, len(events))
+	for _, event := range events {
+		if !containsString(models.WebhookEvents, event) {
+			return nil, i18n.NewError(i18n.CodeWebhookEventUnknown, event)
+		}
+		if !containsString(unique, event) {
+			unique = append(unique, event)
+		}
+	}
+	return unique, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", errors.New("failed to generate webhook secret")
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+// Bentuk data event yang dikirim ke webhook. Field dibatasi agar payload
+// tidak ikut membawa relasi yang tidak perlu.
+
+func webhookActor(user *models.User) map[string]interface{} {
+	if user == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"id":    user.ID,
+		"name":  user.Name,
+		"email": user.Email,
+	}
+}
+
+func webhookTask(task *models.Task) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         task.ID,
+		"project_id": task.ProjectID,
+		"title":      task.Title,
+		"status":     task.Status,
+		"priority":   task.Priority,
+		"start_date": task.StartDate,
+		"due_date":   task.DueDate,
+	}
+}
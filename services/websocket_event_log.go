@@ -0,0 +1,83 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const defaultEventLogSize = 1000
+
+// loggedEvent is an encoded event kept for replay.
+type loggedEvent struct {
+	seq      uint64
+	topics   []string
+	data     []byte
+	audience eventAudience
+}
+
+// eventLog is a bounded ring buffer of the latest events of one workspace.
+// Events from other instances may arrive slightly out of order, so the log
+// tracks the highest sequence it has dropped instead of assuming the buffer
+// is contiguous. It is only used from the hub goroutine.
+type eventLog struct {
+	entries    []loggedEvent
+	next       int
+	count      int
+	lastSeq    uint64
+	maxDropped uint64
+}
+
+func newEventLog(size int) *eventLog {
+	return &eventLog{entries: make([]loggedEvent, size)}
+}
+
+func (l *eventLog) append(event loggedEvent) {
+	if l.count == len(l.entries) {
+		if dropped := l.entries[l.next].seq; dropped > l.maxDropped {
+			l.maxDropped = dropped
+		}
+	} else {
+		l.count++
+	}
+	l.entries[l.next] = event
+	l.next = (l.next + 1) % len(l.entries)
+	if event.seq > l.lastSeq {
+		l.lastSeq = event.seq
+	}
+}
+
+// since returns the events after seq, oldest first. ok is false when seq is
+// unknown or when events after it have already been dropped from the log.
+func (l *eventLog) since(seq uint64) (events []loggedEvent, ok bool) {
+	if seq > l.lastSeq || seq < l.maxDropped {
+		return nil, false
+	}
+
+	for i := 0; i < l.count; i++ {
+		if entry := l.entries[i]; entry.seq > seq {
+			events = append(events, entry)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].seq < events[j].seq })
+	return events, true
+}
+
+// Event ID berbentuk "<epoch>-<seq>". Epoch berubah setiap sequence di-reset
+// (misalnya restart dengan backend memory) sehingga ID lama berujung resync.
+func formatEventID(epoch string, seq uint64) string {
+	return epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+func parseEventID(id string) (epoch string, seq uint64, err error) {
+	epoch, rawSeq, found := strings.Cut(id, "-")
+	if !found {
+		return "", 0, fmt.Errorf("invalid event id %q", id)
+	}
+	seq, err = strconv.ParseUint(rawSeq, 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid event id %q", id)
+	}
+	return epoch, seq, nil
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"log"
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"time"
+)
+
+const (
+	// Koneksi yang mengirim heartbeat dianggap idle jika tidak ada heartbeat
+	// "active" selama durasi ini. Dicek setiap pong (sekitar 1 menit).
+	presenceIdleAfter = 5 * time.Minute
+
+	// Indikator dianggap kedaluwarsa oleh client jika tidak dikirim ulang
+	typingIndicatorTTL  = 10
+	viewingIndicatorTTL = 90
+)
+
+type wsUserStatus struct {
+	Type   string `json:"type"`
+	UserID uint   `json:"user_id"`
+	Status string `json:"status"`
+}
+
+type wsIndicator struct {
+	Type      string      `json:"type"`
+	Topic     string      `json:"topic"`
+	Kind      string      `json:"kind"`
+	Active    bool        `json:"active"`
+	User      interface{} `json:"user"`
+	ExpiresIn int         `json:"expires_in,omitempty"`
+}
+
+// connectionOpened marks the connection online. Status changes are sent to
+// every workspace the user belongs to and to the workspace of the
+// connection, so admins connected without a workspace still show up.
+func (s *webSocketService) connectionOpened(client *models.Client) {
+	client.PresenceWorkspaces = s.presenceWorkspaces(client)
+	client.Presence = models.PresenceOnline
+	s.setPresence(client, models.PresenceOnline)
+}
+
+// connectionClosed removes the connection from the presence of the user and
+// clears the indicators it left active.
+func (s *webSocketService) connectionClosed(client *models.Client) {
+	for topic, kind := range client.Indicators {
+		s.publishIndicator(client, topic, kind, false)
+	}
+	s.setPresence(client, "")
+}
+
+// checkIdle runs on every pong. Connections that never sent a heartbeat keep
+// their state, so older clients are not shown as idle.
+func (s *webSocketService) checkIdle(client *models.Client) {
+	if client.Presence != models.PresenceOnline || client.LastActiveAt.IsZero() {
+		return
+	}
+	if time.Since(client.LastActiveAt) > presenceIdleAfter {
+		client.Presence = models.PresenceIdle
+		s.setPresence(client, models.PresenceIdle)
+	}
+}
+
+func (s *webSocketService) handleHeartbeat(client *models.Client, command wsCommand) {
+	state := models.PresenceOnline
+	switch command.State {
+	case "", "active":
+		client.LastActiveAt = time.Now()
+	case models.PresenceAway:
+		state = models.PresenceAway
+	default:
+		s.replyError(client, command, i18n.NewError(i18n.CodeWsPresenceInvalid, command.State))
+		return
+	}
+
+	if state != client.Presence {
+		client.Presence = state
+		s.setPresence(client, state)
+	}
+}
+
+func (s *webSocketService) handleIndicator(client *models.Client, command wsCommand) {
+	if command.Kind != models.IndicatorViewing && command.Kind != models.IndicatorTyping {
+		s.replyError(client, command, i18n.NewError(i18n.CodeWsIndicatorInvalid, command.Kind))
+		return
+	}
+
+	topic, err := s.resolveTopic(client, command.Topic)
+	if err == nil && !client.Allowed[topic] {
+		err = i18n.NewError(i18n.CodeWsNotSubscribed, topic)
+	}
+	if err != nil {
+		s.replyError(client, command, err)
+		return
+	}
+
+	// Satu koneksi hanya punya satu indikator per topic; typing menggantikan viewing
+	if command.Active {
+		client.Indicators[topic] = command.Kind
+	} else if client.Indicators[topic] == command.Kind {
+		delete(client.Indicators, topic)
+	}
+	s.publishIndicator(client, topic, command.Kind, command.Active)
+}
+
+func (s *webSocketService) publishIndicator(client *models.Client, topic, kind string, active bool) {
+	indicator := wsIndicator{
+		Type:   "indicator",
+		Topic:  topic,
+		Kind:   kind,
+		Active: active,
+		User:   map[string]interface{}{"id": client.UserID, "name": client.UserName},
+	}
+	if active {
+		indicator.ExpiresIn = viewingIndicatorTTL
+		if kind == models.IndicatorTyping {
+			indicator.ExpiresIn = typingIndicatorTTL
+		}
+	}
+	s.publish(realtimeChannelEphemeral, &models.EphemeralMessage{
+		Topics: []string{topic},
+		Data:   encodeWSMessage(indicator),
+	})
+}
+
+// setPresence stores the state of the connection and announces the user's
+// combined state when it changed.
+func (s *webSocketService) setPresence(client *models.Client, state string) {
+	before, after, err := s.backend.UpdatePresence(client.UserID, client.ID, state)
+	if err != nil {
+		log.Printf("[Realtime] Failed to update presence of user %d: %v", client.UserID, err)
+		return
+	}
+	if before == after {
+		return
+	}
+
+	if (before == models.PresenceOffline) != (after == models.PresenceOffline) {
+		s.updateUserOnlineStatus(client.UserID, after != models.PresenceOffline)
+	}
+	s.publishStatus(client.UserID, after, client.PresenceWorkspaces)
+}
+
+// addPresenceWorkspace announces the connection's state to a workspace it
+// subscribed to after connecting.
+func (s *webSocketService) addPresenceWorkspace(client *models.Client, workspaceID uint) {
+	for _, id := range client.PresenceWorkspaces {
+		if id == workspaceID {
+			return
+		}
+	}
+	client.PresenceWorkspaces = append(client.PresenceWorkspaces, workspaceID)
+	s.publishStatus(client.UserID, client.Presence, []uint{workspaceID})
+}
+
+func (s *webSocketService) publishStatus(userID uint, status string, workspaceIDs []uint) {
+	if len(workspaceIDs) == 0 {
+		return
+	}
+	topics := make([]string, 0, len(workspaceIDs))
+	for _, id := range workspaceIDs {
+		topics = append(topics, models.Topic(models.TopicWorkspace, id))
+	}
+	s.publish(realtimeChannelEphemeral, &models.EphemeralMessage{
+		Topics: topics,
+		Data:   encodeWSMessage(wsUserStatus{Type: "user_status", UserID: userID, Status: status}),
+	})
+}
+
+func (s *webSocketService) presenceWorkspaces(client *models.Client) []uint {
+	var ids []uint
+	if client.WorkspaceID != 0 {
+		ids = append(ids, client.WorkspaceID)
+	}
+
+	workspaces, err := s.workspaceRepo.GetWorkspacesByUserID(client.UserID)
+	if err != nil {
+		log.Printf("[Realtime] Failed to load workspaces of user %d: %v", client.UserID, err)
+		return ids
+	}
+	for _, workspace := range workspaces {
+		if workspace.ID != client.WorkspaceID {
+			ids = append(ids, workspace.ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"encoding/json"
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"strconv"
+	"strings"
+)
+
+// Aksi yang bisa dikirim client lewat WebSocket
+const (
+	wsActionSubscribe   = "subscribe"
+	wsActionUnsubscribe = "unsubscribe"
+	wsActionHeartbeat   = "heartbeat"
+	wsActionIndicator   = "indicator"
+)
+
+// wsCommand is a message sent by a client, e.g.
+// {"action": "subscribe", "topic": "project:5", "request_id": "1"}.
+// A subscribe with last_event_id also replays the events missed since then.
+// Clients send {"action": "heartbeat", "state": "active"} about every 30
+// seconds while the user is active ("away" when the app is in the
+// background) and {"action": "indicator", "topic": "task:42", "kind":
+// "typing", "active": true} to show what the user is doing.
+type wsCommand struct {
+	Action      string `json:"action"`
+	Topic       string `json:"topic"`
+	LastEventID string `json:"last_event_id,omitempty"`
+	State       string `json:"state,omitempty"`
+	Kind        string `json:"kind,omitempty"`
+	Active      bool   `json:"active,omitempty"`
+	RequestID   string `json:"request_id,omitempty"`
+}
+
+type wsAck struct {
+	Type      string `json:"type"`
+	Action    string `json:"action"`
+	Topic     string `json:"topic"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// wsResync tells a client that missed events can no longer be replayed. The
+// client should reload the topic and continue from LastEventID.
+type wsResync struct {
+	Type        string `json:"type"`
+	WorkspaceID uint   `json:"workspace_id"`
+	Topic       string `json:"topic"`
+	LastEventID string `json:"last_event_id"`
+}
+
+type wsError struct {
+	Type      string `json:"type"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Action    string `json:"action,omitempty"`
+	Topic     string `json:"topic,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// handleCommand runs on the read goroutine of the client. Access checks that
+// hit the database are done here; the hub only applies the result.
+func (s *webSocketService) handleCommand(client *models.Client, message []byte) {
+	var command wsCommand
+	if err := json.Unmarshal(message, &command); err != nil {
+		s.replyError(client, command, i18n.NewError(i18n.CodeWsMessageInvalid))
+		return
+	}
+
+	switch command.Action {
+	case wsActionSubscribe, wsActionUnsubscribe:
+		var workspaceID uint
+		topic, err := s.resolveTopic(client, command.Topic)
+		if err == nil && command.Action == wsActionSubscribe {
+			workspaceID, err = s.authorizeTopic(client, topic)
+		}
+		if err != nil {
+			s.replyError(client, command, err)
+			return
+		}
+
+		if command.Action == wsActionSubscribe {
+			client.Allowed[topic] = true
+			if strings.HasPrefix(topic, models.TopicWorkspace+":") {
+				s.addPresenceWorkspace(client, workspaceID)
+			}
+		} else {
+			delete(client.Allowed, topic)
+			if kind, ok := client.Indicators[topic]; ok {
+				delete(client.Indicators, topic)
+				s.publishIndicator(client, topic, kind, false)
+			}
+		}
+
+		command.Topic = topic
+		s.hub.Subscriptions <- &models.SubscriptionRequest{
+			Client:      client,
+			Topic:       topic,
+			Subscribe:   command.Action == wsActionSubscribe,
+			WorkspaceID: workspaceID,
+			LastEventID: command.LastEventID,
+			Ack: encodeWSMessage(wsAck{
+				Type:      "ack",
+				Action:    command.Action,
+				Topic:     topic,
+				RequestID: command.RequestID,
+			}),
+			Rejected: s.errorMessage(client, command, i18n.NewError(i18n.CodeWsTooManyTopics, maxSubscriptions)),
+		}
+	case wsActionHeartbeat:
+		s.handleHeartbeat(client, command)
+	case wsActionIndicator:
+		s.handleIndicator(client, command)
+	default:
+		s.replyError(client, command, i18n.NewError(i18n.CodeWsActionUnknown, command.Action))
+	}
+}
+
+// resolveTopic validates a topic name and expands "user:me".
+func (s *webSocketService) resolveTopic(client *models.Client, topic string) (string, error) {
+	kind, rawID, found := strings.Cut(strings.TrimSpace(topic), ":")
+	if !found {
+		return "", i18n.NewError(i18n.CodeWsTopicInvalid, topic)
+	}
+
+	if kind == models.TopicUser && rawID == "me" {
+		return models.Topic(models.TopicUser, client.UserID), nil
+	}
+
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil || id == 0 {
+		return "", i18n.NewError(i18n.CodeWsTopicInvalid, topic)
+	}
+
+	switch kind {
+	case models.TopicWorkspace, models.TopicProject, models.TopicTask, models.TopicUser:
+		return models.Topic(kind, uint(id)), nil
+	}
+	return "", i18n.NewError(i18n.CodeWsTopicInvalid, topic)
+}
+
+// authorizeTopic checks that the client may follow a topic and returns the
+// workspace the topic belongs to (0 for user topics). Besides workspace
+// membership, project topics require project membership and task topics
+// require task or project membership, as the REST endpoints do.
+func (s *webSocketService) authorizeTopic(client *models.Client, topic string) (uint, error) {
+	kind, rawID, _ := strings.Cut(topic, ":")
+	id64, _ := strconv.ParseUint(rawID, 10, 64)
+	id := uint(id64)
+	forbidden := i18n.NewError(i18n.CodeWsTopicForbidden, topic)
+	isAdmin := client.Role == "admin"
+
+	var workspaceID uint
+	switch kind {
+	case models.TopicUser:
+		if id != client.UserID {
+			return 0, forbidden
+		}
+		return 0, nil
+	case models.TopicWorkspace:
+		workspaceID = id
+	case models.TopicProject:
+		project, err := s.projectRepo.GetByID(id)
+		if err != nil {
+			return 0, forbidden
+		}
+		if !isAdmin {
+			isProjectMember, err := s.projectRepo.IsUserMember(project.ID, client.UserID)
+			if err != nil || !isProjectMember {
+				return 0, forbidden
+			}
+		}
+		workspaceID = project.WorkspaceID
+	case models.TopicTask:
+		task, err := s.taskRepo.GetByID(id)
+		if err != nil {
+			return 0, forbidden
+		}
+		if !isAdmin {
+			isTaskMember, _ := s.taskRepo.IsUserMember(task.ID, client.UserID)
+			isProjectMember, _ := s.taskRepo.IsUserInProject(task.ProjectID, client.UserID)
+			if !isTaskMember && !isProjectMember {
+				return 0, forbidden
+			}
+		}
+		workspaceID = task.Project.WorkspaceID
+	}
+
+	if isAdmin {
+		return workspaceID, nil
+	}
+	isMember, err := s.workspaceRepo.IsUserMember(workspaceID, client.UserID)
+	if err != nil || !isMember {
+		return 0, forbidden
+	}
+	return workspaceID, nil
+}
+
+func (s *webSocketService) replyError(client *models.Client, command wsCommand, err error) {
+	s.hub.Reply <- &models.ClientMessage{Client: client, Data: s.errorMessage(client, command, err)}
+}
+
+func (s *webSocketService) errorMessage(client *models.Client, command wsCommand, err error) []byte {
+	code, message := i18n.Localize(client.Lang, err)
+	return encodeWSMessage(wsError{
+		Type:      "error",
+		Code:      code,
+		Message:   message,
+		Action:    command.Action,
+		Topic:     command.Topic,
+		RequestID: command.RequestID,
+	})
+}
+
+// encodeWSMessage marshals values that cannot fail to encode.
+func encodeWSMessage(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
@@ -1,12 +1,16 @@
 package services
 
 import (
+	"encoding/json"
+	"log"
+	"os"
 	"project-management-backend/models"
 	"project-management-backend/repositories"
 	"project-management-backend/utils"
 	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
 
@@ -15,103 +19,400 @@ const (
 	pongWait       = 60 * time.Second
 	pingPeriod     = (pongWait * 9) / 10
 	maxMessageSize = 512
+
+	// Batas topic per koneksi
+	maxSubscriptions = 100
 )
 
 // WebSocketService handles WebSocket connections and communication.
 
 type WebSocketService interface {
+	EventPublisher
 	RunHub()
-	RegisterAndServeClient(conn *websocket.Conn, userID uint, workspaceID uint)
+	RegisterAndServeClient(conn *websocket.Conn, user *models.User, workspaceID uint, lastEventID string)
+	SendToUser(userID uint, message []byte)
+	OpenStream(user *models.User, topics []string, lastEventID string) (*EventStream, error)
+	CloseStream(stream *EventStream)
 }
 
 type webSocketService struct {
 	hub           *models.Hub
+	backend       RealtimeBackend
 	userRepo      repositories.UserRepository
 	workspaceRepo repositories.WorkspaceRepository
 	projectRepo   repositories.ProjectRepository
 	taskRepo      repositories.TaskRepository
+
+	// Log event per workspace untuk replay, hanya dipakai goroutine hub
+	logs    map[uint]*eventLog
+	logSize int
 }
 
-// NewWebSocketService creates a new WebSocketService.
-func NewWebSocketService(userRepo repositories.UserRepository, workspaceRepo repositories.WorkspaceRepository, projectRepo repositories.ProjectRepository, taskRepo repositories.TaskRepository) WebSocketService {
+// NewWebSocketService creates a new WebSocketService. Events, direct
+// messages and presence go through backend so that every instance sharing
+// it serves the same stream.
+func NewWebSocketService(backend RealtimeBackend, userRepo repositories.UserRepository, workspaceRepo repositories.WorkspaceRepository, projectRepo repositories.ProjectRepository, taskRepo repositories.TaskRepository) WebSocketService {
 	hub := &models.Hub{
-		Clients:    make(map[uint]map[*models.Client]bool),
-		Broadcast:  make(chan []byte),
-		Register:   make(chan *models.Client),
-		Unregister: make(chan *models.Client),
+		Clients:       make(map[*models.Client]bool),
+		Topics:        make(map[string]map[*models.Client]bool),
+		Broadcast:     make(chan *models.EventMessage, 256),
+		Direct:        make(chan *models.DirectMessage, 64),
+		Ephemeral:     make(chan *models.EphemeralMessage, 256),
+		Reply:         make(chan *models.ClientMessage, 64),
+		Subscriptions: make(chan *models.SubscriptionRequest, 64),
+		Register:      make(chan *models.Client),
+		Unregister:    make(chan *models.Client),
+	}
+
+	logSize := defaultEventLogSize
+	if n, err := strconv.Atoi(os.Getenv("REALTIME_EVENT_LOG_SIZE")); err == nil && n > 0 {
+		logSize = n
 	}
+
 	return &webSocketService{
 		hub:           hub,
+		backend:       backend,
 		userRepo:      userRepo,
 		workspaceRepo: workspaceRepo,
 		projectRepo:   projectRepo,
 		taskRepo:      taskRepo,
+		logs:          make(map[uint]*eventLog),
+		logSize:       logSize,
 	}
 }
 
-// RunHub runs the WebSocket hub.
+// RunHub runs the WebSocket hub. Only the hub goroutine writes to the Send
+// channel of a client and touches the client and topic maps.
 func (s *webSocketService) RunHub() {
+	if err := s.backend.Subscribe(s.receive); err != nil {
+		log.Fatalf("[Realtime] Failed to subscribe to realtime backend: %v", err)
+	}
+
 	for {
 		select {
 		case client := <-s.hub.Register:
-			if s.hub.Clients[client.WorkspaceID] == nil {
-				s.hub.Clients[client.WorkspaceID] = make(map[*models.Client]bool)
+			s.hub.Clients[client] = true
+			s.subscribe(client, models.Topic(models.TopicUser, client.UserID))
+			if client.WorkspaceID != 0 {
+				topic := models.Topic(models.TopicWorkspace, client.WorkspaceID)
+				s.subscribe(client, topic)
+				s.replay(client, client.WorkspaceID, topic, client.LastEventID)
 			}
-			s.hub.Clients[client.WorkspaceID][client] = true
-			s.updateUserOnlineStatus(client.UserID, true)
-			s.broadcastStatus(client.WorkspaceID, client.UserID, "online")
 
 		case client := <-s.hub.Unregister:
-			if _, ok := s.hub.Clients[client.WorkspaceID][client]; ok {
-				delete(s.hub.Clients[client.WorkspaceID], client)
-				close(client.Send)
-				if len(s.hub.Clients[client.WorkspaceID]) == 0 {
-					delete(s.hub.Clients, client.WorkspaceID)
-				}
+			s.dropClient(client)
+
+		case event := <-s.hub.Broadcast:
+			audience := newEventAudience(event)
+			s.eventLog(event.WorkspaceID).append(loggedEvent{seq: event.Seq, topics: event.Topics, data: event.Data, audience: audience})
+			s.fanOut(event.Topics, event.Data, audience)
+
+		case message := <-s.hub.Ephemeral:
+			s.fanOut(message.Topics, message.Data, nil)
+
+		case direct := <-s.hub.Direct:
+			for client := range s.hub.Topics[models.Topic(models.TopicUser, direct.UserID)] {
+				s.deliver(client, direct.Data)
+			}
+
+		case reply := <-s.hub.Reply:
+			if s.hub.Clients[reply.Client] {
+				s.deliver(reply.Client, reply.Data)
+			}
+
+		case request := <-s.hub.Subscriptions:
+			client := request.Client
+			if !s.hub.Clients[client] {
+				continue
 			}
-			s.updateUserOnlineStatus(client.UserID, false)
-			s.broadcastStatus(client.WorkspaceID, client.UserID, "offline")
-
-		case message := <-s.hub.Broadcast:
-			for _, clients := range s.hub.Clients {
-				for client := range clients {
-					select {
-					case client.Send <- message:
-					default:
-						client.Hub.Unregister <- client
-					}
+			if !request.Subscribe {
+				s.unsubscribe(client, request.Topic)
+			} else if !client.Topics[request.Topic] && len(client.Topics) >= maxSubscriptions {
+				if len(request.Rejected) > 0 {
+					s.deliver(client, request.Rejected)
 				}
+				continue
+			} else {
+				s.subscribe(client, request.Topic)
+			}
+			// Stream SSE tidak menerima ack
+			if len(request.Ack) > 0 {
+				s.deliver(client, request.Ack)
+			}
+			if request.Subscribe {
+				s.replay(client, request.WorkspaceID, request.Topic, request.LastEventID)
 			}
 		}
 	}
 }
 
-// RegisterAndServeClient creates a client and starts serving it.
-func (s *webSocketService) RegisterAndServeClient(conn *websocket.Conn, userID uint, workspaceID uint) {
-	client := &models.Client{Hub: s.hub, Conn: conn, Send: make(chan []byte, 256), UserID: userID, WorkspaceID: workspaceID}
+// PublishEvent assigns the next sequence of the workspace to an event and
+// sends it to every client subscribed to its topics, on all instances.
+func (s *webSocketService) PublishEvent(event *models.RealtimeEvent) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	seq, err := s.backend.NextSequence(event.WorkspaceID)
+	if err != nil {
+		log.Printf("[Realtime] Failed to assign sequence for workspace %d: %v", event.WorkspaceID, err)
+		return
+	}
+	event.Seq = seq
+	event.ID = formatEventID(s.backend.Epoch(), seq)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("[Realtime] Failed to encode %s event: %v", event.Type, err)
+		return
+	}
+	message := &models.EventMessage{
+		WorkspaceID: event.WorkspaceID,
+		Seq:         seq,
+		Topics:      event.Topics(),
+		Data:        data,
+	}
+	if event.ProjectID != 0 {
+		message.Restricted = true
+		message.Audience = s.eventAudienceIDs(event)
+	}
+	s.publish(realtimeChannelEvents, message)
+}
+
+// eventAudienceIDs returns the users who may see a project or task event
+// without being admins: project members, plus task members for task events,
+// the same users the REST task endpoints allow. A failed lookup leaves the
+// event to admins only.
+func (s *webSocketService) eventAudienceIDs(event *models.RealtimeEvent) []uint {
+	projectMembers, err := s.projectRepo.GetMembers(event.ProjectID)
+	if err != nil {
+		log.Printf("[Realtime] Failed to load members of project %d: %v", event.ProjectID, err)
+		return nil
+	}
+	ids := make([]uint, 0, len(projectMembers))
+	for _, member := range projectMembers {
+		ids = append(ids, member.UserID)
+	}
+
+	if event.TaskID != 0 {
+		taskMembers, err := s.taskRepo.GetMembers(event.TaskID)
+		if err != nil {
+			log.Printf("[Realtime] Failed to load members of task %d: %v", event.TaskID, err)
+			return ids
+		}
+		for _, member := range taskMembers {
+			ids = append(ids, member.UserID)
+		}
+	}
+	return ids
+}
+
+// SendToUser queues a message for every open connection of the given user.
+func (s *webSocketService) SendToUser(userID uint, message []byte) {
+	s.publish(realtimeChannelDirect, &models.DirectMessage{UserID: userID, Data: message})
+}
+
+func (s *webSocketService) publish(channel string, message interface{}) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("[Realtime] Failed to encode %s message: %v", channel, err)
+		return
+	}
+	if err := s.backend.Publish(channel, data); err != nil {
+		log.Printf("[Realtime] Failed to publish %s message: %v", channel, err)
+	}
+}
+
+// receive hands a message from the backend to the hub.
+func (s *webSocketService) receive(channel string, data []byte) {
+	var err error
+	switch channel {
+	case realtimeChannelEvents:
+		var message models.EventMessage
+		if err = json.Unmarshal(data, &message); err == nil {
+			s.hub.Broadcast <- &message
+		}
+	case realtimeChannelDirect:
+		var message models.DirectMessage
+		if err = json.Unmarshal(data, &message); err == nil {
+			s.hub.Direct <- &message
+		}
+	case realtimeChannelEphemeral:
+		var message models.EphemeralMessage
+		if err = json.Unmarshal(data, &message); err == nil {
+			s.hub.Ephemeral <- &message
+		}
+	}
+	if err != nil {
+		log.Printf("[Realtime] Dropped invalid %s message: %v", channel, err)
+	}
+}
+
+// RegisterAndServeClient creates a client and starts serving it. A non-zero
+// workspaceID subscribes the client to that workspace right away, replaying
+// the events after lastEventID when it is set.
+func (s *webSocketService) RegisterAndServeClient(conn *websocket.Conn, user *models.User, workspaceID uint, lastEventID string) {
+	client := &models.Client{
+		ID:          uuid.New().String(),
+		Hub:         s.hub,
+		Conn:        conn,
+		Send:        make(chan []byte, 256),
+		UserID:      user.ID,
+		UserName:    user.Name,
+		Role:        user.Role,
+		Lang:        userLanguage(user),
+		WorkspaceID: workspaceID,
+		LastEventID: lastEventID,
+		Topics:      make(map[string]bool),
+		Allowed:     make(map[string]bool),
+		Indicators:  make(map[string]string),
+	}
 	s.hub.Register <- client
+	s.connectionOpened(client)
 
 	go s.writePump(client)
 	go s.readPump(client)
 }
 
+func (s *webSocketService) eventLog(workspaceID uint) *eventLog {
+	log, ok := s.logs[workspaceID]
+	if !ok {
+		log = newEventLog(s.logSize)
+		s.logs[workspaceID] = log
+	}
+	return log
+}
+
+// replay sends the events of a workspace published after lastEventID that
+// match topic. When they are no longer in the log, or the ID comes from a
+// previous server run, the client is asked to resync instead.
+func (s *webSocketService) replay(client *models.Client, workspaceID uint, topic, lastEventID string) {
+	if lastEventID == "" || workspaceID == 0 {
+		return
+	}
+
+	history := s.eventLog(workspaceID)
+	epoch, seq, err := parseEventID(lastEventID)
+	var events []loggedEvent
+	ok := err == nil && epoch == s.backend.Epoch()
+	if ok {
+		events, ok = history.since(seq)
+	}
+	if !ok {
+		s.deliver(client, encodeWSMessage(wsResync{
+			Type:        "resync",
+			WorkspaceID: workspaceID,
+			Topic:       topic,
+			LastEventID: formatEventID(s.backend.Epoch(), history.lastSeq),
+		}))
+		return
+	}
+
+	for _, event := range events {
+		if containsString(event.topics, topic) && event.audience.allows(client) {
+			s.deliver(client, event.data)
+		}
+	}
+}
+
+// fanOut delivers a message to the subscribers of topics that audience
+// allows. A client that follows several of the topics gets it once.
+func (s *webSocketService) fanOut(topics []string, message []byte, audience eventAudience) {
+	sent := make(map[*models.Client]bool)
+	for _, topic := range topics {
+		for client := range s.hub.Topics[topic] {
+			if !sent[client] && audience.allows(client) {
+				sent[client] = true
+				s.deliver(client, message)
+			}
+		}
+	}
+}
+
+// eventAudience holds the non-admin users allowed to receive a restricted
+// event; nil means everyone subscribed may receive it.
+type eventAudience map[uint]bool
+
+func newEventAudience(event *models.EventMessage) eventAudience {
+	if !event.Restricted {
+		return nil
+	}
+	audience := make(eventAudience, len(event.Audience))
+	for _, userID := range event.Audience {
+		audience[userID] = true
+	}
+	return audience
+}
+
+func (a eventAudience) allows(client *models.Client) bool {
+	return a == nil || client.Role == "admin" || a[client.UserID]
+}
+
+func (s *webSocketService) subscribe(client *models.Client, topic string) {
+	if s.hub.Topics[topic] == nil {
+		s.hub.Topics[topic] = make(map[*models.Client]bool)
+	}
+	s.hub.Topics[topic][client] = true
+	client.Topics[topic] = true
+}
+
+func (s *webSocketService) unsubscribe(client *models.Client, topic string) {
+	delete(client.Topics, topic)
+	if clients, ok := s.hub.Topics[topic]; ok {
+		delete(clients, client)
+		if len(clients) == 0 {
+			delete(s.hub.Topics, topic)
+		}
+	}
+}
+
+// deliver queues a message for a client. A client whose buffer is full is
+// dropped; it runs on the hub goroutine, so it cannot send to Unregister.
+func (s *webSocketService) deliver(client *models.Client, message []byte) {
+	if !s.hub.Clients[client] {
+		return
+	}
+	select {
+	case client.Send <- message:
+	default:
+		s.dropClient(client)
+	}
+}
+
+func (s *webSocketService) dropClient(client *models.Client) {
+	if !s.hub.Clients[client] {
+		return
+	}
+	delete(s.hub.Clients, client)
+	for topic := range client.Topics {
+		s.unsubscribe(client, topic)
+	}
+	close(client.Send)
+}
+
 func (s *webSocketService) readPump(client *models.Client) {
 	defer func() {
 		client.Hub.Unregister <- client
 		client.Conn.Close()
+		s.connectionClosed(client)
 	}()
 	client.Conn.SetReadLimit(maxMessageSize)
 	client.Conn.SetReadDeadline(time.Now().Add(pongWait))
-	client.Conn.SetPongHandler(func(string) error { client.Conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
+	client.Conn.SetPongHandler(func(string) error {
+		client.Conn.SetReadDeadline(time.Now().Add(pongWait))
+		s.checkIdle(client)
+		return nil
+	})
 
 	for {
-		_, _, err := client.Conn.ReadMessage()
+		_, message, err := client.Conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				utils.Error(client.UserID, "read_message", "websocket", client.WorkspaceID, err.Error(), "")
 			}
 			break
 		}
+		s.handleCommand(client, message)
 	}
 }
 
@@ -153,19 +454,6 @@ func (s *webSocketService) writePump(client *models.Client) {
 	}
 }
 
-func (s *webSocketService) broadcastStatus(workspaceID, userID uint, status string) {
-	message := []byte(`{"type": "user_status", "user_id": ` + strconv.Itoa(int(userID)) + `, "status": "` + status + `"}`)
-	if clients, ok := s.hub.Clients[workspaceID]; ok {
-		for client := range clients {
-			select {
-			case client.Send <- message:
-			default:
-				client.Hub.Unregister <- client
-			}
-		}
-	}
-}
-
 func (s *webSocketService) updateUserOnlineStatus(userID uint, isOnline bool) {
 	if err := s.userRepo.UpdateUserOnlineStatus(userID, isOnline); err != nil {
 		utils.Error(userID, "update_user_status", "users", userID, err.Error(), "")
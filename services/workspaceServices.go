@@ -2,10 +2,13 @@ package services
 
 import (
 	"errors"
-	"fmt"
+	"log"
 	"project-management-backend/config"
+	"project-management-backend/i18n"
 	"project-management-backend/models"
 	"project-management-backend/repositories"
+
+	"gorm.io/gorm"
 )
 
 type WorkspaceMember struct {
@@ -23,21 +26,26 @@ type WorkspaceService interface {
 	AddMembers(workspaceID uint, members []WorkspaceMember, currentUser *models.User) error
 	RemoveMembers(workspaceID uint, userIDs []uint, currentUser *models.User) error
 	RemoveMember(workspaceID uint, userID uint, currentUser *models.User) error
+	SetRequireAdminTwoFactor(workspaceID uint, required bool, user *models.User) error
+	CheckAdminTwoFactor(workspaceID uint, user *models.User) error
+	CheckProjectAdminTwoFactor(projectID uint, user *models.User) error
 }
 
 type workspaceService struct {
 	repo        repositories.WorkspaceRepository
 	projectRepo repositories.ProjectRepository
 	taskRepo    repositories.TaskRepository
+	mailer      Mailer
+	events      EventPublisher
 }
 
-func NewWorkspaceService(r repositories.WorkspaceRepository, p repositories.ProjectRepository, t repositories.TaskRepository) WorkspaceService {
-	return &workspaceService{repo: r, projectRepo: p, taskRepo: t}
+func NewWorkspaceService(r repositories.WorkspaceRepository, p repositories.ProjectRepository, t repositories.TaskRepository, mailer Mailer, events EventPublisher) WorkspaceService {
+	return &workspaceService{repo: r, projectRepo: p, taskRepo: t, mailer: mailer, events: events}
 }
 
 func (s *workspaceService) CreateWorkspace(workspace *models.Workspace, user *models.User) error {
 	if user.Role != "admin" {
-		return errors.New("hanya admin yang boleh buat workspace")
+		return i18n.NewError(i18n.CodeAdminOnlyCreateWorkspace)
 	}
 	workspace.CreatedBy = user.ID
 
@@ -54,7 +62,7 @@ func (s *workspaceService) CreateWorkspace(workspace *models.Workspace, user *mo
 
 	if err := s.repo.AddMember(creatorMember); err != nil {
 		s.repo.DeleteWorkspace(workspace.ID)
-		return errors.New("gagal menambahkan creator sebagai member workspace")
+		return i18n.NewError(i18n.CodeWorkspaceCreatorMemberFailed)
 	}
 
 	return nil
@@ -70,13 +78,13 @@ func (s *workspaceService) GetAllWorkspaces(user *models.User) ([]models.Workspa
 func (s *workspaceService) GetByID(workspaceID uint, user *models.User) (*models.Workspace, error) {
 	workspace, err := s.repo.GetByID(workspaceID)
 	if err != nil {
-		return nil, errors.New("workspace tidak ditemukan")
+		return nil, i18n.NewError(i18n.CodeWorkspaceNotFound)
 	}
 
 	if user.Role != "admin" {
 		isMember, err := s.repo.IsUserMember(workspaceID, user.ID)
 		if err != nil || !isMember {
-			return nil, errors.New("akses ditolak untuk workspace ini")
+			return nil, i18n.NewError(i18n.CodeWorkspaceAccessDenied)
 		}
 	}
 
@@ -87,11 +95,11 @@ func (s *workspaceService) UpdateWorkspace(workspace *models.Workspace, user *mo
 
 	existingWorkspace, err := s.repo.GetByID(workspace.ID)
 	if err != nil {
-		return errors.New("workspace tidak ditemukan")
+		return i18n.NewError(i18n.CodeWorkspaceNotFound)
 	}
 
 	if user.Role != "admin" && existingWorkspace.CreatedBy != user.ID {
-		return errors.New("hanya creator workspace yang boleh mengupdate")
+		return i18n.NewError(i18n.CodeWorkspaceCreatorOnlyUpdate)
 	}
 
 	return s.repo.UpdateWorkspace(workspace)
@@ -100,25 +108,27 @@ func (s *workspaceService) UpdateWorkspace(workspace *models.Workspace, user *mo
 func (s *workspaceService) SoftDeleteWorkspace(workspaceID uint, user *models.User) error {
 	workspace, err := s.repo.GetByID(workspaceID)
 	if err != nil {
-		return errors.New("workspace tidak ditemukan")
+		return i18n.NewError(i18n.CodeWorkspaceNotFound)
 	}
 
 	if user.Role != "admin" && workspace.CreatedBy != user.ID {
-		return errors.New("hanya creator workspace yang boleh soft delete")
+		return i18n.NewError(i18n.CodeWorkspaceCreatorOnlySoftDelete)
 	}
 
 	projects, err := s.projectRepo.GetProjectsByWorkspace(workspaceID)
 	if err != nil {
-		return errors.New("gagal mengambil data projects")
+		return i18n.NewError(i18n.CodeProjectFetchFailed)
 	}
 
 	for _, project := range projects {
 		if err := s.projectRepo.SoftDeleteProject(project.ID); err != nil {
-			return fmt.Errorf("gagal soft delete project %d: %w", project.ID, err)
+			log.Printf("[Workspace] Failed to soft delete project %d: %v", project.ID, err)
+			return i18n.NewError(i18n.CodeProjectDeleteFailed, project.ID)
 		}
 
 		if err := s.taskRepo.SoftDeleteAllTasksInProject(project.ID); err != nil {
-			return fmt.Errorf("gagal soft delete tasks project %d: %w", project.ID, err)
+			log.Printf("[Workspace] Failed to soft delete tasks of project %d: %v", project.ID, err)
+			return i18n.NewError(i18n.CodeProjectTasksDeleteFailed, project.ID)
 		}
 	}
 
@@ -130,34 +140,34 @@ func (s *workspaceService) DeleteWorkspace(workspaceID uint, user *models.User)
 	var workspace models.Workspace
 	err := config.DB.Unscoped().Where("id = ?", workspaceID).First(&workspace).Error
 	if err != nil {
-		return errors.New("workspace tidak ditemukan")
+		return i18n.NewError(i18n.CodeWorkspaceNotFound)
 	}
 
 	if user.Role != "admin" && workspace.CreatedBy != user.ID {
-		return errors.New("hanya creator workspace yang boleh hard delete")
+		return i18n.NewError(i18n.CodeWorkspaceCreatorOnlyHardDelete)
 	}
 
 	return s.repo.DeleteWorkspace(workspaceID)
 }
 
 func (s *workspaceService) AddMembers(workspaceID uint, members []WorkspaceMember, currentUser *models.User) error {
-	_, err := s.repo.GetByID(workspaceID)
+	workspace, err := s.repo.GetByID(workspaceID)
 	if err != nil {
-		return errors.New("workspace tidak ditemukan")
+		return i18n.NewError(i18n.CodeWorkspaceNotFound)
 	}
 
 	for _, member := range members {
-		_, err := s.repo.GetUserByID(member.UserID)
+		user, err := s.repo.GetUserByID(member.UserID)
 		if err != nil {
-			return fmt.Errorf("user %d tidak ditemukan", member.UserID)
+			return i18n.NewError(i18n.CodeMemberUserNotFound, member.UserID)
 		}
 
 		isMember, err := s.repo.IsUserMember(workspaceID, member.UserID)
 		if err != nil {
-			return fmt.Errorf("gagal memvalidasi member %d", member.UserID)
+			return i18n.NewError(i18n.CodeMemberValidationFailed, member.UserID)
 		}
 		if isMember {
-			return fmt.Errorf("user %d sudah menjadi member di workspace ini", member.UserID)
+			return i18n.NewError(i18n.CodeMemberAlreadyInWorkspace, member.UserID)
 		}
 
 		workspaceMember := &models.WorkspaceUser{
@@ -166,24 +176,53 @@ func (s *workspaceService) AddMembers(workspaceID uint, members []WorkspaceMembe
 			RoleInWorkspace: member.Role,
 		}
 		if err := s.repo.AddMember(workspaceMember); err != nil {
-			return fmt.Errorf("gagal menambahkan user %d", member.UserID)
+			return i18n.NewError(i18n.CodeMemberAddFailed, member.UserID)
+		}
+
+		go s.sendInvitation(workspace, user, currentUser)
+
+		role := ""
+		if member.Role != nil {
+			role = *member.Role
 		}
+		s.events.PublishEvent(newRealtimeEvent(models.EventMemberAdded, workspaceID, currentUser, memberAddedData("workspace", user, role)))
 	}
 
 	return nil
 }
 
+// sendInvitation memberi tahu user lewat email bahwa ia ditambahkan ke
+// workspace. Kegagalan hanya dicatat di log.
+func (s *workspaceService) sendInvitation(workspace *models.Workspace, user *models.User, inviter *models.User) {
+	if user.Email == "" {
+		return
+	}
+
+	msg, err := RenderMail(MailTemplateInvitation, userLanguage(user), MailData{
+		"Name":          user.Name,
+		"InviterName":   actorName(inviter),
+		"WorkspaceName": workspace.Name,
+	})
+	if err == nil {
+		msg.To = []string{user.Email}
+		err = s.mailer.Send(msg)
+	}
+	if err != nil {
+		log.Printf("[Workspace] Failed to send invitation for workspace %d to user %d: %v", workspace.ID, user.ID, err)
+	}
+}
+
 func (s *workspaceService) GetMembers(workspaceID uint, user *models.User) ([]models.WorkspaceUser, error) {
 
 	_, err := s.repo.GetByID(workspaceID)
 	if err != nil {
-		return nil, errors.New("workspace tidak ditemukan")
+		return nil, i18n.NewError(i18n.CodeWorkspaceNotFound)
 	}
 
 	if user.Role != "admin" {
 		isMember, err := s.repo.IsUserMember(workspaceID, user.ID)
 		if err != nil || !isMember {
-			return nil, errors.New("anda bukan member")
+			return nil, i18n.NewError(i18n.CodeNotMember)
 		}
 	}
 
@@ -193,12 +232,12 @@ func (s *workspaceService) GetMembers(workspaceID uint, user *models.User) ([]mo
 func (s *workspaceService) RemoveMember(workspaceID uint, userID uint, currentUser *models.User) error {
 	workspace, err := s.repo.GetByID(workspaceID)
 	if err != nil {
-		return errors.New("workspace tidak ditemukan")
+		return i18n.NewError(i18n.CodeWorkspaceNotFound)
 	}
 
 	targetMember, err := s.repo.GetWorkspaceMember(workspaceID, userID)
 	if err != nil {
-		return errors.New("member tidak ditemukan di workspace ini")
+		return i18n.NewError(i18n.CodeWorkspaceMemberNotFound)
 	}
 
 	if currentUser.Role != "admin" {
@@ -207,21 +246,21 @@ func (s *workspaceService) RemoveMember(workspaceID uint, userID uint, currentUs
 
 			currentMember, err := s.repo.GetWorkspaceMember(workspaceID, currentUser.ID)
 			if err != nil {
-				return errors.New("anda bukan member workspace ini")
+				return i18n.NewError(i18n.CodeNotWorkspaceMember)
 			}
 
 			if *currentMember.RoleInWorkspace != "admin" {
-				return errors.New("role Anda tidak cukup untuk menghapus member")
+				return i18n.NewError(i18n.CodeMemberRemoveRoleInsufficient)
 			}
 
 			if targetMember.RoleInWorkspace != nil && *targetMember.RoleInWorkspace == "owner" {
-				return errors.New("tidak bisa menghapus owner workspace")
+				return i18n.NewError(i18n.CodeCannotRemoveWorkspaceOwner)
 			}
 		}
 	}
 
 	if userID == currentUser.ID {
-		return errors.New("tidak bisa menghapus diri sendiri")
+		return i18n.NewError(i18n.CodeCannotRemoveSelf)
 	}
 
 	return s.repo.RemoveMember(workspaceID, userID)
@@ -230,17 +269,17 @@ func (s *workspaceService) RemoveMember(workspaceID uint, userID uint, currentUs
 func (s *workspaceService) RemoveMembers(workspaceID uint, userIDs []uint, currentUser *models.User) error {
 	workspace, err := s.repo.GetByID(workspaceID)
 	if err != nil {
-		return errors.New("workspace tidak ditemukan")
+		return i18n.NewError(i18n.CodeWorkspaceNotFound)
 	}
 
 	if currentUser.Role != "admin" {
 		if workspace.CreatedBy != currentUser.ID {
 			currentMember, err := s.repo.GetWorkspaceMember(workspaceID, currentUser.ID)
 			if err != nil {
-				return errors.New("anda bukan member workspace ini")
+				return i18n.NewError(i18n.CodeNotWorkspaceMember)
 			}
 			if *currentMember.RoleInWorkspace != "admin" {
-				return errors.New("role Anda tidak cukup untuk menghapus member")
+				return i18n.NewError(i18n.CodeMemberRemoveRoleInsufficient)
 			}
 		}
 	}
@@ -248,13 +287,68 @@ func (s *workspaceService) RemoveMembers(workspaceID uint, userIDs []uint, curre
 	for _, userID := range userIDs {
 		_, err := s.repo.GetWorkspaceMember(workspaceID, userID)
 		if err != nil {
-			return fmt.Errorf("user %d tidak ditemukan di workspace ini", userID)
+			return i18n.NewError(i18n.CodeMemberNotFoundInWorkspace, userID)
 		}
 
 		if userID == currentUser.ID {
-			return fmt.Errorf("tidak bisa menghapus diri sendiri (user_id: %d)", userID)
+			return i18n.NewError(i18n.CodeCannotRemoveSelfID, userID)
 		}
 	}
 
 	return s.repo.RemoveMembers(workspaceID, userIDs)
 }
+
+// SetRequireAdminTwoFactor turns the two-factor requirement for workspace
+// admins on or off. The user changing it must have two-factor
+// authentication enabled so they cannot lock themselves out.
+func (s *workspaceService) SetRequireAdminTwoFactor(workspaceID uint, required bool, user *models.User) error {
+	workspace, err := s.repo.GetByID(workspaceID)
+	if err != nil {
+		return i18n.NewError(i18n.CodeWorkspaceNotFound)
+	}
+
+	if user.Role != "admin" && workspace.CreatedBy != user.ID {
+		return i18n.NewError(i18n.CodeWorkspaceCreatorOnlyUpdate)
+	}
+	if !user.TwoFactorEnabled {
+		return i18n.NewError(i18n.CodeWorkspaceTwoFactorRequired)
+	}
+
+	return s.repo.SetRequireAdminTwoFactor(workspaceID, required)
+}
+
+// CheckAdminTwoFactor applies the workspace two-factor policy to an admin
+// acting on the workspace. A missing workspace passes so the handler can
+// report it.
+func (s *workspaceService) CheckAdminTwoFactor(workspaceID uint, user *models.User) error {
+	workspace, err := s.repo.GetByID(workspaceID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return i18n.NewError(i18n.CodeFetchFailed, "workspace")
+	}
+	return requireAdminTwoFactor(workspace, user)
+}
+
+// CheckProjectAdminTwoFactor is CheckAdminTwoFactor for the workspace that
+// owns the project.
+func (s *workspaceService) CheckProjectAdminTwoFactor(projectID uint, user *models.User) error {
+	project, err := s.projectRepo.GetByID(projectID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return i18n.NewError(i18n.CodeFetchFailed, "project")
+	}
+	return requireAdminTwoFactor(&project.Workspace, user)
+}
+
+// requireAdminTwoFactor blocks admin actions on a workspace that requires
+// two-factor authentication when the acting user has not enabled it.
+func requireAdminTwoFactor(workspace *models.Workspace, user *models.User) error {
+	if workspace.RequireAdminTwoFactor && !user.TwoFactorEnabled {
+		return i18n.NewError(i18n.CodeWorkspaceTwoFactorRequired)
+	}
+	return nil
+}
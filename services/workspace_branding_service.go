@@ -0,0 +1,221 @@
+package services
+
+import (
+	"bytes"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+	"project-management-backend/repositories"
+	pdf_templates "project-management-backend/services/pdf_templates"
+
+	"github.com/google/uuid"
+)
+
+type WorkspaceBrandingInput struct {
+	OrganisationName string `json:"organisation_name"`
+	AddressLine      string `json:"address_line"`
+	FooterText       string `json:"footer_text"`
+	AccentColor      string `json:"accent_color"`
+}
+
+type WorkspaceBrandingService interface {
+	GetBranding(workspaceID uint, user *models.User) (*models.WorkspaceBranding, error)
+	UpdateBranding(workspaceID uint, input WorkspaceBrandingInput, user *models.User) (*models.WorkspaceBranding, error)
+	UploadLogo(workspaceID uint, file *multipart.FileHeader, user *models.User) (*models.WorkspaceBranding, error)
+	DeleteLogo(workspaceID uint, user *models.User) (*models.WorkspaceBranding, error)
+	Preview(workspaceID uint, user *models.User, lang string) ([]byte, error)
+}
+
+type workspaceBrandingService struct {
+	repo          repositories.WorkspaceBrandingRepository
+	workspaceRepo repositories.WorkspaceRepository
+}
+
+func NewWorkspaceBrandingService(repo repositories.WorkspaceBrandingRepository, workspaceRepo repositories.WorkspaceRepository) WorkspaceBrandingService {
+	return &workspaceBrandingService{
+		repo:          repo,
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+func (s *workspaceBrandingService) checkAccess(workspaceID uint, user *models.User) (*models.Workspace, error) {
+	workspace, err := s.workspaceRepo.GetByID(workspaceID)
+	if err != nil {
+		return nil, i18n.NewError(i18n.CodeWorkspaceNotFound)
+	}
+
+	if user.Role != "admin" {
+		isMember, err := s.workspaceRepo.IsUserMember(workspaceID, user.ID)
+		if err != nil || !isMember {
+			return nil, i18n.NewError(i18n.CodeWorkspaceAccessDenied)
+		}
+	}
+
+	return workspace, nil
+}
+
+// getOrNew returns the stored branding, or an empty one that falls back to the defaults.
+func (s *workspaceBrandingService) getOrNew(workspaceID uint) *models.WorkspaceBranding {
+	branding, err := s.repo.GetByWorkspaceID(workspaceID)
+	if err != nil {
+		return &models.WorkspaceBranding{WorkspaceID: workspaceID}
+	}
+	return branding
+}
+
+func (s *workspaceBrandingService) GetBranding(workspaceID uint, user *models.User) (*models.WorkspaceBranding, error) {
+	if _, err := s.checkAccess(workspaceID, user); err != nil {
+		return nil, err
+	}
+	return s.getOrNew(workspaceID), nil
+}
+
+func (s *workspaceBrandingService) UpdateBranding(workspaceID uint, input WorkspaceBrandingInput, user *models.User) (*models.WorkspaceBranding, error) {
+	if _, err := s.checkAccess(workspaceID, user); err != nil {
+		return nil, err
+	}
+
+	accent := strings.TrimSpace(input.AccentColor)
+	if accent != "" {
+		if _, _, _, err := pdf_templates.ParseHexColor(accent); err != nil {
+			return nil, i18n.NewError(i18n.CodeAccentColorInvalid)
+		}
+	}
+
+	branding := s.getOrNew(workspaceID)
+	branding.OrganisationName = strings.TrimSpace(input.OrganisationName)
+	branding.AddressLine = strings.TrimSpace(input.AddressLine)
+	branding.FooterText = strings.TrimSpace(input.FooterText)
+	branding.AccentColor = strings.ToUpper(accent)
+
+	if err := s.repo.Save(branding); err != nil {
+		return nil, i18n.NewError(i18n.CodeBrandingSaveFailed, err.Error())
+	}
+	return branding, nil
+}
+
+func (s *workspaceBrandingService) UploadLogo(workspaceID uint, file *multipart.FileHeader, user *models.User) (*models.WorkspaceBranding, error) {
+	if _, err := s.checkAccess(workspaceID, user); err != nil {
+		return nil, err
+	}
+
+	if file.Size > 2*1024*1024 {
+		return nil, i18n.NewError(i18n.CodeFileTooLarge, "2MB")
+	}
+
+	// Jenis file ditentukan dari isinya, bukan header atau nama file.
+	// gofpdf memilih parser dari ekstensi dan hanya mendukung PNG dan JPEG.
+	ext, err := detectLogoExtension(file)
+	if err != nil {
+		return nil, err
+	}
+
+	uploadDir := "./uploads/branding"
+	if err := os.MkdirAll(uploadDir, 0755); err != nil {
+		return nil, i18n.NewError(i18n.CodeUploadDirFailed)
+	}
+
+	fileName := uuid.New().String() + ext
+	filePath := filepath.Join(uploadDir, fileName)
+	if err := saveUploadedFile(file, filePath); err != nil {
+		return nil, i18n.NewError(i18n.CodeFileSaveFailed, err.Error())
+	}
+
+	branding := s.getOrNew(workspaceID)
+	oldLogo := branding.LogoURL
+	relativeURL := "/uploads/branding/" + fileName
+	branding.LogoURL = &relativeURL
+
+	if err := s.repo.Save(branding); err != nil {
+		os.Remove(filePath)
+		return nil, i18n.NewError(i18n.CodeBrandingSaveFailed, err.Error())
+	}
+
+	if oldLogo != nil && *oldLogo != "" {
+		os.Remove("." + *oldLogo)
+	}
+
+	return branding, nil
+}
+
+func (s *workspaceBrandingService) DeleteLogo(workspaceID uint, user *models.User) (*models.WorkspaceBranding, error) {
+	if _, err := s.checkAccess(workspaceID, user); err != nil {
+		return nil, err
+	}
+
+	branding, err := s.repo.GetByWorkspaceID(workspaceID)
+	if err != nil || branding.LogoURL == nil {
+		return nil, i18n.NewError(i18n.CodeWorkspaceLogoNotFound)
+	}
+
+	oldLogo := *branding.LogoURL
+	branding.LogoURL = nil
+	if err := s.repo.Save(branding); err != nil {
+		return nil, i18n.NewError(i18n.CodeBrandingSaveFailed, err.Error())
+	}
+	os.Remove("." + oldLogo)
+
+	return branding, nil
+}
+
+func (s *workspaceBrandingService) Preview(workspaceID uint, user *models.User, lang string) ([]byte, error) {
+	workspace, err := s.checkAccess(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf, err := pdf_templates.GenerateBrandingPreview(workspace, s.getOrNew(workspaceID), lang)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// detectLogoExtension decodes the image header of file and returns the
+// extension for its format. Only PNG and JPEG that gofpdf can embed are
+// accepted.
+func detectLogoExtension(file *multipart.FileHeader) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", i18n.NewError(i18n.CodeLogoTypeNotAllowed)
+	}
+	defer src.Close()
+
+	_, format, err := image.DecodeConfig(src)
+	if err != nil {
+		return "", i18n.NewError(i18n.CodeLogoTypeNotAllowed)
+	}
+
+	var ext string
+	switch format {
+	case "png":
+		ext = ".png"
+	case "jpeg":
+		ext = ".jpg"
+	default:
+		return "", i18n.NewError(i18n.CodeLogoTypeNotAllowed)
+	}
+
+	// Logo yang lolos DecodeConfig belum tentu bisa dipakai gofpdf,
+	// misalnya PNG interlaced
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return "", i18n.NewError(i18n.CodeLogoTypeNotAllowed)
+	}
+	if err := pdf_templates.ValidateLogo(src, ext[1:]); err != nil {
+		return "", i18n.NewError(i18n.CodeLogoTypeNotAllowed)
+	}
+	return ext, nil
+}
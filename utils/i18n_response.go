@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"project-management-backend/i18n"
+	"project-management-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestLanguage returns the language for the current request: ?lang, then
+// the authenticated user's preference, then Accept-Language.
+func RequestLanguage(c *gin.Context) string {
+	preferred := ""
+	if value, exists := c.Get("currentUser"); exists {
+		if user, ok := value.(*models.User); ok && user.Language != nil {
+			preferred = *user.Language
+		}
+	}
+	return i18n.Resolve(c.Query("lang"), preferred, c.GetHeader("Accept-Language"))
+}
+
+// RespondError writes err as {"error": <localized message>, "code": <code>}.
+// Errors without a code keep their original message and omit "code".
+func RespondError(c *gin.Context, status int, err error) {
+	code, message := i18n.Localize(RequestLanguage(c), err)
+	body := gin.H{"error": message}
+	if code != "" {
+		body["code"] = code
+	}
+	c.JSON(status, body)
+}
+
+// RespondCode is RespondError for a catalog code.
+func RespondCode(c *gin.Context, status int, code string, args ...interface{}) {
+	RespondError(c, status, i18n.NewError(code, args...))
+}